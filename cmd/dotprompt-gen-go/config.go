@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/config"
+	"github.com/oter/dotprompt-gen-go/internal/template"
+)
+
+// explicitFlags returns the names of flags set on the command line.
+func explicitFlags() map[string]bool {
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	return explicit
+}
+
+// applyConfig loads a config file into the generator. Settings given explicitly on the
+// command line win over the config file; in verbose mode the source of each setting is logged.
+func applyConfig(gen *codegen.Generator, path string, explicit map[string]bool) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	sources := make(map[string]string)
+
+	sources["pkg"] = settingSource(explicit["pkg"], cfg.Package != nil)
+	if sources["pkg"] == sourceConfig {
+		gen.PackageName = *cfg.Package
+	}
+
+	sources["out"] = settingSource(explicit["out"], cfg.Out != nil)
+	if sources["out"] == sourceConfig {
+		gen.OutputDir = *cfg.Out
+	}
+
+	sources["v"] = settingSource(explicit["v"], cfg.Verbose != nil)
+	if sources["v"] == sourceConfig {
+		gen.Verbose = *cfg.Verbose
+	}
+
+	gen.TypeMappings = cfg.TypeMappings
+	gen.Initialisms = cfg.Initialisms
+	gen.Imports = append(gen.Imports, cfg.Imports...)
+
+	// Helpers registered with -helper win over config entries of the same name
+	helpers, err := template.ParseHelperSpecs(cfg.Helpers)
+	if err != nil {
+		return err
+	}
+
+	for name, spec := range gen.Helpers {
+		helpers[name] = spec
+	}
+
+	gen.Helpers = helpers
+
+	if gen.Verbose {
+		fmt.Printf("Loaded config file: %s\n", path)
+		fmt.Printf("  package: %q (%s)\n", gen.PackageName, sources["pkg"])
+		fmt.Printf("  out: %q (%s)\n", gen.OutputDir, sources["out"])
+		fmt.Printf("  verbose: %t (%s)\n", gen.Verbose, sources["v"])
+		fmt.Printf("  type_mappings: %v (%s)\n", gen.TypeMappings, settingSource(false, len(cfg.TypeMappings) > 0))
+		fmt.Printf("  initialisms: %v (%s)\n", gen.Initialisms, settingSource(false, len(cfg.Initialisms) > 0))
+		fmt.Printf("  imports: %v (%s)\n", gen.Imports, settingSource(explicit["import"], len(cfg.Imports) > 0))
+		fmt.Printf("  helpers: %v (%s)\n", cfg.Helpers, settingSource(false, len(cfg.Helpers) > 0))
+	}
+
+	return nil
+}
+
+const (
+	sourceFlag    = "flag"
+	sourceConfig  = "config"
+	sourceDefault = "default"
+)
+
+// settingSource reports where a setting's value comes from, with flags taking precedence.
+func settingSource(fromFlag, fromConfig bool) string {
+	switch {
+	case fromFlag:
+		return sourceFlag
+	case fromConfig:
+		return sourceConfig
+	default:
+		return sourceDefault
+	}
+}
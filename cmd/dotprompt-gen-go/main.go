@@ -11,24 +11,90 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
 
 	"github.com/oter/dotprompt-gen-go/internal/codegen"
 	"github.com/oter/dotprompt-gen-go/internal/generator"
+	"github.com/oter/dotprompt-gen-go/internal/template"
 )
 
 func main() {
 	var (
 		inputFile = flag.String("file", "", "Single .prompt file to process")
 		inputDir  = flag.String("dir", "", "Directory containing .prompt files")
-		outputPkg = flag.String("pkg", "models", "Output package name")
+		outputPkg = flag.String("pkg", "", "Output package name (default: the output directory name)")
 		outputDir = flag.String("out", "", "Output directory (default: same as input)")
 		verbose   = flag.Bool("v", false, "Verbose output")
+		cfgFile   = flag.String("config", "", "YAML config file with generation options (flags override it)")
+		errTypes  = flag.Bool("error-types", false, "Generate typed error values for output error_code enums")
+		reset     = flag.Bool("reset", false, "Generate Reset() methods to zero structs for pooling")
+		enumBase  = flag.String("enum-base-type", "string", "Underlying type for string enums (e.g. a shared EnumBase type)")
+		keepGoing = flag.Bool("keep-going", false, "Report all independent errors (schema and template) instead of stopping at the first")
+		language  = flag.String("lang", "go", "Output language: go or zod (TypeScript Zod schemas)")
+		omitEmpty = flag.Bool("omitempty-optional", false, "Deprecated, has no effect and prints a warning: optional fields get omitempty by default, see -no-omitempty")
+		buildChk  = flag.Bool("go-build-check", false, "Type-check generated Go code, and its output package once written, and fail on compile errors")
+		examples  = flag.Bool("example-structs", false, "Generate Example<Name>() constructors with valid enum values")
+		maxLine   = flag.Int("max-line-length", generator.DefaultMaxLineLength, "Wrap generated comment lines longer than this width (0 disables)")
+		valAll    = flag.Bool("validate-all", false, "Generate ValidateAll() methods returning every field validation error")
+		strictEnm = flag.Bool("strict-enums", false, "Generate MarshalJSON/UnmarshalJSON on enums that reject invalid values, and Validate<Enum>Set/Has<Enum> helpers for array-of-enum fields")
+		inSuffix  = flag.String("input-suffix", generator.DefaultInputSuffix, "Suffix for input struct names")
+		outSuffix = flag.String("output-suffix", generator.DefaultOutputSuffix, "Suffix for output struct names")
+		allowDups = flag.Bool("allow-duplicate-types", false, "Allow prompt files in one directory run to declare the same type names")
+		dryRun    = flag.Bool("dry-run", false, "Print a diff of what would be generated without writing files; exit non-zero if any file would change")
+		ctors     = flag.Bool("constructors", false, "Generate New<Name>() constructors for input structs taking their required fields")
+		noTime    = flag.Bool("no-time-types", false, "Keep date-time formatted strings as string instead of time.Time")
+		noOmit    = flag.Bool("no-omitempty", false, "Do not add omitempty to optional pointer, slice and map fields")
+		unions    = flag.Bool("experimental-unions", false, "Generate interfaces and variant structs for oneOf/anyOf fields with a discriminator")
+		structVal = flag.Bool("struct-validate", false, "Generate struct-level Validate() methods recursing into enum and nested struct fields")
+		strictEnN = flag.Bool("strict-enum-names", false, "Fail when enum values map to the same Go constant name instead of numbering them")
+		noInit    = flag.Bool("no-initialisms", false, "Do not upper-case initialisms like ID and URL in generated names (user_id becomes UserId)")
+		consTags  = flag.Bool("constraint-tags", false, "Translate length, size, range and pattern constraints into validate struct tags")
+		single    = flag.String("single-file", "", "Generate every prompt of -dir into this one file (relative to -out or -dir), sharing identical enums")
+		dedupe    = flag.Bool("dedupe-structs", false, "Collapse nested structs with identical fields into one shared type")
+		getters   = flag.Bool("getters", false, "Generate nil-safe Get<Field>() methods returning zero values for nil pointers")
+		strictTpl = flag.Bool("strict-template", false, "Fail generation on undefined template variables and unknown helpers instead of warning")
+		mirror    = flag.Bool("mirror-tree", false, "Mirror -dir subdirectories under -out, naming each subdirectory's package after its folder")
+		emitRendr = flag.Bool("emit-render", false, "Embed each prompt template and generate a Render() method on input structs")
+		strict    = flag.Bool("strict", false, "Fail generation when a required field name is not defined in its schema instead of warning")
+		noMkdir   = flag.Bool("no-mkdir", false, "Fail when the -out directory does not exist instead of creating it")
+		ignoreTtl = flag.Bool("ignore-title", false, "Name structs after the prompt file and field path even when their JSON Schema has a title")
+		recursive = flag.Bool("recursive", true, "Search subdirectories of -dir for .prompt files; -recursive=false only processes -dir itself")
+		maxDepth  = flag.Int("max-depth", 0, "Search at most this many subdirectory levels below -dir (0: unlimited)")
+		emitTests = flag.Bool("emit-tests", false, "Write a <name>.gen_test.go JSON round-trip test next to every generated Go file")
+		tmplFile  = flag.String("template", "", "Go text/template file executed against codegen.TemplateData instead of the built-in template")
+		emitMeta  = flag.Bool("emit-metadata", false, "Generate <Prompt>Model and <Prompt>Config declarations from the frontmatter model and config")
+		jobs      = flag.Int("jobs", 0, "Render at most this many prompt files of -dir concurrently (0: GOMAXPROCS)")
+		force     = flag.Bool("force", false, "Regenerate every prompt file even when its output records an unchanged input hash")
+		emitZero  = flag.Bool("emit-iszero", false, "Generate IsZero() methods reporting whether every struct field holds its zero value")
+		allReq    = flag.Bool("all-required", false, "Treat every output field as required, generating pointers only for nullable fields")
+		gofmt     = flag.Bool("gofmt", true, "Format generated Go code; -gofmt=false writes it unformatted to debug the generator")
+		prefixEnm = flag.Bool("prefix-enums", false, "Prefix the enums of nested fields with their struct name, e.g. TasksItemStatusEnum, so they cannot collide")
+		schemaFil = flag.String("schema-file", "", "Bare JSON Schema file to generate types from, without a .prompt wrapper (requires -type)")
+		typeName  = flag.String("type", "", "With -schema-file, name of the struct generated for the root object")
+		schemaEx  = flag.Bool("emit-examples", false, "Generate <Name>Examples fixtures from the examples of the root input and output schemas")
+		mergeEnum = flag.Bool("merge-enums", false, "Collapse enums of a prompt declaring the same values into one type named after the sorted values, e.g. NoYesEnum")
+		splitEnum = flag.Bool("split-enums", false, "Write the enums of each prompt to <name>.enums.gen.go, next to its structs in <name>.gen.go")
+		optStyle  = flag.String("optional-style", generator.OptionalStylePointer, "Type of optional output fields: pointer (*T) or wrapper (Optional[T], telling absent fields apart from null)")
+		watch     = flag.Bool("watch", false, "After generating, watch -dir recursively and regenerate changed .prompt files until interrupted")
+		lintTmpl  = flag.Bool("lint-templates", false, "Check prompt templates against their input schemas without generating code")
+		list      = flag.Bool("list", false, "Print the schema formats, struct names and enum names of each prompt without generating code")
+		jsonOut   = flag.Bool("json", false, "With -list, print the listing as JSON; with -v, print the generation summary as JSON")
 		help      = flag.Bool("h", false, "Show help")
 	)
 
+	var imports stringList
+	flag.Var(&imports, "import", "Import path added to every generated Go file, repeatable (for x-codegen-go-type overrides)")
+
+	flag.BoolVar(buildChk, "verify-compile", false, "Alias of -go-build-check")
+
+	var helpers stringList
+	flag.Var(&helpers, "helper", "Custom template helper accepted by validation as name[:arity], e.g. json:1, media:1-2 or log:0+; repeatable")
+
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Generate Go request/response models from dotprompt files.\n\n")
@@ -41,6 +107,13 @@ func main() {
 			os.Args[0],
 		)
 		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -pkg models\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -lint-templates\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -list -json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -config dotprompt-gen.yaml\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -dry-run\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -watch -v\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir app/classify/prompts/ -single-file models.gen.go\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -schema-file schemas/order.json -type Order -pkg orders\n", os.Args[0])
 		fmt.Fprintf(
 			os.Stderr,
 			"  %s -dir app/classify/prompts/ -out app/classify/models/\n",
@@ -56,7 +129,19 @@ func main() {
 		return
 	}
 
-	if *inputFile == "" && *inputDir == "" {
+	if *schemaFil != "" && (*inputFile != "" || *inputDir != "" || *typeName == "") {
+		fmt.Fprintf(os.Stderr, "Error: -schema-file requires -type and cannot be combined with -file or -dir\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *typeName != "" && *schemaFil == "" {
+		fmt.Fprintf(os.Stderr, "Error: -type requires -schema-file\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *inputFile == "" && *inputDir == "" && *schemaFil == "" {
 		fmt.Fprintf(os.Stderr, "Error: Either -file or -dir must be specified\n\n")
 		flag.Usage()
 		os.Exit(1)
@@ -68,14 +153,150 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *single != "" && *inputDir == "" {
+		fmt.Fprintf(os.Stderr, "Error: -single-file requires -dir\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *maxDepth < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-depth must not be negative\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *jobs < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -jobs must not be negative\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *mirror && (*inputDir == "" || *single != "") {
+		fmt.Fprintf(os.Stderr, "Error: -mirror-tree requires -dir and cannot be combined with -single-file\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *list && (*watch || *lintTmpl) {
+		fmt.Fprintf(os.Stderr, "Error: -list cannot be combined with -watch or -lint-templates\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *jsonOut && !*list && !*verbose {
+		fmt.Fprintf(os.Stderr, "Error: -json requires -list or -v\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *watch && (*inputDir == "" || *lintTmpl) {
+		fmt.Fprintf(os.Stderr, "Error: -watch requires -dir and cannot be combined with -lint-templates\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *omitEmpty {
+		fmt.Fprintf(os.Stderr, "Warning: -omitempty-optional is deprecated and has no effect, optional fields get omitempty unless -no-omitempty is set\n")
+	}
+
 	gen := codegen.Generator{
-		PackageName: *outputPkg,
-		OutputDir:   *outputDir,
-		Verbose:     *verbose,
+		PackageName:   *outputPkg,
+		OutputDir:     *outputDir,
+		Verbose:       *verbose,
+		ErrorTypes:    *errTypes,
+		Reset:         *reset,
+		EnumBase:      *enumBase,
+		KeepGoing:     *keepGoing,
+		Language:      *language,
+		BuildCheck:    *buildChk,
+		Examples:      *examples,
+		MaxLineLength: *maxLine,
+		ValidateAll:   *valAll,
+		StrictEnums:   *strictEnm,
+		InputSuffix:   *inSuffix,
+		OutputSuffix:  *outSuffix,
+
+		AllowDuplicateTypes: *allowDups,
+		DryRun:              *dryRun,
+		Constructors:        *ctors,
+		NoTimeTypes:         *noTime,
+		NoOmitEmpty:         *noOmit,
+		ExperimentalUnions:  *unions,
+		StructValidate:      *structVal,
+		StrictEnumNames:     *strictEnN,
+		NoInitialisms:       *noInit,
+		ConstraintTags:      *consTags,
+		SingleFile:          *single,
+		DedupeStructs:       *dedupe,
+		Getters:             *getters,
+		StrictTemplate:      *strictTpl,
+		MirrorTree:          *mirror,
+		EmitRender:          *emitRendr,
+		Strict:              *strict,
+		NoMkdir:             *noMkdir,
+		IgnoreTitle:         *ignoreTtl,
+		Imports:             imports,
+		NoRecursive:         !*recursive,
+		MaxDepth:            *maxDepth,
+		EmitTests:           *emitTests,
+		EmitMetadata:        *emitMeta,
+		Jobs:                *jobs,
+		Force:               *force,
+		EmitIsZero:          *emitZero,
+		AllRequired:         *allReq,
+		NoGofmt:             !*gofmt,
+		PrefixEnums:         *prefixEnm,
+		SchemaExamples:      *schemaEx,
+		MergeEnums:          *mergeEnum,
+		SummaryJSON:         *jsonOut && !*list,
+		SplitEnums:          *splitEnum,
+		OptionalStyle:       *optStyle,
+	}
+
+	knownHelpers, err := template.ParseHelperSpecs(helpers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	gen.Helpers = knownHelpers
+
+	if *cfgFile != "" {
+		if err := applyConfig(&gen, *cfgFile, explicitFlags()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *tmplFile != "" {
+		if gen.Language == generator.LanguageZod {
+			fmt.Fprintf(os.Stderr, "Error: -template is only supported with -lang go\n\n")
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		tmpl, err := generator.LoadTemplate(*tmplFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		gen.Template = tmpl
 	}
 
-	var err error
-	if *inputFile != "" {
+	if *schemaFil != "" {
+		err = generator.ProcessSchemaFile(gen, *schemaFil, *typeName)
+	} else if *list {
+		err = generator.ListPrompts(gen, *inputFile+*inputDir, *jsonOut, os.Stdout)
+	} else if *lintTmpl {
+		err = generator.LintTemplates(gen, *inputFile+*inputDir)
+	} else if *watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		err = generator.Watch(ctx, gen, *inputDir)
+
+		stop()
+	} else if *inputFile != "" {
 		err = generator.ProcessFile(gen, *inputFile)
 	} else {
 		err = generator.ProcessDirectory(gen, *inputDir)
@@ -86,7 +307,23 @@ func main() {
 		os.Exit(1)
 	}
 
-	if *verbose {
+	// The JSON summary stays the last line of the output
+	if *verbose && !*jsonOut {
 		fmt.Println("Code generation completed successfully!")
 	}
 }
+
+// stringList is a repeatable string flag, e.g. -import a -import b.
+type stringList []string
+
+// String returns the values joined with commas.
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set appends a value each time the flag is given.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+
+	return nil
+}
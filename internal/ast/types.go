@@ -21,6 +21,8 @@ type FrontmatterData struct {
 	Input  SchemaSpec `yaml:"input"`
 	Output SchemaSpec `yaml:"output"`
 	Config any        `yaml:"config"`
+	// Ext holds namespaced metadata by namespace, from an ext block or dotted keys like codegen.package
+	Ext map[string]map[string]any `yaml:"ext"`
 }
 
 // SchemaSpec represents input/output schema specification.
@@ -35,6 +37,11 @@ func (pf *PromptFile) HasSchema() bool {
 	return pf.Frontmatter.Input.Schema != nil || pf.Frontmatter.Output.Schema != nil
 }
 
+// ExtValue returns the namespaced metadata value ext.<namespace>.<key>, or nil when it is not set.
+func (pf *PromptFile) ExtValue(namespace, key string) any {
+	return pf.Frontmatter.Ext[namespace][key]
+}
+
 // GetInputSchema returns the input schema if available.
 func (pf *PromptFile) GetInputSchema() any {
 	return pf.Frontmatter.Input.Schema
@@ -90,8 +97,9 @@ func (pf *PromptFile) ValidateTemplate() *template.ValidationResult {
 	return template.ValidateHandlebarsTemplate(pf.Template)
 }
 
-// ValidateTemplateWithSchema validates template variables against input schema.
-func (pf *PromptFile) ValidateTemplateWithSchema() []template.ValidationError {
+// ValidateTemplateWithSchema validates template variables against input schema. Helpers other
+// than the built-in ones must be registered in knownHelpers.
+func (pf *PromptFile) ValidateTemplateWithSchema(knownHelpers map[string]template.HelperSpec) []template.ValidationError {
 	var allErrors []template.ValidationError
 
 	// First validate template syntax
@@ -105,13 +113,13 @@ func (pf *PromptFile) ValidateTemplateWithSchema() []template.ValidationError {
 	// Validate variables against input schema if schema exists
 	if inputSchema := pf.GetInputSchema(); inputSchema != nil {
 		if schemaMap, ok := inputSchema.(map[string]any); ok {
-			schemaErrors := template.ValidateVariablesAgainstSchema(result.Variables, schemaMap)
+			schemaErrors := template.ValidateVariablesAgainstSchema(result.InputVariables, schemaMap)
 			allErrors = append(allErrors, schemaErrors...)
 		}
 	}
 
 	// Validate helper functions
-	helperErrors := template.ValidateHelpers(result.Helpers, result.BlockHelpers)
+	helperErrors := template.ValidateHelpers(result.Helpers, result.BlockHelpers, knownHelpers)
 	allErrors = append(allErrors, helperErrors...)
 
 	return allErrors
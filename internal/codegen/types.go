@@ -1,20 +1,77 @@
 package codegen
 
 import (
-	"sort"
+	"io/fs"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/template"
 )
 
 // GoField represents a field in a Go struct.
 type GoField struct {
-	Name       string
-	GoType     string
-	JSONTag    string
-	Comment    string
-	IsEnum     bool
-	EnumValues []string
-	IsObject   bool              // indicates nested struct
-	IsPointer  bool              // indicates pointer field
-	ExtraTags  map[string]string // additional struct tags (e.g., validate:"required")
+	Name          string
+	GoType        string
+	JSONTag       string
+	JSONName      string // json key replacing the schema key, from x-codegen-json-name
+	Comment       string
+	IsEnum        bool
+	EnumValues    []string
+	IsObject      bool              // indicates nested struct
+	IsPointer     bool              // indicates pointer field
+	IsOptional    bool              // optional field wrapped in Optional[T] instead of a pointer
+	OmitEmpty     bool              // append ",omitempty" (",omitzero" for Optional fields) to the default json tag
+	ExtraTags     map[string]string // additional struct tags (e.g., validate:"required")
+	Example       string            // Go expression used for the field in generated example structs
+	Primary       bool              // field returned by the struct's generated String() method
+	ValidateStmt  string            // statement validating the field in the generated ValidateAll() method
+	ValidatorStmt string            // statement collecting the field's validators in the generated Validate() method
+	Deprecated    bool              // field is documented with a "Deprecated:" comment
+	Required      bool              // field is required by the schema
+	Format        string            // JSON Schema format of a string field, e.g. date-time
+	Union         *GoUnion          // discriminated oneOf/anyOf the field holds, nil for other fields
+	TypeOverride  string            // Go type forced with the x-codegen-go-type extension
+	Import        string            // import path required by TypeOverride, from x-codegen-import
+	Constraints   []string          // validator rules translated from schema constraints, e.g. max=280
+	GetterType    string            // type returned by the generated Get<Field>() method
+	GetterZero    string            // Go expression the getter returns for nil values
+	GetterDeref   bool              // getter dereferences the pointer field
+	Default       any               // schema default value, nil when the field has none
+	DefaultStmt   string            // statement applying the default in the generated ApplyDefaults() method
+	IsZeroCond    string            // condition reporting a zero field in the generated IsZero() method
+	ParamName     string            // parameter name of the field in the generated New<Name>() constructor
+}
+
+// DocComment returns the text of the comment generated above this field.
+// Deprecated fields get a "Deprecated:" prefix so linters and IDEs flag their usages.
+func (f GoField) DocComment() string {
+	if !f.Deprecated {
+		return f.Comment
+	}
+
+	if f.Comment == "" {
+		return "Deprecated: do not use in new code."
+	}
+
+	return "Deprecated: " + f.Comment
+}
+
+// DocLines returns DocComment split into the lines of the generated comment, without trailing
+// spaces or blank lines, so multi-line descriptions keep one "//" prefix per line.
+func (f GoField) DocLines() []string {
+	doc := strings.TrimRight(f.DocComment(), " \t\r\n")
+	if doc == "" {
+		return nil
+	}
+
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return lines
 }
 
 // NeedsValidation returns true if this field requires validation.
@@ -22,53 +79,76 @@ func (f GoField) NeedsValidation() bool {
 	return f.IsEnum || f.IsObject
 }
 
+// JSONKey returns the key the field is encoded under: its x-codegen-json-name, else the schema key.
+func (f GoField) JSONKey() string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+
+	return f.JSONTag
+}
+
 // StructTags returns the complete struct tag string for this field.
+// A json entry in ExtraTags replaces the generated json tag verbatim, without automatic omitempty;
+// otherwise the tag uses JSONKey, so x-codegen-json-name only renames the key.
 func (f GoField) StructTags() string {
 	var tags []string
 
 	// Check if user provided a custom json tag in ExtraTags
-	hasCustomJSON := false
-	for tagName := range f.ExtraTags {
-		if tagName == "json" {
-			hasCustomJSON = true
-
-			break
-		}
-	}
+	_, hasCustomJSON := f.ExtraTags["json"]
 
 	// Add default JSON tag only if no custom one is provided
 	if !hasCustomJSON {
-		tags = append(tags, `json:"`+f.JSONTag+`"`)
-	}
+		jsonTag := f.JSONKey()
 
-	// Add all extra tags in sorted order for deterministic output
-	if len(f.ExtraTags) > 0 {
-		var tagNames []string
-		for tagName := range f.ExtraTags {
-			tagNames = append(tagNames, tagName)
+		switch {
+		case f.OmitEmpty && f.IsOptional:
+			// omitempty never omits structs, omitzero leaves out unset values via Optional.IsZero()
+			jsonTag += ",omitzero"
+		case f.OmitEmpty:
+			jsonTag += ",omitempty"
 		}
-		sort.Strings(tagNames)
 
-		for _, tagName := range tagNames {
-			tagValue := f.ExtraTags[tagName]
-			tags = append(tags, tagName+`:"`+tagValue+`"`)
+		tags = append(tags, `json:"`+jsonTag+`"`)
+	}
+
+	// Add all extra tags in sorted order with a custom json tag first, map iteration order must
+	// not change the output
+	tagNames := slices.SortedFunc(maps.Keys(f.ExtraTags), func(a, b string) int {
+		switch {
+		case a == "json":
+			return -1
+		case b == "json":
+			return 1
+		default:
+			return strings.Compare(a, b)
 		}
+	})
+
+	for _, tagName := range tagNames {
+		tags = append(tags, tagName+`:"`+f.ExtraTags[tagName]+`"`)
 	}
 
-	if len(tags) == 1 {
-		return tags[0]
+	return strings.Join(tags, " ")
+}
+
+// StringExpr returns a Go expression converting value, which holds the field's non-pointer
+// type, to a string.
+func (f GoField) StringExpr(value string) string {
+	if f.ValueType() == "string" {
+		return value
 	}
 
-	// Join multiple tags with spaces
-	result := ""
-	for i, tag := range tags {
-		if i > 0 {
-			result += " "
-		}
-		result += tag
+	return "fmt.Sprint(" + value + ")"
+}
+
+// ValueType returns the type of the value the field holds, without its pointer or Optional wrapper.
+func (f GoField) ValueType() string {
+	if valueType, ok := strings.CutPrefix(f.GoType, "Optional["); ok && f.IsOptional {
+		return strings.TrimSuffix(valueType, "]")
 	}
 
-	return result
+	return strings.TrimPrefix(f.GoType, "*")
 }
 
 // GoStruct represents a Go struct to be generated.
@@ -79,6 +159,53 @@ type GoStruct struct {
 	Enums    []GoEnum  // Related enums
 	IsInput  bool      // explicitly mark input structs
 	IsOutput bool      // explicitly mark output structs
+	Tuple    bool      // positional tuple encoded as a JSON array of its fields
+	Title    string    // type name from the schema title, replacing Name unless titles are ignored
+
+	TemplateConst   string // name of the constant holding the prompt template, set with -emit-render
+	TemplateLiteral string // Go string literal of the prompt template
+
+	Metadata *PromptMetadata // frontmatter model and config declared with this struct, set with -emit-metadata
+	Fixtures []string        // Go literals of the schema examples, set with -emit-examples
+}
+
+// DefaultFields returns the fields with a default applied by the generated ApplyDefaults() method.
+func (s GoStruct) DefaultFields() []GoField {
+	var fields []GoField
+
+	for _, field := range s.Fields {
+		if field.DefaultStmt != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// RequiredFields returns the fields required by the schema, in declaration order.
+func (s GoStruct) RequiredFields() []GoField {
+	var fields []GoField
+
+	for _, field := range s.Fields {
+		if field.Required {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// UnionFields returns the fields holding a discriminated union, which need a custom UnmarshalJSON.
+func (s GoStruct) UnionFields() []GoField {
+	var fields []GoField
+
+	for _, field := range s.Fields {
+		if field.Union != nil {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
 }
 
 // HasValidationFields returns true if this struct has any fields requiring validation.
@@ -92,38 +219,243 @@ func (s GoStruct) HasValidationFields() bool {
 	return false
 }
 
-// NeedsValidation returns true if this struct needs a master Validate() method.
-// Only enums need Validate() methods now - structs use validation tags instead.
+// PrimaryField returns the field marked as primary, or nil if the struct has none.
+func (s GoStruct) PrimaryField() *GoField {
+	for i := range s.Fields {
+		if s.Fields[i].Primary {
+			return &s.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// NeedsValidation returns true if the struct-level Validate() method has fields to check.
+// The method is only generated with -struct-validate, and only for structs with enum fields or
+// nested structs that need validation themselves; otherwise structs rely on validation tags.
 func (s GoStruct) NeedsValidation() bool {
+	for _, field := range s.Fields {
+		if field.ValidatorStmt != "" {
+			return true
+		}
+	}
+
 	return false
 }
 
+// PromptMetadata is the frontmatter model and config of a prompt, generated as declarations
+// named after the prompt.
+type PromptMetadata struct {
+	Name   string // prompt name prefixing the declarations, e.g. ClassifyHabits
+	Model  string // Go string literal of the model, empty when the prompt names none
+	Config string // Go map[string]any literal of the config, empty when the prompt has none
+}
+
 // GoEnum represents a Go enum/constant type.
 type GoEnum struct {
-	Name    string      // Enum identifier
-	Comment string      // Documentation describing the enum
-	Type    string      // Underlying type (string, int, etc.)
-	Values  []EnumValue // Enum values
+	Name       string      // Enum identifier
+	Comment    string      // Documentation describing the enum
+	Type       string      // Underlying type (string, int, etc.)
+	Values     []EnumValue // Enum values
+	ErrorSet   bool        // generate a typed error value per enum value
+	BaseType   string      // custom declared type (e.g. a shared EnumBase), empty means Type
+	Preferred  string      // schema example/default value used in generated examples
+	ValuesFunc string      // name of the generated all-values helper, empty means <Name>Values
+	Scope      string      // nested struct declaring the enum field, empty for top-level fields and titled enums
+	SetHelpers bool        // generate Validate<Name>Set and Has<Name> helpers for []<Name> fields
+
+	// DescriptionWarning explains why per-value descriptions were only partly applied
+	DescriptionWarning string
+}
+
+// DeclType returns the type the enum is declared with.
+func (e GoEnum) DeclType() string {
+	if e.BaseType != "" {
+		return e.BaseType
+	}
+
+	return e.Type
+}
+
+// ValuesFuncName returns the name of the generated helper listing all enum values.
+func (e GoEnum) ValuesFuncName() string {
+	if e.ValuesFunc != "" {
+		return e.ValuesFunc
+	}
+
+	return e.Name + "Values"
+}
+
+// ValueList returns the enum values joined for error messages, e.g. "low, medium, high".
+// The result is escaped for use inside a quoted fmt format string.
+func (e GoEnum) ValueList() string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		quoted := strconv.Quote(value.Value)
+		values[i] = strings.ReplaceAll(quoted[1:len(quoted)-1], "%", "%%")
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// IsNumeric returns true if the enum is backed by a numeric type.
+func (e GoEnum) IsNumeric() bool {
+	return e.Type == "int" || e.Type == "float64"
+}
+
+// IsSequential returns true for integer enums whose values are 0, 1, 2, ... in declaration
+// order, which are generated as iota constants.
+func (e GoEnum) IsSequential() bool {
+	if e.Type != "int" || len(e.Values) == 0 {
+		return false
+	}
+
+	for i, value := range e.Values {
+		if value.Value != strconv.Itoa(i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Literal returns the Go literal for an enum value: quoted for string enums, bare for numeric ones.
+func (e GoEnum) Literal(value string) string {
+	if e.IsNumeric() {
+		return value
+	}
+
+	return strconv.Quote(value)
+}
+
+// HasCustomBase returns true if the enum is declared on a user-provided base type.
+// Only enum-specific methods (Validate, Err) are generated for such enums; generic
+// behavior is expected to come from the base type.
+func (e GoEnum) HasCustomBase() bool {
+	return e.BaseType != "" && e.BaseType != e.Type
+}
+
+// ExampleConstName returns the constant used for the enum in generated examples: the
+// preferred value from the schema when it is a declared value, otherwise the first constant.
+func (e GoEnum) ExampleConstName() string {
+	if len(e.Values) == 0 {
+		return ""
+	}
+
+	for _, value := range e.Values {
+		if value.Value == e.Preferred {
+			return value.ConstName
+		}
+	}
+
+	return e.Values[0].ConstName
 }
 
 // EnumValue represents a single enum value.
 type EnumValue struct {
 	ConstName string
 	Value     string
+	ErrorName string // typed error variable name, set when the enum is an error set
+	Comment   string // doc comment of the constant, from x-enum-descriptions
+}
+
+// GoUnion represents a oneOf/anyOf schema whose object variants are selected by a discriminator property.
+type GoUnion struct {
+	Name          string           // interface type implemented by every variant
+	Comment       string           // documentation describing the union
+	Discriminator string           // JSON property holding the variant name
+	Variants      []GoUnionVariant // variants in schema declaration order
+	Structs       []GoStruct       // structs declared by inline variants, including nested ones
+	Enums         []GoEnum         // enums declared inside inline variants
+}
+
+// GoUnionVariant is a single variant of a discriminated union.
+type GoUnionVariant struct {
+	Value      string // discriminator value selecting the variant
+	StructName string // struct decoded for the variant
 }
 
 // TemplateData represents data passed to Go code template.
 type TemplateData struct {
-	Version string     // Used in generated file header
-	Package string     // Go file package declaration
-	Imports []string   // Go file imports section
-	Enums   []GoEnum   // Enum types with receiver functions
-	Structs []GoStruct // Struct types with receiver functions
+	Version   string     // Used in generated file header
+	Source    string     // prompt files the code is generated from
+	InputHash string     // hash of the prompt and options, compared to skip unchanged files
+	Package   string     // Go file package declaration
+	Imports   []string   // Go file imports section
+	Enums     []GoEnum   // Enum types with receiver functions
+	Structs   []GoStruct // Struct types with receiver functions
+	Unions    []GoUnion  // Discriminated union interfaces with their decode functions
+
+	EmitReset        bool // generate Reset() methods on structs
+	EmitExamples     bool // generate Example<Name>() constructors returning sample values
+	EmitValidateAll  bool // generate ValidateAll() methods collecting every field error
+	StrictEnums      bool // generate MarshalJSON/UnmarshalJSON methods rejecting invalid enum values
+	EmitConstructors bool // generate New<Name>() constructors for input structs taking their required fields
+	EmitValidate     bool // generate struct-level Validate() methods recursing into enum and struct fields
+	EmitGetters      bool // generate nil-safe Get<Field>() methods on structs
+	EmitIsZero       bool // generate IsZero() methods on structs
+
+	DeclareEnumErrors bool // declare ErrInvalidEnum, unless a shared file of the package declares it
+	DeclareOptional   bool // declare Optional[T] for wrapped optional fields, unless a shared file of the package declares it
 }
 
 // Generator holds configuration for code generation.
 type Generator struct {
-	PackageName string
-	OutputDir   string
-	Verbose     bool
+	PackageName         string
+	OutputDir           string
+	Verbose             bool
+	ErrorTypes          bool              // generate typed error values for output error_code enums
+	Reset               bool              // generate Reset() methods for pooling structs
+	EnumBase            string            // underlying type for string enums, e.g. a shared "EnumBase" (default string)
+	KeepGoing           bool              // collect all independent errors instead of stopping at the first
+	Language            string            // output language: "go" (default) or "zod"
+	BuildCheck          bool              // type-check generated Go code before writing it, and its output package after
+	Examples            bool              // generate Example<Name>() constructors with valid enum values
+	MaxLineLength       int               // wrap generated comment lines longer than this width, 0 disables wrapping
+	ValidateAll         bool              // generate ValidateAll() methods returning every field validation error
+	TypeMappings        map[string]string // schema primitive type -> Go type overrides, e.g. "number": "float32"
+	StrictEnums         bool              // generate JSON methods on enums that reject unknown values when decoding
+	InputSuffix         string            // input struct name suffix, empty means "Input"
+	OutputSuffix        string            // output struct name suffix, empty means "Output"
+	AllowDuplicateTypes bool              // skip the cross-file duplicate type name check in directory runs
+	DryRun              bool              // print a diff of the generated code instead of writing files
+	Constructors        bool              // generate New<Name>() constructors for input structs
+	NoTimeTypes         bool              // keep date-time formatted fields as strings
+	NoOmitEmpty         bool              // keep optional pointer, slice and map fields without omitempty
+	ExperimentalUnions  bool              // generate interfaces and variant structs for discriminated oneOf/anyOf fields
+	StructValidate      bool              // generate struct-level Validate() methods aggregating field validation errors
+	StrictEnumNames     bool              // fail on enum values mapping to the same constant name instead of numbering them
+	NoInitialisms       bool              // keep initialisms like ID and URL in PascalCase (UserId) in generated names
+	Initialisms         []string          // initialisms upper-cased in generated names in addition to the defaults
+	ConstraintTags      bool              // translate schema constraints like maxLength into validate struct tags
+	SingleFile          string            // generate every prompt of a directory into this one file
+	DedupeStructs       bool              // collapse nested structs with identical fields into one shared type
+	Getters             bool              // generate nil-safe Get<Field>() methods on structs
+	StrictTemplate      bool              // fail generation on template issues instead of warning
+	MirrorTree          bool              // mirror input subdirectories under the output directory, one package per folder
+	EmitRender          bool              // embed prompt templates and generate Render() methods on input structs
+	Strict              bool              // fail generation on required field names missing from the schema instead of warning
+	NoMkdir             bool              // fail when the output directory is missing instead of creating it
+	DirMode             fs.FileMode       // permission of created output directories, 0 means 0o755
+	IgnoreTitle         bool              // name structs after the prompt file and field path even when their schema has a title
+	Imports             []string          // extra import paths added to every generated Go file, e.g. for x-codegen-go-type overrides
+	NoRecursive         bool              // only process the prompt files directly in the input directory
+	MaxDepth            int               // search at most this many subdirectory levels below the input directory, 0 means unlimited
+	EmitTests           bool              // write a <name>_test.go round-trip test next to every generated Go file
+	Template            string            // text/template source executed against TemplateData instead of the built-in Go template
+	EmitMetadata        bool              // generate <Prompt>Model and <Prompt>Config declarations from the frontmatter
+	Jobs                int               // prompt files of a directory rendered concurrently, 0 means GOMAXPROCS
+	Force               bool              // regenerate prompt files even when their output records an unchanged input hash
+	EmitIsZero          bool              // generate IsZero() methods reporting structs whose fields all hold zero values
+	AllRequired         bool              // treat every output field as required, so only nullable fields are pointers
+	NoGofmt             bool              // write generated Go code without running go/format, for debugging the generator
+	PrefixEnums         bool              // prefix the enums of nested fields with the name of their struct
+	SchemaExamples      bool              // generate <Name>Examples fixtures from the examples of the root schemas
+	MergeEnums          bool              // collapse enums of one prompt declaring the same values into one type
+	SummaryJSON         bool              // print the verbose generation summary as JSON
+	SplitEnums          bool              // write enums to <name>.enums.gen.go next to the structs in <name>.gen.go
+	OptionalStyle       string            // type of optional output fields: "pointer" (default) or "wrapper" for Optional[T]
+
+	// Helpers are the custom template helpers accepted by template validation, keyed by name
+	Helpers map[string]template.HelperSpec
 }
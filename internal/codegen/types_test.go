@@ -0,0 +1,24 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStructTagsAreSorted tests that extra tags are emitted alphabetically after the json tag
+func TestStructTagsAreSorted(t *testing.T) {
+	field := GoField{
+		Name:      "UserID",
+		JSONTag:   "user_id",
+		OmitEmpty: true,
+		ExtraTags: map[string]string{"yaml": "user_id", "bson": "uid", "validate": "required"},
+	}
+
+	for range 10 {
+		assert.Equal(t, `json:"user_id,omitempty" bson:"uid" validate:"required" yaml:"user_id"`, field.StructTags())
+	}
+
+	field.ExtraTags["json"] = "id,string"
+	assert.Equal(t, `json:"id,string" bson:"uid" validate:"required" yaml:"user_id"`, field.StructTags())
+}
@@ -0,0 +1,113 @@
+// Package config loads dotprompt-gen-go generation options from a YAML file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oter/dotprompt-gen-go/internal/template"
+)
+
+// Config holds generation options read from a config file. Pointer fields distinguish
+// settings that are absent from the file from explicit zero values.
+type Config struct {
+	Package      *string           `yaml:"package"`       // output package name
+	Out          *string           `yaml:"out"`           // output directory
+	Verbose      *bool             `yaml:"verbose"`       // verbose output
+	TypeMappings map[string]string `yaml:"type_mappings"` // schema primitive type -> Go type
+	Initialisms  []string          `yaml:"initialisms"`   // extra initialisms upper-cased in generated names, e.g. SKU
+	Imports      []string          `yaml:"imports"`       // import paths added to every generated Go file
+	Helpers      []string          `yaml:"helpers"`       // custom template helpers as name[:arity], e.g. json:1
+}
+
+// MappableTypes returns the schema primitive types whose Go type can be overridden, mapped to
+// the Go type generated by default.
+func MappableTypes() map[string]string {
+	return map[string]string{
+		"string":  "string",
+		"number":  "float64",
+		"integer": "int",
+		"boolean": "bool",
+	}
+}
+
+// Load reads and validates a YAML config file. Unknown keys are rejected so typos fail early.
+func Load(path string) (*Config, error) {
+	// #nosec G304 - config path is provided by the user running the generator
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var cfg Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// validate checks values that YAML decoding alone cannot.
+func (c *Config) validate() error {
+	if c.Package != nil && strings.TrimSpace(*c.Package) == "" {
+		return errors.New("package must not be empty")
+	}
+
+	mappable := MappableTypes()
+
+	var unknown []string
+
+	for schemaType, goType := range c.TypeMappings {
+		if _, ok := mappable[schemaType]; !ok {
+			unknown = append(unknown, schemaType)
+		}
+
+		if strings.TrimSpace(goType) == "" {
+			return fmt.Errorf("type mapping for %s must not be empty", schemaType)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+
+		return fmt.Errorf("unknown type_mappings keys %s: expected string, number, integer or boolean", strings.Join(unknown, ", "))
+	}
+
+	for _, word := range c.Initialisms {
+		if !initialismPattern.MatchString(word) {
+			return fmt.Errorf("invalid initialism %q: only letters and digits are allowed", word)
+		}
+	}
+
+	for _, importPath := range c.Imports {
+		if strings.TrimSpace(importPath) == "" {
+			return errors.New("imports must not contain empty paths")
+		}
+	}
+
+	if _, err := template.ParseHelperSpecs(c.Helpers); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// initialismPattern matches initialisms that can be spelled inside a Go identifier.
+var initialismPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes config content to a temp file and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dotprompt-gen.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// TestLoadConfig tests loading all supported settings
+func TestLoadConfig(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `package: prompts
+out: ./generated
+verbose: true
+type_mappings:
+  number: float32
+  integer: int64
+initialisms: [SKU, LLM]
+imports: [github.com/google/uuid]
+helpers: ["json:1", "media:1-2"]
+`))
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.Package)
+	assert.Equal(t, "prompts", *cfg.Package)
+	require.NotNil(t, cfg.Out)
+	assert.Equal(t, "./generated", *cfg.Out)
+	require.NotNil(t, cfg.Verbose)
+	assert.True(t, *cfg.Verbose)
+	assert.Equal(t, map[string]string{"number": "float32", "integer": "int64"}, cfg.TypeMappings)
+	assert.Equal(t, []string{"SKU", "LLM"}, cfg.Initialisms)
+	assert.Equal(t, []string{"github.com/google/uuid"}, cfg.Imports)
+	assert.Equal(t, []string{"json:1", "media:1-2"}, cfg.Helpers)
+}
+
+// TestLoadConfigPartial tests that absent settings stay unset
+func TestLoadConfigPartial(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "package: prompts\n"))
+	require.NoError(t, err)
+
+	assert.Nil(t, cfg.Out)
+	assert.Nil(t, cfg.Verbose)
+	assert.Empty(t, cfg.TypeMappings)
+}
+
+// TestLoadConfigErrors tests that invalid config files fail with descriptive errors
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			content: "package: prompts\noutput_dir: ./generated\n",
+			wantErr: "field output_dir not found",
+		},
+		{
+			name:    "unknown type mapping",
+			content: "type_mappings:\n  decimal: float64\n",
+			wantErr: "unknown type_mappings keys decimal",
+		},
+		{
+			name:    "invalid initialism",
+			content: "initialisms: [\"A-B\"]\n",
+			wantErr: `invalid initialism "A-B"`,
+		},
+		{
+			name:    "empty import",
+			content: "imports: [\" \"]\n",
+			wantErr: "imports must not contain empty paths",
+		},
+		{
+			name:    "invalid helper arity",
+			content: "helpers: [\"json:one\"]\n",
+			wantErr: `invalid helper arity "json:one"`,
+		},
+		{
+			name:    "empty package",
+			content: "package: \"\"\n",
+			wantErr: "package must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config file")
+}
@@ -2,6 +2,8 @@ package generator
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/oter/dotprompt-gen-go/internal/codegen"
@@ -126,3 +128,63 @@ func BenchmarkFilenameToStructNames(b *testing.B) {
 		}
 	}
 }
+
+// writeBenchmarkPrompts writes count independent prompt files with nested objects and enums to dir
+func writeBenchmarkPrompts(tb testing.TB, dir string, count int) {
+	tb.Helper()
+
+	for i := range count {
+		prompt := fmt.Sprintf(`---
+input:
+  schema:
+    type: object
+    properties:
+      query%[1]d: {type: string}
+      limit: {type: integer}
+output:
+  schema:
+    type: object
+    properties:
+      priority%[1]d:
+        type: string
+        enum: [low, medium, high]
+      details%[1]d:
+        type: object
+        properties:
+          summary: {type: string}
+          score: {type: number}
+          tags: {type: array, items: {type: string}}
+    required: [priority%[1]d]
+---
+Search for {{query%[1]d}}.
+`, i)
+
+		name := filepath.Join(dir, fmt.Sprintf("prompt_%03d.prompt", i))
+		if err := os.WriteFile(name, []byte(prompt), 0o600); err != nil {
+			tb.Fatalf("Failed to write prompt file: %v", err)
+		}
+	}
+}
+
+// BenchmarkProcessDirectory compares sequential and concurrent rendering of 100 prompt files
+func BenchmarkProcessDirectory(b *testing.B) {
+	promptDir := b.TempDir()
+	writeBenchmarkPrompts(b, promptDir, 100)
+
+	for _, jobs := range []int{1, 0} {
+		name := "sequential"
+		if jobs == 0 {
+			name = "concurrent"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			gen := codegen.Generator{PackageName: "models", OutputDir: b.TempDir(), Jobs: jobs}
+
+			for b.Loop() {
+				if err := ProcessDirectory(gen, promptDir); err != nil {
+					b.Fatalf("Failed to process directory: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// inputHashPrefix starts the header line recording the input hash of a generated file.
+const inputHashPrefix = "// Input hash: "
+
+// devVersion is the Version of builds without a release version, e.g. go run and go install.
+const devVersion = "dev"
+
+// unhashedOptions are generator options that do not change the generated code.
+var unhashedOptions = map[string]bool{ //nolint:gochecknoglobals // read-only lookup table
+	"Verbose":     true,
+	"OutputDir":   true,
+	"DryRun":      true,
+	"Jobs":        true,
+	"Force":       true,
+	"SummaryJSON": true,
+}
+
+// inputHash returns the hash recorded in the header of the code generated for promptFile. It covers
+// the generator version, every option that is set and the parsed prompt including external schemas,
+// so changing any of them regenerates the file.
+func inputHash(g codegen.Generator, promptFile *ast.PromptFile) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "version=%s\n", Version)
+
+	options := reflect.ValueOf(g)
+	for i := range options.NumField() {
+		name := options.Type().Field(i).Name
+		if unhashedOptions[name] || options.Field(i).IsZero() {
+			continue
+		}
+
+		// fmt prints map keys sorted, so the hash does not depend on map iteration order
+		fmt.Fprintf(hash, "%s=%#v\n", name, options.Field(i).Interface())
+	}
+
+	prompt := *promptFile
+	prompt.Filename = sourceHeader([]string{prompt.Filename})
+	fmt.Fprintf(hash, "%#v", prompt)
+
+	// 128 bits are plenty to detect changes and keep the header line short
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// cachedFile returns the existing output of promptFile when its header records the current input
+// hash, or nil when the prompt file has to be regenerated. The returned file is not written again.
+// Dev builds never use the cache: they all report the same Version, so the hash would not notice a
+// changed generator.
+func cachedFile(g codegen.Generator, promptFile *ast.PromptFile) *generatedFile {
+	if Version == devVersion {
+		return nil
+	}
+
+	packageName, err := promptPackage(g, promptFile)
+	if err != nil {
+		return nil
+	}
+
+	g.PackageName = packageName
+	outputPath := getOutputFilePath(g, promptFile.Filename)
+
+	code, err := os.ReadFile(outputPath)
+	if err != nil || recordedInputHash(code) != inputHash(g, promptFile) {
+		return nil
+	}
+
+	file := &generatedFile{
+		source:      promptFile.Filename,
+		outputPath:  outputPath,
+		cached:      true,
+		packageName: packageName,
+	}
+
+	if g.Language == LanguageZod {
+		return file
+	}
+
+	// Split enums are part of the cached output, their types count for the duplicate check below
+	var enumCode []byte
+
+	if g.SplitEnums {
+		var current bool
+		if enumCode, current = cachedEnumsFile(outputPath, recordedInputHash(code)); !current {
+			return nil
+		}
+	}
+
+	file.usesEnumErrors = bytes.Contains(code, []byte("ErrInvalidEnum")) || bytes.Contains(enumCode, []byte("ErrInvalidEnum"))
+	file.usesOptional = bytes.Contains(code, []byte("Optional["))
+
+	if g.EmitTests {
+		if _, err := os.Stat(testFilePath(outputPath)); err != nil {
+			return nil
+		}
+	}
+
+	// Cached files still take part in the duplicate type name check
+	file.typeNames, err = declaredTypesInFile(outputPath, code)
+	if err != nil {
+		return nil
+	}
+
+	if enumCode != nil {
+		enumTypes, err := declaredTypesInFile(enumsFilePath(outputPath), enumCode)
+		if err != nil {
+			return nil
+		}
+
+		file.typeNames = append(file.typeNames, enumTypes...)
+	}
+
+	return file
+}
+
+// recordedInputHash returns the input hash in the header comment of generated code, or "" when
+// the header has none.
+func recordedInputHash(code []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(code))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+
+		if hash, ok := strings.CutPrefix(line, inputHashPrefix); ok {
+			return hash
+		}
+	}
+
+	return ""
+}
+
+// declaredTypesInFile returns the top-level type names declared by generated Go code.
+func declaredTypesInFile(path string, code []byte) ([]string, error) {
+	file, err := goparser.ParseFile(token.NewFileSet(), path, code, goparser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var names []string
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*goast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			names = append(names, spec.(*goast.TypeSpec).Name.Name)
+		}
+	}
+
+	return names, nil
+}
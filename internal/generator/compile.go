@@ -0,0 +1,234 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	goast "go/ast"
+	"go/build"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// CheckGoCompiles type-checks generated Go source with go/types so generator bugs such as
+// duplicate identifiers or unknown type references surface immediately. The file is checked
+// in isolation: types declared in sibling hand-written files are not visible. Only the generated
+// shared files of the package are added for the declarations the file does not declare itself.
+// Imports outside the standard library, like pkg/validator, resolve in the module of the file.
+func CheckGoCompiles(filename string, code []byte) error {
+	return checkGoFilesCompile([]fileContent{{path: filename, code: code}})
+}
+
+// checkGoFilesCompile type-checks generated files of one package together, like the code and
+// enums files written with -split-enums. Errors name the first file.
+func checkGoFilesCompile(sources []fileContent) error {
+	fset := token.NewFileSet()
+	filename := sources[0].path
+
+	var files []*goast.File
+
+	for _, source := range sources {
+		file, err := goparser.ParseFile(fset, source.path, source.code, goparser.AllErrors)
+		if err != nil {
+			return fmt.Errorf("generated code for %s does not parse: %w", source.path, err)
+		}
+
+		files = append(files, file)
+	}
+
+	packageName := files[0].Name.Name
+
+	for _, shared := range sharedFiles {
+		if slices.ContainsFunc(files, func(file *goast.File) bool { return declares(file, shared.decl) }) {
+			continue
+		}
+
+		sharedFile, err := goparser.ParseFile(fset, shared.name+".gen.go",
+			fmt.Sprintf(shared.template, Version, packageName), 0)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s file: %w", shared.name, err)
+		}
+
+		files = append(files, sharedFile)
+	}
+
+	if err := typeCheck(fset, filepath.Dir(filename), packageName, files); err != nil {
+		return fmt.Errorf("generated code for %s does not compile: %w", filename, err)
+	}
+
+	return nil
+}
+
+// verifyOutputPackages type-checks the packages of the output directories once the files are
+// written, together with the hand-written files next to them, like go build would.
+func verifyOutputPackages(g codegen.Generator, files []*generatedFile) error {
+	if !g.BuildCheck || g.DryRun || g.Language == LanguageZod {
+		return nil
+	}
+
+	var dirs []string
+
+	for _, file := range files {
+		if dir := filepath.Dir(file.outputPath); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range dirs {
+		if err := checkGoPackageCompiles(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkGoPackageCompiles type-checks the non-test Go files of dir as one package.
+func checkGoPackageCompiles(dir string) error {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return fmt.Errorf("failed to load the package in %s: %w", dir, err)
+	}
+
+	fset := token.NewFileSet()
+
+	var files []*goast.File
+
+	for _, name := range pkg.GoFiles {
+		file, err := goparser.ParseFile(fset, filepath.Join(dir, name), nil, goparser.AllErrors)
+		if err != nil {
+			return fmt.Errorf("package %s in %s does not parse: %w", pkg.Name, dir, err)
+		}
+
+		files = append(files, file)
+	}
+
+	if err := typeCheck(fset, dir, pkg.Name, files); err != nil {
+		return fmt.Errorf("package %s in %s does not compile: %w", pkg.Name, dir, err)
+	}
+
+	return nil
+}
+
+// typeCheck type-checks the files of one package, resolving their imports from dir.
+func typeCheck(fset *token.FileSet, dir, packageName string, files []*goast.File) error {
+	imp, err := exportImporter(fset, dir, files)
+	if err != nil {
+		return err
+	}
+
+	conf := types.Config{Importer: imp}
+	if _, err := conf.Check(packageName, fset, files, nil); err != nil {
+		return err //nolint:wrapcheck // callers name the checked files
+	}
+
+	return nil
+}
+
+// exportImporter returns an importer reading the export data "go list -export" reports for the
+// imports of files and their dependencies. Packages outside the standard library resolve in the
+// module containing dir, or the working directory when dir is not inside a module.
+func exportImporter(fset *token.FileSet, dir string, files []*goast.File) (types.Importer, error) {
+	var paths []string
+
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err == nil && path != "unsafe" && !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	exports := make(map[string]string)
+
+	if len(paths) > 0 {
+		args := append([]string{"list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}={{.Export}}"}, paths...)
+		cmd := exec.Command("go", args...) //nolint:gosec // import paths of generated code
+		cmd.Dir = moduleDir(dir)
+
+		out, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return nil, fmt.Errorf("failed to list imported packages: %w: %s", err, bytes.TrimSpace(exitErr.Stderr))
+			}
+
+			return nil, fmt.Errorf("failed to list imported packages: %w", err)
+		}
+
+		for line := range strings.Lines(string(out)) {
+			path, export, _ := strings.Cut(strings.TrimSpace(line), "=")
+			if export != "" {
+				exports[path] = export
+			}
+		}
+	}
+
+	return importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		export, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("package %s is not available in the module of %s", path, dir)
+		}
+
+		return os.Open(export) //nolint:gosec // path reported by go list
+	}), nil
+}
+
+// moduleDir returns the closest directory of dir, or one of its parents, that has a go.mod file,
+// or "." when there is none, so imports resolve in the working directory.
+func moduleDir(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "."
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(abs, "go.mod")); err == nil {
+			return abs
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "."
+		}
+
+		abs = parent
+	}
+}
+
+// declares reports whether file declares the package-level variable or type name.
+func declares(file *goast.File, name string) bool {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*goast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			switch spec := spec.(type) {
+			case *goast.ValueSpec:
+				if slices.ContainsFunc(spec.Names, func(ident *goast.Ident) bool { return ident.Name == name }) {
+					return true
+				}
+			case *goast.TypeSpec:
+				if spec.Name.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
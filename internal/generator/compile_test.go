@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestCheckGoCompilesValidCode tests that well-formed generated code passes the compile check
+func TestCheckGoCompilesValidCode(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	codeStr := processTestPrompt(t, gen, "comprehensive_enums.prompt")
+
+	assert.NoError(t, CheckGoCompiles("comprehensive_enums.gen.go", []byte(codeStr)))
+}
+
+// TestCheckGoCompilesSurfacesTypeErrors tests that an invalid type reference reports the compiler error
+func TestCheckGoCompilesSurfacesTypeErrors(t *testing.T) {
+	structs := []codegen.GoStruct{{
+		Name: "BrokenOutput",
+		Fields: []codegen.GoField{
+			{Name: "Amount", GoType: "decimal.Decimal", JSONTag: "amount"},
+		},
+	}}
+
+	code, err := GenerateGoCode(structs, nil, "models")
+	require.NoError(t, err)
+
+	err = CheckGoCompiles("broken.gen.go", code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken.gen.go does not compile")
+	assert.Contains(t, err.Error(), "undefined: decimal")
+}
+
+// TestBuildCheckFailsGeneration tests that -go-build-check fails the run on uncompilable output
+func TestBuildCheckFailsGeneration(t *testing.T) {
+	// Colliding values are numbered by the parser, so the duplicate constants are built by hand
+	enums := []codegen.GoEnum{{
+		Name: "StatusEnum",
+		Type: "string",
+		Values: []codegen.EnumValue{
+			{ConstName: "StatusEnumVeryEasy", Value: "very-easy"},
+			{ConstName: "StatusEnumVeryEasy", Value: "very_easy"},
+		},
+	}}
+
+	gen, _ := createTempGenerator(t, "models")
+	gen.BuildCheck = true
+
+	err := writeGeneratedCode(gen, nil, enums, "colliding.prompt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "redeclared")
+}
+
+// TestBuildCheckResolvesModuleImports tests that the compile check resolves the packages generated
+// code imports from this module, like pkg/validator with -struct-validate and pkg/render with -emit-render
+func TestBuildCheckResolvesModuleImports(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.BuildCheck = true
+	gen.StructValidate = true
+	gen.EmitRender = true
+
+	content := `---
+input:
+  schema:
+    type: object
+    properties:
+      topic:
+        type: string
+output:
+  schema:
+    type: object
+    properties:
+      status:
+        type: string
+        enum: [open, closed]
+    required: [status]
+---
+Triage {{topic}}.`
+
+	code := processPromptContent(t, gen, "triage.prompt", content)
+	assert.Contains(t, code, `"github.com/oter/dotprompt-gen-go/pkg/validator"`)
+	assert.Contains(t, code, `"github.com/oter/dotprompt-gen-go/pkg/render"`)
+}
+
+// TestBuildCheckVerifiesWrittenPackage tests that -go-build-check type-checks the output package
+// after writing it, so clashes with hand-written files next to the generated one fail the run
+func TestBuildCheckVerifiesWrittenPackage(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	gen.BuildCheck = true
+
+	handWritten := "package models\n\n// TriageOutput is declared by hand\ntype TriageOutput struct{}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "manual.go"), []byte(handWritten), 0o600))
+
+	inputFile := filepath.Join(t.TempDir(), "triage.prompt")
+	content := "---\noutput:\n  schema:\n    type: object\n    properties:\n      note:\n        type: string\n---\nTriage."
+	require.NoError(t, os.WriteFile(inputFile, []byte(content), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "package models in "+outDir+" does not compile")
+	assert.Contains(t, err.Error(), "TriageOutput redeclared")
+}
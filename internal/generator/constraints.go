@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+)
+
+// applyConstraintTags merges the validator rules translated from schema constraints into each
+// field's validate tag. Optional fields get omitempty so absent values pass validation. Constrained
+// fields wrapped in Optional[T] fail, the validator cannot check the value inside the wrapper.
+func applyConstraintTags(structs []codegen.GoStruct) error {
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			if len(field.Constraints) == 0 {
+				continue
+			}
+
+			if field.IsOptional {
+				return fmt.Errorf("field %s of struct %s has constraints that -constraint-tags cannot check inside %s, use -optional-style=pointer",
+					field.Name, structs[i].Name, field.GoType)
+			}
+
+			rules := field.Constraints
+			if !field.Required {
+				rules = append([]string{"omitempty"}, rules...)
+			}
+
+			*field = parser.AddValidateRules(*field, rules...)
+		}
+	}
+
+	return nil
+}
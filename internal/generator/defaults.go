@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// assignDefaultStatements sets the statement each field with a schema default contributes to the
+// generated ApplyDefaults() method. Defaults come from the field's "default" keyword or, for the
+// root structs, from the input/output default block of the frontmatter. String, number, bool and
+// enum defaults are supported; other defaults are skipped with a warning.
+func assignDefaultStatements(structs []codegen.GoStruct, enums []codegen.GoEnum, promptFile *ast.PromptFile) {
+	enumsByName := make(map[string]codegen.GoEnum, len(enums))
+	for _, enum := range enums {
+		enumsByName[enum.Name] = enum
+	}
+
+	for i := range structs {
+		goStruct := &structs[i]
+
+		var specDefaults map[string]any
+
+		switch {
+		case goStruct.IsInput:
+			specDefaults, _ = promptFile.Frontmatter.Input.Default.(map[string]any)
+		case goStruct.IsOutput:
+			specDefaults, _ = promptFile.Frontmatter.Output.Default.(map[string]any)
+		}
+
+		for j := range goStruct.Fields {
+			field := &goStruct.Fields[j]
+			if field.Default == nil {
+				field.Default = specDefaults[field.JSONTag]
+			}
+
+			if field.Default == nil {
+				continue
+			}
+
+			stmt, err := defaultStatement(*field, enumsByName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s: skipping default of %s.%s: %v\n",
+					promptFile.Filename, goStruct.Name, field.Name, err)
+
+				continue
+			}
+
+			field.DefaultStmt = stmt
+		}
+	}
+}
+
+// defaultStatement returns the Go statement setting a zero-valued field to its default.
+func defaultStatement(field codegen.GoField, enums map[string]codegen.GoEnum) (string, error) {
+	value := "x." + field.Name
+	valueType := field.ValueType()
+	isPointer := strings.HasPrefix(field.GoType, "*")
+
+	literal, zero, err := defaultLiteral(valueType, field.Default, enums)
+	if err != nil {
+		return "", err
+	}
+
+	if field.IsOptional {
+		// An explicit null is kept, only absent values get the default
+		return fmt.Sprintf("if !%s.IsSet() {\n%s = Some[%s](%s)\n}", value, value, valueType, literal), nil
+	}
+
+	if isPointer {
+		// Numeric literals are converted so the pointer gets the field's type instead of int or float64
+		if _, isNumeric := defaultNumber(field.Default); isNumeric && enums[valueType].Name == "" {
+			literal = valueType + "(" + literal + ")"
+		}
+
+		return fmt.Sprintf("if %s == nil {\nvalue := %s\n%s = &value\n}", value, literal, value), nil
+	}
+
+	condition := value + " == " + zero
+	if zero == "false" {
+		condition = "!" + value
+	}
+
+	return fmt.Sprintf("if %s {\n%s = %s\n}", condition, value, literal), nil
+}
+
+// defaultLiteral returns the Go literal of a default value and the zero value it replaces.
+func defaultLiteral(goType string, value any, enums map[string]codegen.GoEnum) (string, string, error) {
+	if enum, isEnum := enums[goType]; isEnum {
+		for _, enumValue := range enum.Values {
+			if enumValue.Value == fmt.Sprintf("%v", value) {
+				return enumValue.ConstName, enumZero(enum), nil
+			}
+		}
+
+		return "", "", fmt.Errorf("%v is not a %s value", value, goType)
+	}
+
+	switch goType {
+	case "string":
+		if text, ok := value.(string); ok {
+			return strconv.Quote(text), `""`, nil
+		}
+	case "bool":
+		if flag, ok := value.(bool); ok {
+			return strconv.FormatBool(flag), "false", nil
+		}
+	case "int", "int32", "int64":
+		if number, ok := defaultNumber(value); ok && number == math.Trunc(number) {
+			return strconv.FormatFloat(number, 'f', -1, 64), "0", nil
+		}
+	case "float32", "float64":
+		if number, ok := defaultNumber(value); ok {
+			return strconv.FormatFloat(number, 'g', -1, 64), "0", nil
+		}
+	default:
+		return "", "", fmt.Errorf("only string, number, bool and enum defaults are supported, not %s", goType)
+	}
+
+	return "", "", fmt.Errorf("%v is not a valid %s", value, goType)
+}
+
+// enumZero returns the zero value of an enum's underlying type.
+func enumZero(enum codegen.GoEnum) string {
+	if enum.IsNumeric() {
+		return "0"
+	}
+
+	return `""`
+}
+
+// defaultNumber converts a decoded YAML or JSON number to float64.
+func defaultNumber(value any) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
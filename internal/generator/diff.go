@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+// diffOp is a single line of an edit script: ' ' keeps, '-' removes and '+' adds a line.
+type diffOp struct {
+	kind    byte
+	line    string
+	oldLine int // 1-based line in the old text, 0 for additions
+	newLine int // 1-based line in the new text, 0 for removals
+}
+
+// unifiedDiff returns a unified diff turning oldText into newText, or "" when they are equal.
+func unifiedDiff(oldName, newName string, oldText, newText []byte) string {
+	if string(oldText) == string(newText) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(string(oldText)), splitLines(string(newText)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(ops); {
+		first := nextChange(ops, start)
+		if first < 0 {
+			break
+		}
+
+		// Extend the hunk while the next change is close enough to share context
+		last := first
+		for next := nextChange(ops, last+1); next >= 0 && next-last <= 2*diffContextLines; next = nextChange(ops, last+1) {
+			last = next
+		}
+
+		from := max(first-diffContextLines, start)
+		to := min(last+diffContextLines+1, len(ops))
+		writeHunk(&b, ops[from:to])
+
+		start = to
+	}
+
+	return b.String()
+}
+
+// splitLines splits text into lines without their trailing newlines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines builds an edit script from the longest common subsequence of the two line slices.
+func diffLines(oldLines, newLines []string) []diffOp {
+	// lcs[i][j] is the LCS length of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			ops = append(ops, diffOp{kind: ' ', line: oldLines[i], oldLine: i + 1, newLine: j + 1})
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: oldLines[i], oldLine: i + 1})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: newLines[j], newLine: j + 1})
+			j++
+		}
+	}
+
+	return ops
+}
+
+// nextChange returns the index of the first added or removed line at or after start, or -1.
+func nextChange(ops []diffOp, start int) int {
+	for i := start; i < len(ops); i++ {
+		if ops[i].kind != ' ' {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// writeHunk writes one hunk header followed by its lines.
+func writeHunk(b *strings.Builder, ops []diffOp) {
+	var oldStart, newStart, oldCount, newCount int
+
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldCount++
+			if oldStart == 0 {
+				oldStart = op.oldLine
+			}
+		}
+
+		if op.kind != '-' {
+			newCount++
+			if newStart == 0 {
+				newStart = op.newLine
+			}
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+
+	for _, op := range ops {
+		fmt.Fprintf(b, "%c%s\n", op.kind, op.line)
+	}
+}
+
+// hunkRange formats a hunk range; a hunk with no lines on one side only happens for an empty file.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return "0,0"
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
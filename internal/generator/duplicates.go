@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// declaredTypeNames returns the top-level type names declared by the generated code.
+func declaredTypeNames(structs []codegen.GoStruct, enums []codegen.GoEnum) []string {
+	names := make([]string, 0, len(structs)+len(enums))
+
+	for _, goStruct := range structs {
+		names = append(names, goStruct.Name)
+	}
+
+	for _, enum := range enums {
+		names = append(names, enum.Name)
+	}
+
+	return names
+}
+
+// checkDuplicateTypes reports type names declared by more than one prompt file in the same output directory.
+func checkDuplicateTypes(files []*generatedFile) error {
+	// output directory -> type name -> source prompt files
+	sources := make(map[string]map[string][]string)
+
+	for _, file := range files {
+		dir := filepath.Dir(file.outputPath)
+		if sources[dir] == nil {
+			sources[dir] = make(map[string][]string)
+		}
+
+		for _, name := range file.typeNames {
+			sources[dir][name] = append(sources[dir][name], file.source)
+		}
+	}
+
+	var duplicates []string
+
+	for dir, names := range sources {
+		for name, files := range names {
+			if len(files) > 1 {
+				duplicates = append(duplicates, fmt.Sprintf("%s in %s (declared by %s)", name, dir, strings.Join(files, ", ")))
+			}
+		}
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	sort.Strings(duplicates)
+
+	return fmt.Errorf("duplicate generated type names (use -allow-duplicate-types to generate anyway):\n  %s",
+		strings.Join(duplicates, "\n  "))
+}
+
+// checkOutputPackages reports output directories whose Go files would declare different packages,
+// e.g. a prompt overriding its package with ext.codegen.package next to prompts using -pkg. Go files
+// already in the directory count too, except the ones rewritten now, tests and files excluded by
+// build constraints.
+func checkOutputPackages(g codegen.Generator, files []*generatedFile) error {
+	if g.Language == LanguageZod {
+		return nil
+	}
+
+	// output directory -> package name -> files declaring it
+	packages := make(map[string]map[string][]string)
+	rewritten := make(map[string]bool)
+
+	addFile := func(dir, packageName, source string) {
+		if packages[dir] == nil {
+			packages[dir] = make(map[string][]string)
+		}
+
+		packages[dir][packageName] = append(packages[dir][packageName], source)
+	}
+
+	for _, file := range files {
+		rewritten[file.outputPath] = true
+		rewritten[enumsFilePath(file.outputPath)] = true
+		addFile(filepath.Dir(file.outputPath), file.packageName, file.source)
+	}
+
+	for dir := range packages {
+		for path, packageName := range existingPackageFiles(dir) {
+			if !rewritten[path] {
+				addFile(dir, packageName, path)
+			}
+		}
+	}
+
+	var conflicts []string
+
+	for dir, names := range packages {
+		if len(names) < 2 {
+			continue
+		}
+
+		declared := make([]string, 0, len(names))
+		for name, sources := range names {
+			declared = append(declared, fmt.Sprintf("package %s (%s)", name, strings.Join(sources, ", ")))
+		}
+
+		sort.Strings(declared)
+		conflicts = append(conflicts, fmt.Sprintf("%s: %s", dir, strings.Join(declared, ", ")))
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	sort.Strings(conflicts)
+
+	return fmt.Errorf("output directories would mix packages (write ext.codegen.package prompts to their own -out):\n  %s",
+		strings.Join(conflicts, "\n  "))
+}
+
+// existingPackageFiles returns the package name of every non-test Go file in dir that the build
+// includes. A missing directory has none.
+func existingPackageFiles(dir string) map[string]string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	packageNames := make(map[string]string)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		file, err := goparser.ParseFile(token.NewFileSet(), path, nil, goparser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+
+		packageNames[path] = file.Name.Name
+	}
+
+	return packageNames
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+const goTestTemplate = `// Code generated by dotprompt-gen-go {{.Version}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"testing"
+)
+{{range .Structs}}
+// sample{{.Name}} returns the {{.Name}} round-tripped by Test{{.Name}}RoundTrip
+func sample{{.Name}}() {{.Name}} {
+	return {{.Name}}{
+{{range .Fields}}		{{.Name}}: {{.Value}},
+{{end}}	}
+}
+
+func Test{{.Name}}RoundTrip(t *testing.T) {
+	data, err := json.Marshal(sample{{.Name}}())
+	if err != nil {
+		t.Fatalf("failed to marshal {{.Name}}: %v", err)
+	}
+
+	var decoded {{.Name}}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal {{.Name}}: %v", err)
+	}
+{{range .Enums}}{{if .Slice}}
+	for _, value := range decoded.{{.Name}} {
+		if err := value.Validate(); err != nil {
+			t.Errorf("invalid {{.Name}}: %v", err)
+		}
+	}
+{{else}}
+	if err := decoded.{{.Name}}.Validate(); err != nil {
+		t.Errorf("invalid {{.Name}}: %v", err)
+	}
+{{end}}{{end}}}
+{{end}}`
+
+// testStruct is a struct covered by the generated round-trip tests.
+type testStruct struct {
+	Name   string
+	Fields []testField // fields set to a non-zero value in the sample
+	Enums  []testField // enum fields validated after decoding
+}
+
+// testField is a struct field set in a generated sample value.
+type testField struct {
+	Name  string
+	Value string // Go expression assigned to the field
+	Slice bool   // field is a slice of enum values
+}
+
+// generateGoTests renders a test file that round-trips every struct through encoding/json and
+// validates the decoded enum fields. Only the standard library is imported.
+func generateGoTests(g codegen.Generator, structs []codegen.GoStruct, enums []codegen.GoEnum) ([]byte, error) {
+	if len(structs) == 0 {
+		return nil, nil
+	}
+
+	enumsByName := make(map[string]codegen.GoEnum, len(enums))
+	for _, enum := range enums {
+		enumsByName[enum.Name] = enum
+	}
+
+	structNames := make(map[string]bool, len(structs))
+	for _, s := range structs {
+		structNames[s.Name] = true
+	}
+
+	data := struct {
+		Version string
+		Package string
+		Structs []testStruct
+	}{Version: Version, Package: g.PackageName}
+
+	for _, s := range structs {
+		data.Structs = append(data.Structs, sampleStruct(s, enumsByName, structNames))
+	}
+
+	tmpl := template.Must(template.New("gotest").Parse(goTestTemplate))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute test template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated tests: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// sampleStruct picks the sample value of every field of s that must not stay zero: enums get a
+// valid constant so decoding with -strict-enums succeeds, and nested structs their own sample.
+// Pointer fields stay nil.
+func sampleStruct(s codegen.GoStruct, enumsByName map[string]codegen.GoEnum, structNames map[string]bool) testStruct {
+	sample := testStruct{Name: s.Name}
+
+	for _, field := range s.Fields {
+		elemType, isSlice := strings.CutPrefix(field.GoType, "[]")
+
+		if enum, ok := enumsByName[elemType]; ok && enum.ExampleConstName() != "" {
+			value := enum.ExampleConstName()
+			if isSlice {
+				value = field.GoType + "{" + value + "}"
+			}
+
+			sample.Fields = append(sample.Fields, testField{Name: field.Name, Value: value})
+			sample.Enums = append(sample.Enums, testField{Name: field.Name, Slice: isSlice})
+
+			continue
+		}
+
+		if !isSlice && structNames[field.GoType] {
+			sample.Fields = append(sample.Fields, testField{Name: field.Name, Value: "sample" + field.GoType + "()"})
+		}
+	}
+
+	return sample
+}
+
+// testFilePath returns the path of the test file generated next to a Go output file,
+// e.g. classify.gen_test.go for classify.gen.go.
+func testFilePath(outputPath string) string {
+	return strings.TrimSuffix(outputPath, ".go") + "_test.go"
+}
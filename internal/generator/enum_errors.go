@@ -0,0 +1,14 @@
+package generator
+
+// enumErrorsFileName is the base name of the file declaring ErrInvalidEnum for per-prompt output.
+const enumErrorsFileName = "enum_errors"
+
+const enumErrorsTemplate = `// Code generated by dotprompt-gen-go %s. DO NOT EDIT.
+
+package %s
+
+import "errors"
+
+// ErrInvalidEnum is wrapped by the errors of every enum Validate() and Parse function in this package
+var ErrInvalidEnum = errors.New("invalid enum value")
+`
@@ -0,0 +1,322 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// dedupeEnums removes repeated enum definitions that share a name, which happens when several
+// fields reference the same titled enum. Enums sharing a name but declaring different values
+// cannot be represented by one Go type and are reported as an error.
+func dedupeEnums(enums []codegen.GoEnum) ([]codegen.GoEnum, error) {
+	var unique []codegen.GoEnum
+
+	seen := make(map[string]codegen.GoEnum)
+
+	for _, enum := range enums {
+		existing, found := seen[enum.Name]
+		if !found {
+			seen[enum.Name] = enum
+			unique = append(unique, enum)
+
+			continue
+		}
+
+		if !sameEnumValues(existing, enum) {
+			hint := ""
+			if existing.Scope != "" || enum.Scope != "" {
+				hint = " (-prefix-enums names nested enums after their struct)"
+			}
+
+			return nil, fmt.Errorf(
+				"enum %s is defined with conflicting values: [%s] and [%s]%s",
+				enum.Name, joinEnumValues(existing), joinEnumValues(enum), hint,
+			)
+		}
+	}
+
+	return unique, nil
+}
+
+// applyEnumPrefixes prefixes the enums of nested fields with the name of the struct declaring the
+// field, e.g. TasksItemStatusEnum, and updates the field types and constant names. Enums of
+// different nested structs no longer share a name, so they can declare different values.
+func applyEnumPrefixes(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	renames := make(map[string]map[string]string)
+
+	for i, enum := range enums {
+		if enum.Scope == "" {
+			continue
+		}
+
+		name := enum.Scope + enum.Name
+		if renames[enum.Scope] == nil {
+			renames[enum.Scope] = make(map[string]string)
+		}
+
+		renames[enum.Scope][enum.Name] = name
+
+		values := slices.Clone(enum.Values)
+		for j, value := range values {
+			// Constant names set by x-enum-varnames do not start with the enum name and are kept
+			if suffix, ok := strings.CutPrefix(value.ConstName, enum.Name); ok {
+				values[j].ConstName = name + suffix
+			}
+		}
+
+		enums[i].Name = name
+		enums[i].Values = values
+	}
+
+	for i := range structs {
+		structRenames, ok := renames[structs[i].Name]
+		if !ok {
+			continue
+		}
+
+		for j := range structs[i].Fields {
+			structs[i].Fields[j].GoType = renameType(structs[i].Fields[j].GoType, structRenames)
+		}
+	}
+}
+
+// mergeIdenticalEnums collapses enums declaring the same set of values into one shared enum named
+// after the sorted values, e.g. NoYesEnum for two fields with enum: [yes, no], and updates the
+// field types and constant names. The first enum of each set keeps its declaration order and
+// position; enums without a duplicate are left alone.
+func mergeIdenticalEnums(structs []codegen.GoStruct, enums []codegen.GoEnum, names naming.Initialisms) ([]codegen.GoEnum, error) {
+	groups := make(map[string][]int)
+
+	var keys []string
+
+	for i, enum := range enums {
+		values := enumValueStrings(enum)
+		slices.Sort(values)
+
+		key := enum.Type + "\x00" + strings.Join(values, "\x00")
+		if groups[key] == nil {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], i)
+	}
+
+	taken := make(map[string]bool, len(structs)+len(enums))
+	for _, goStruct := range structs {
+		taken[goStruct.Name] = true
+	}
+
+	for _, enum := range enums {
+		taken[enum.Name] = true
+	}
+
+	renames := make(map[string]string)
+	merged := make(map[int]bool)
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+
+		name := mergedEnumName(enums[group[0]], names)
+		for _, i := range group {
+			delete(taken, enums[i].Name)
+		}
+
+		if taken[name] {
+			return nil, fmt.Errorf("merged enum name %s is already declared", name)
+		}
+
+		taken[name] = true
+
+		for _, i := range group {
+			renames[enums[i].Name] = name
+			merged[i] = i != group[0]
+		}
+
+		first := &enums[group[0]]
+		if keys := enumFieldKeys(structs, enums, group); len(keys) > 0 {
+			first.Comment = "valid values of the " + strings.Join(keys, ", ") + " fields"
+		}
+
+		values := slices.Clone(first.Values)
+
+		for j, value := range values {
+			// Constant names set by x-enum-varnames do not start with the enum name and are kept
+			if suffix, ok := strings.CutPrefix(value.ConstName, first.Name); ok {
+				values[j].ConstName = name + suffix
+			}
+		}
+
+		first.Name = name
+		first.Values = values
+	}
+
+	if len(renames) == 0 {
+		return enums, nil
+	}
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			structs[i].Fields[j].GoType = renameType(structs[i].Fields[j].GoType, renames)
+		}
+	}
+
+	var result []codegen.GoEnum
+
+	for i, enum := range enums {
+		if !merged[i] {
+			result = append(result, enum)
+		}
+	}
+
+	return result, nil
+}
+
+// mergedEnumName names a merged enum after its sorted values, e.g. NoYesEnum.
+func mergedEnumName(enum codegen.GoEnum, names naming.Initialisms) string {
+	values := enumValueStrings(enum)
+	slices.Sort(values)
+
+	var name strings.Builder
+
+	for _, value := range values {
+		name.WriteString(strings.TrimPrefix(names.EnumValueToConstName("Enum", value), "Enum"))
+	}
+
+	return naming.SanitizeIdentifier(name.String(), "Enum") + "Enum"
+}
+
+// enumFieldKeys returns the json keys of the fields typed with one of the enums at indexes, in
+// struct declaration order and without repeats.
+func enumFieldKeys(structs []codegen.GoStruct, enums []codegen.GoEnum, indexes []int) []string {
+	names := make(map[string]string, len(indexes))
+	for _, i := range indexes {
+		names[enums[i].Name] = ""
+	}
+
+	var keys []string
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			// renameType sees through pointers, slices and maps
+			if renameType(field.GoType, names) != field.GoType && !slices.Contains(keys, field.JSONKey()) {
+				keys = append(keys, field.JSONKey())
+			}
+		}
+	}
+
+	return keys
+}
+
+// markSetEnums flags the enums used as array items, e.g. []CategoryItemEnum, so they get helpers
+// validating and querying the slice as a set.
+func markSetEnums(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	itemEnums := make(map[string]bool)
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if itemType, isSlice := strings.CutPrefix(strings.TrimPrefix(field.GoType, "*"), "[]"); isSlice {
+				itemEnums[itemType] = true
+			}
+		}
+	}
+
+	for i := range enums {
+		enums[i].SetHelpers = itemEnums[enums[i].Name]
+	}
+}
+
+// warnEnumDescriptions reports enums whose per-value descriptions do not line up with their values.
+func warnEnumDescriptions(promptFile *ast.PromptFile, enums []codegen.GoEnum) {
+	for _, enum := range enums {
+		if enum.DescriptionWarning != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s: enum %s: %s\n", promptFile.Filename, enum.Name, enum.DescriptionWarning)
+		}
+	}
+}
+
+// checkEnumConstNames reports enum values that collapse to the same Go constant name, e.g.
+// "very-easy" and "very_easy". Without the check the parser numbers the later constants.
+func checkEnumConstNames(enums []codegen.GoEnum, names naming.Initialisms) error {
+	for _, enum := range enums {
+		values := make(map[string]string, len(enum.Values))
+
+		for _, value := range enum.Values {
+			constName := names.EnumValueToConstName(enum.Name, value.Value)
+			if previous, found := values[constName]; found {
+				return fmt.Errorf("enum %s values %q and %q both map to the constant %s", enum.Name, previous, value.Value, constName)
+			}
+
+			values[constName] = value.Value
+		}
+	}
+
+	return nil
+}
+
+// sameEnumValues checks if two enums declare the same values in the same order.
+func sameEnumValues(a, b codegen.GoEnum) bool {
+	return a.Type == b.Type && slices.Equal(enumValueStrings(a), enumValueStrings(b))
+}
+
+// enumValueStrings returns the raw values of an enum in declaration order.
+func enumValueStrings(enum codegen.GoEnum) []string {
+	values := make([]string, 0, len(enum.Values))
+	for _, value := range enum.Values {
+		values = append(values, value.Value)
+	}
+
+	return values
+}
+
+// joinEnumValues formats enum values for error messages.
+func joinEnumValues(enum codegen.GoEnum) string {
+	return strings.Join(enumValueStrings(enum), ", ")
+}
+
+// applyEnumBaseType declares string enums on a custom base type instead of string.
+func applyEnumBaseType(enums []codegen.GoEnum, baseType string) {
+	if baseType == "" || baseType == "string" {
+		return
+	}
+
+	for i := range enums {
+		if enums[i].Type == "string" {
+			enums[i].BaseType = baseType
+		}
+	}
+}
+
+// withValuesFuncNames returns a copy of enums where the <Name>Values helper is renamed to
+// <Name>ValueList if the default name is already taken, e.g. by the constant generated for an
+// enum value named "values" or by a generated type.
+func withValuesFuncNames(structs []codegen.GoStruct, enums []codegen.GoEnum) []codegen.GoEnum {
+	taken := make(map[string]bool)
+	for _, goStruct := range structs {
+		taken[goStruct.Name] = true
+	}
+
+	for _, enum := range enums {
+		taken[enum.Name] = true
+		for _, value := range enum.Values {
+			taken[value.ConstName] = true
+		}
+	}
+
+	named := slices.Clone(enums)
+	for i := range named {
+		if taken[named[i].ValuesFuncName()] {
+			named[i].ValuesFunc = named[i].Name + "ValueList"
+		}
+	}
+
+	return named
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// assignFieldExamples sets the example expression for enum and nested struct fields so that
+// generated Example<Name>() constructors never fall back to an invalid zero enum value.
+func assignFieldExamples(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	enumsByName := make(map[string]codegen.GoEnum, len(enums))
+	for _, enum := range enums {
+		enumsByName[enum.Name] = enum
+	}
+
+	nestedTypes := make(map[string][]string, len(structs))
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if field.IsObject {
+				nestedTypes[goStruct.Name] = append(nestedTypes[goStruct.Name], fieldTypeName(field))
+			}
+		}
+	}
+
+	for i := range structs {
+		for j, field := range structs[i].Fields {
+			// Recursive fields keep their nil zero value, building their example would never end
+			if field.IsObject && typeReaches(nestedTypes, fieldTypeName(field), structs[i].Name) {
+				continue
+			}
+
+			structs[i].Fields[j].Example = fieldExample(field, enumsByName)
+		}
+	}
+}
+
+// fieldTypeName returns the named type of a field without pointer, slice and map markers.
+func fieldTypeName(field codegen.GoField) string {
+	typeName := strings.TrimLeft(field.GoType, "[]*")
+	if valueType, isMap := strings.CutPrefix(typeName, "map[string]"); isMap {
+		return strings.TrimLeft(valueType, "[]*")
+	}
+
+	return typeName
+}
+
+// typeReaches reports whether the struct named from is target or nests target through its fields.
+func typeReaches(nestedTypes map[string][]string, from, target string) bool {
+	visited := make(map[string]bool)
+	pending := []string{from}
+
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if name == target {
+			return true
+		}
+
+		if visited[name] {
+			continue
+		}
+
+		visited[name] = true
+		pending = append(pending, nestedTypes[name]...)
+	}
+
+	return false
+}
+
+// fieldExample returns the example Go expression for a field, or "" to keep the zero value.
+func fieldExample(field codegen.GoField, enumsByName map[string]codegen.GoEnum) string {
+	typeName := field.ValueType()
+
+	var value string
+
+	switch {
+	case field.IsEnum:
+		enum, ok := enumsByName[typeName]
+		if !ok {
+			return ""
+		}
+
+		value = enum.ExampleConstName()
+	case field.IsObject:
+		value = "Example" + typeName + "()"
+	default:
+		return ""
+	}
+
+	if value == "" {
+		return ""
+	}
+
+	if field.IsPointer {
+		return "func() *" + typeName + " { v := " + value + "; return &v }()"
+	}
+
+	if field.IsOptional {
+		return "Some(" + value + ")"
+	}
+
+	return value
+}
@@ -0,0 +1,346 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+)
+
+// assignSchemaFixtures sets the Go literals of the "examples" of the input and output schemas on
+// their root structs. Every example is decoded against the generated types, so an example that
+// does not match its schema fails generation instead of producing code that does not compile.
+func assignSchemaFixtures(structs []codegen.GoStruct, enums []codegen.GoEnum, promptFile *ast.PromptFile) error {
+	builder := fixtureBuilder{
+		structs: make(map[string]codegen.GoStruct, len(structs)),
+		enums:   make(map[string]codegen.GoEnum, len(enums)),
+	}
+
+	for _, goStruct := range structs {
+		builder.structs[goStruct.Name] = goStruct
+	}
+
+	for _, enum := range enums {
+		builder.enums[enum.Name] = enum
+	}
+
+	for i := range structs {
+		var (
+			schema any
+			kind   string
+		)
+
+		switch {
+		case structs[i].IsInput:
+			schema, kind = promptFile.GetInputSchema(), "input"
+		case structs[i].IsOutput:
+			schema, kind = promptFile.GetOutputSchema(), "output"
+		default:
+			continue
+		}
+
+		examples := schemaExamples(schema)
+
+		for j, example := range examples {
+			literal, err := builder.literal(structs[i].Name, example)
+			if err != nil {
+				return fmt.Errorf("%s examples[%d]: %w", kind, j, err)
+			}
+
+			// The element type of the generated slice is implied, as gofmt -s would simplify it
+			structs[i].Fixtures = append(structs[i].Fixtures, strings.TrimPrefix(literal, structs[i].Name))
+		}
+	}
+
+	return nil
+}
+
+// schemaExamples returns the "examples" of a JSON Schema root. Picoschema has no examples keyword,
+// a property named examples is a field.
+func schemaExamples(schema any) []any {
+	schemaMap, ok := schema.(map[string]any)
+	if !ok || parser.IsPicoschema(schema) {
+		return nil
+	}
+
+	examples, _ := schemaMap["examples"].([]any)
+
+	return examples
+}
+
+// fixtureBuilder converts decoded example values into Go literals of the generated types.
+type fixtureBuilder struct {
+	structs map[string]codegen.GoStruct
+	enums   map[string]codegen.GoEnum
+}
+
+// literal returns the Go literal of value as goType.
+func (b fixtureBuilder) literal(goType string, value any) (string, error) {
+	if valueType, ok := strings.CutPrefix(goType, "Optional["); ok {
+		return b.optionalLiteral(strings.TrimSuffix(valueType, "]"), value)
+	}
+
+	if value == nil {
+		if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") ||
+			strings.HasPrefix(goType, "map[") || goType == "any" {
+			return "nil", nil
+		}
+
+		return "", fmt.Errorf("null is not a valid %s", goType)
+	}
+
+	if elemType, ok := strings.CutPrefix(goType, "*"); ok {
+		return b.pointerLiteral(elemType, value)
+	}
+
+	if elemType, ok := strings.CutPrefix(goType, "[]"); ok {
+		return b.sliceLiteral(goType, elemType, value)
+	}
+
+	if elemType, ok := strings.CutPrefix(goType, "map[string]"); ok {
+		return b.mapLiteral(goType, elemType, value)
+	}
+
+	if goStruct, ok := b.structs[goType]; ok {
+		return b.structLiteral(goStruct, value)
+	}
+
+	return b.scalarLiteral(goType, value)
+}
+
+// pointerLiteral returns a pointer to the literal of value as elemType. Scalars cannot be
+// addressed directly, so they are wrapped in a function returning the address of a local.
+func (b fixtureBuilder) pointerLiteral(elemType string, value any) (string, error) {
+	literal, err := b.literal(elemType, value)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isStruct := b.structs[elemType]; isStruct {
+		return "&" + literal, nil
+	}
+
+	// Numeric literals are converted so the pointer gets the field's type instead of int or float64
+	if _, isNumeric := defaultNumber(value); isNumeric && b.enums[elemType].Name == "" {
+		literal = elemType + "(" + literal + ")"
+	}
+
+	return fmt.Sprintf("func() *%s { value := %s; return &value }()", elemType, literal), nil
+}
+
+// optionalLiteral returns an Optional holding the literal of value as valueType, or null.
+func (b fixtureBuilder) optionalLiteral(valueType string, value any) (string, error) {
+	if value == nil {
+		return "Null[" + valueType + "]()", nil
+	}
+
+	literal, err := b.literal(valueType, value)
+	if err != nil {
+		return "", err
+	}
+
+	// The type argument converts numeric literals, which would otherwise infer int or float64
+	return "Some[" + valueType + "](" + literal + ")", nil
+}
+
+// sliceLiteral returns the literal of a JSON array as a slice of elemType.
+func (b fixtureBuilder) sliceLiteral(goType, elemType string, value any) (string, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return "", fmt.Errorf("%v is not an array", value)
+	}
+
+	elems := make([]string, len(items))
+
+	for i, item := range items {
+		literal, err := b.literal(elemType, item)
+		if err != nil {
+			return "", fmt.Errorf("[%d]: %w", i, err)
+		}
+
+		elems[i] = literal
+	}
+
+	return goType + "{" + strings.Join(elems, ", ") + "}", nil
+}
+
+// mapLiteral returns the literal of a JSON object as a map of elemType, with sorted keys.
+func (b fixtureBuilder) mapLiteral(goType, elemType string, value any) (string, error) {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("%v is not an object", value)
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(goType + "{\n")
+
+	for _, key := range slices.Sorted(maps.Keys(object)) {
+		literal, err := b.literal(elemType, object[key])
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+
+		fmt.Fprintf(&builder, "%s: %s,\n", strconv.Quote(key), literal)
+	}
+
+	builder.WriteString("}")
+
+	return builder.String(), nil
+}
+
+// structLiteral returns the literal of a JSON object as goStruct. Unknown properties and missing
+// required non-pointer fields are errors, null properties are left at their zero value unless the
+// field is an Optional, which tells null apart.
+func (b fixtureBuilder) structLiteral(goStruct codegen.GoStruct, value any) (string, error) {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("%v is not an object", value)
+	}
+
+	if goStruct.Tuple {
+		return "", fmt.Errorf("tuple %s is not supported", goStruct.Name)
+	}
+
+	fields := make(map[string]codegen.GoField, len(goStruct.Fields))
+	for _, field := range goStruct.Fields {
+		fields[field.JSONKey()] = field
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(object)) {
+		if _, known := fields[key]; !known {
+			return "", fmt.Errorf("%s is not a property of %s", key, goStruct.Name)
+		}
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(goStruct.Name + "{\n")
+
+	for _, field := range goStruct.Fields {
+		fieldValue, present := object[field.JSONKey()]
+		if !present {
+			if field.Required && !field.IsPointer {
+				return "", fmt.Errorf("%s: required property is missing", field.JSONKey())
+			}
+
+			continue
+		}
+
+		if fieldValue == nil && !field.IsOptional {
+			continue
+		}
+
+		if field.Union != nil || field.TypeOverride != "" {
+			return "", fmt.Errorf("%s: %s fields are not supported", field.JSONKey(), field.GoType)
+		}
+
+		literal, err := b.literal(field.GoType, fieldValue)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", field.JSONKey(), err)
+		}
+
+		fmt.Fprintf(&builder, "%s: %s,\n", field.Name, literal)
+	}
+
+	builder.WriteString("}")
+
+	return builder.String(), nil
+}
+
+// scalarLiteral returns the literal of an enum, string, bool, number, time or any value.
+func (b fixtureBuilder) scalarLiteral(goType string, value any) (string, error) {
+	switch goType {
+	case "any":
+		return anyLiteral(value)
+	case "time.Time":
+		return timeLiteral(value)
+	case "string", "bool", "int", "int32", "int64", "float32", "float64":
+	default:
+		if _, isEnum := b.enums[goType]; !isEnum {
+			return "", fmt.Errorf("%s fields are not supported", goType)
+		}
+	}
+
+	literal, _, err := defaultLiteral(goType, value, b.enums)
+
+	return literal, err
+}
+
+// timeLiteral returns a time.Date call for an RFC 3339 timestamp, converted to UTC.
+func timeLiteral(value any) (string, error) {
+	var parsed time.Time
+
+	switch typed := value.(type) {
+	case time.Time: // unquoted YAML timestamps are decoded as time.Time
+		parsed = typed
+	case string:
+		var err error
+		if parsed, err = time.Parse(time.RFC3339Nano, typed); err != nil {
+			return "", fmt.Errorf("%q is not an RFC 3339 date-time: %w", typed, err)
+		}
+	default:
+		return "", fmt.Errorf("%v is not a date-time string", value)
+	}
+
+	parsed = parsed.UTC()
+
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, time.UTC)",
+		parsed.Year(), parsed.Month(), parsed.Day(),
+		parsed.Hour(), parsed.Minute(), parsed.Second(), parsed.Nanosecond()), nil
+}
+
+// anyLiteral returns the literal of an untyped JSON value as decoded by encoding/json.
+func anyLiteral(value any) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "nil", nil
+	case string:
+		return strconv.Quote(typed), nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case []any:
+		elems := make([]string, len(typed))
+
+		for i, item := range typed {
+			literal, err := anyLiteral(item)
+			if err != nil {
+				return "", err
+			}
+
+			elems[i] = literal
+		}
+
+		return "[]any{" + strings.Join(elems, ", ") + "}", nil
+	case map[string]any:
+		var builder strings.Builder
+
+		builder.WriteString("map[string]any{\n")
+
+		for _, key := range slices.Sorted(maps.Keys(typed)) {
+			literal, err := anyLiteral(typed[key])
+			if err != nil {
+				return "", err
+			}
+
+			fmt.Fprintf(&builder, "%s: %s,\n", strconv.Quote(key), literal)
+		}
+
+		builder.WriteString("}")
+
+		return builder.String(), nil
+	}
+
+	// encoding/json decodes every number into any as float64
+	if number, ok := defaultNumber(value); ok {
+		return "float64(" + strconv.FormatFloat(number, 'g', -1, 64) + ")", nil
+	}
+
+	return "", fmt.Errorf("%v is not a JSON value", value)
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/scanner"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// rawFileExtension is appended to the output path of generated code that go/format rejected.
+const rawFileExtension = ".raw"
+
+// FormatError is returned when go/format rejects generated code, which points at a generator bug.
+type FormatError struct {
+	Line     int    // line of the first syntax error, 0 when unknown
+	LineText string // source of that line
+	Code     []byte // the unformatted code
+	Err      error  // the go/format error
+
+	outputPath string // file the code was generated for, see writeRawOutput
+}
+
+func (e *FormatError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("failed to format generated code: %v", e.Err)
+	}
+
+	return fmt.Sprintf("failed to format generated code: %v (line %d: %s)", e.Err, e.Line, strings.TrimSpace(e.LineText))
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// formatGoCode runs go/format over generated code unless g.NoGofmt is set.
+func formatGoCode(g codegen.Generator, code []byte) ([]byte, error) {
+	if g.NoGofmt {
+		return code, nil
+	}
+
+	formatted, err := format.Source(code)
+	if err == nil {
+		return wrapCommentLines(formatted, g.MaxLineLength), nil
+	}
+
+	formatErr := &FormatError{Code: code, Err: err}
+
+	var syntaxErrors scanner.ErrorList
+	if errors.As(err, &syntaxErrors) && len(syntaxErrors) > 0 {
+		formatErr.Line = syntaxErrors[0].Pos.Line
+
+		lines := bytes.Split(code, []byte("\n"))
+		if formatErr.Line <= len(lines) {
+			formatErr.LineText = string(lines[formatErr.Line-1])
+		}
+	}
+
+	return nil, formatErr
+}
+
+// writeRawOutput writes the unformatted code of a FormatError in err next to the file it was
+// generated for, so generator bugs can be inspected. Other errors and dry runs are returned as is.
+func writeRawOutput(g codegen.Generator, err error) error {
+	var formatErr *FormatError
+	if g.DryRun || !errors.As(err, &formatErr) || formatErr.outputPath == "" {
+		return err
+	}
+
+	rawPath := formatErr.outputPath + rawFileExtension
+
+	writeErr := ensureOutputDir(g, filepath.Dir(rawPath))
+	if writeErr == nil {
+		writeErr = os.WriteFile(rawPath, formatErr.Code, 0o600)
+	}
+
+	if writeErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to write unformatted code: %w", writeErr))
+	}
+
+	return fmt.Errorf("%w (unformatted code written to %s)", err, rawPath)
+}
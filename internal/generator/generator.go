@@ -2,15 +2,20 @@ package generator
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"go/format"
+	"go/token"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/oter/dotprompt-gen-go/internal/ast"
 	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
 	"github.com/oter/dotprompt-gen-go/internal/parser"
 )
 
@@ -20,7 +25,9 @@ import (
 var Version = "dev" //nolint:gochecknoglobals // set at build time
 
 const goStructTemplate = `// Code generated by dotprompt-gen-go {{.Version}}. DO NOT EDIT.
-
+{{with .Source}}// Source: {{.}}
+{{end}}{{with .InputHash}}// Input hash: {{.}}
+{{end}}
 package {{.Package}}
 
 {{range .Imports}}import "{{.}}"
@@ -28,18 +35,199 @@ package {{.Package}}
 {{range .Structs}}
 {{range .Comments}}// {{.}}
 {{end}}{{if .Fields}}type {{.Name}} struct {
-{{range .Fields}}{{if .Comment}}	// {{.Comment}}
+{{range .Fields}}{{range .DocLines}}	//{{with .}} {{.}}{{end}}
 {{end}}	{{.Name}} {{.GoType}} ` + "`{{.StructTags}}`" + `
 {{end}}}
+{{if $.EmitGetters}}{{$struct := .}}{{range .Fields}}
+// Get{{.Name}} returns the {{.Name}} field of {{$struct.Name}}, or the zero value when x{{if .GetterDeref}} or the field{{end}} is nil
+func (x *{{$struct.Name}}) Get{{.Name}}() {{.GetterType}} {
+	if x == nil{{if .GetterDeref}} || x.{{.Name}} == nil{{end}} {
+		return {{.GetterZero}}
+	}
+
+	return {{if .GetterDeref}}*{{end}}x.{{.Name}}
+}
+{{end}}{{end}}{{if and $.EmitConstructors .IsInput}}
+// New{{.Name}} returns a {{.Name}} populated with its required fields
+func New{{.Name}}({{range $i, $f := .RequiredFields}}{{if $i}}, {{end}}{{$f.ParamName}} {{$f.GoType}}{{end}}) {{.Name}} {
+	return {{.Name}}{
+{{range .RequiredFields}}		{{.Name}}: {{.ParamName}},
+{{end}}	}
+}
+{{end}}{{if .Tuple}}
+// MarshalJSON encodes {{.Name}} as a JSON array of its elements
+func (x {{.Name}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{ {{- range $i, $f := .Fields}}{{if $i}}, {{end}}x.{{$f.Name}}{{end -}} })
+}
+
+// UnmarshalJSON decodes {{.Name}} from a JSON array of exactly {{len .Fields}} elements
+func (x *{{.Name}}) UnmarshalJSON(data []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return fmt.Errorf("failed to decode {{.Name}}: %w", err)
+	}
+
+	if len(elems) != {{len .Fields}} {
+		return fmt.Errorf("failed to decode {{.Name}}: expected {{len .Fields}} elements, got %d", len(elems))
+	}
+{{$struct := .}}{{range $i, $f := .Fields}}
+	if err := json.Unmarshal(elems[{{$i}}], &x.{{$f.Name}}); err != nil {
+		return fmt.Errorf("failed to decode {{$struct.Name}} element {{$i}}: %w", err)
+	}
+{{end}}
+	return nil
+}
+{{end}}{{if .TemplateConst}}
+// {{.TemplateConst}} is the handlebars template of the prompt {{.Name}} renders
+const {{.TemplateConst}} = {{.TemplateLiteral}}
+
+// Render executes {{.TemplateConst}} with x, resolving template variables by json field name
+func (x {{.Name}}) Render() (string, error) {
+	return render.Execute({{.TemplateConst}}, x)
+}
+{{end}}{{with .Metadata}}{{if .Model}}
+// {{.Name}}Model is the model named in the frontmatter of the {{.Name}} prompt
+const {{.Name}}Model = {{.Model}}
+{{end}}{{if .Config}}
+// {{.Name}}Config is the model config in the frontmatter of the {{.Name}} prompt
+var {{.Name}}Config = {{.Config}}
+{{end}}{{end}}{{if .Fixtures}}
+// {{.Name}}Examples holds the examples of the {{.Name}} schema
+var {{.Name}}Examples = []{{.Name}}{
+{{range .Fixtures}}	{{.}},
+{{end}}}
+{{end}}{{if .UnionFields}}
+// UnmarshalJSON decodes {{.Name}}, choosing the variant of each union field from its discriminator
+func (x *{{.Name}}) UnmarshalJSON(data []byte) error {
+	type plain {{.Name}}
+
+	var raw struct {
+		*plain
+{{range .UnionFields}}		{{.Name}} json.RawMessage ` + "`json:\"{{.JSONKey}}\"`" + `
+{{end}}	}
+
+	raw.plain = (*plain)(x)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to decode {{.Name}}: %w", err)
+	}
+{{range .UnionFields}}
+	if len(raw.{{.Name}}) > 0 && string(raw.{{.Name}}) != "null" {
+		value, err := Unmarshal{{.Union.Name}}(raw.{{.Name}})
+		if err != nil {
+			return err
+		}
+
+		x.{{.Name}} = value
+	}
 {{end}}
+	return nil
+}
+{{end}}{{$struct := .}}{{with .DefaultFields}}
+// ApplyDefaults sets the zero-valued fields of {{$struct.Name}} to their schema defaults
+func (x *{{$struct.Name}}) ApplyDefaults() {
+{{range $i, $f := .}}{{if $i}}
+{{end}}	{{$f.DefaultStmt}}
+{{end}}}
+{{end}}{{if $.EmitReset}}
+// Reset zeroes all fields of {{.Name}} so the instance can be reused, e.g. from a sync.Pool
+func (x *{{.Name}}) Reset() {
+	*x = {{.Name}}{}
+}
+{{end}}{{if $.EmitIsZero}}
+// IsZero reports whether every field of {{.Name}} holds its zero value; empty slices and maps count as zero
+func (x {{.Name}}) IsZero() bool {
+	return {{range $i, $f := .Fields}}{{if $i}} &&
+		{{end}}{{$f.IsZeroCond}}{{end}}
+}
+{{end}}{{if and $.EmitValidate .NeedsValidation}}
+// Validate checks the enum and nested struct fields of {{.Name}} and joins their errors
+func (x {{.Name}}) Validate() error {
+	var validators []validator.Validator
+{{range .Fields}}{{if .ValidatorStmt}}
+	{{.ValidatorStmt}}
+{{end}}{{end}}
+	return validator.ValidateAll(validators...)
+}
+{{end}}{{if $.EmitValidateAll}}
+// ValidateAll validates every enum and nested struct field of {{.Name}} and returns all failures
+func (x {{.Name}}) ValidateAll() []error {
+	var errs []error
+{{range .Fields}}{{if .ValidateStmt}}
+	{{.ValidateStmt}}
+{{end}}{{end}}
+	return errs
+}
+{{end}}{{$struct := .}}{{with .PrimaryField}}
+// String returns the {{.Name}} value, the primary field of {{$struct.Name}}
+func (x {{$struct.Name}}) String() string {
+{{if .IsPointer}}	if x.{{.Name}} == nil {
+		return ""
+	}
+
+	return {{.StringExpr (print "*x." .Name)}}
+{{else if .IsOptional}}	value, ok := x.{{.Name}}.Get()
+	if !ok {
+		return ""
+	}
+
+	return {{.StringExpr "value"}}
+{{else}}	return {{.StringExpr (print "x." .Name)}}
+{{end}}}
+{{end}}{{if $.EmitExamples}}
+// Example{{.Name}} returns a sample {{.Name}} whose enum fields hold valid values
+func Example{{.Name}}() {{.Name}} {
+	return {{.Name}}{
+{{range .Fields}}{{if .Example}}		{{.Name}}: {{.Example}},
+{{end}}{{end}}	}
+}
+{{end}}{{end}}
+{{end}}{{range .Unions}}
+// {{.Name}} is {{with .Comment}}{{.}}, {{end}}one of the variants selected by the "{{.Discriminator}}" property
+type {{.Name}} interface {
+	is{{.Name}}()
+}
+{{$union := .}}{{range .Variants}}
+func ({{.StructName}}) is{{$union.Name}}() {}
 {{end}}
+// Unmarshal{{.Name}} decodes a {{.Name}}, choosing the variant from its "{{.Discriminator}}" property
+func Unmarshal{{.Name}}(data []byte) ({{.Name}}, error) {
+	var probe struct {
+		Discriminator string ` + "`json:\"{{.Discriminator}}\"`" + `
+	}
+
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to decode {{.Name}}: %w", err)
+	}
+
+	switch probe.Discriminator {
+{{range .Variants}}	case {{printf "%q" .Value}}:
+		var variant {{.StructName}}
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return nil, fmt.Errorf("failed to decode {{.StructName}}: %w", err)
+		}
+
+		return variant, nil
+{{end}}	default:
+		return nil, fmt.Errorf("unknown {{.Name}} {{.Discriminator}} %q", probe.Discriminator)
+	}
+}
+{{end}}{{if .DeclareEnumErrors}}
+// ErrInvalidEnum is wrapped by the errors of every enum Validate() and Parse function in this package
+var ErrInvalidEnum = errors.New("invalid enum value")
+{{end}}{{if .DeclareOptional}}` + optionalDecl + `{{end}}
 {{range .Enums}}
 // {{.Name}} represents {{.Comment}}
-type {{.Name}} {{.Type}}
+type {{.Name}} {{.DeclType}}
 
 const (
-{{$enumType := .Name}}{{range .Values}}	{{.ConstName}} {{$enumType}} = "{{.Value}}"
-{{end}})
+{{$enum := .}}{{range $i, $v := .Values}}{{with .Comment}}	// {{.}}
+{{end}}{{if not $enum.IsSequential}}	{{.ConstName}} {{$enum.Name}} = {{$enum.Literal .Value}}
+{{else if $i}}	{{.ConstName}}
+{{else}}	{{.ConstName}} {{$enum.Name}} = iota
+{{end}}{{end}})
+
+// All{{.Name}} lists every {{.Name}} value in schema declaration order
+var All{{.Name}} = []{{.Name}}{ {{- range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.ConstName}}{{end -}} }
 
 // Validate checks if the {{.Name}} value is valid
 func (e {{.Name}}) Validate() error {
@@ -47,34 +235,237 @@ func (e {{.Name}}) Validate() error {
 	case {{$enumType := .Name}}{{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.ConstName}}{{end}}:
 		return nil
 	default:
-		return fmt.Errorf("invalid {{.Name}} value: %q, must be one of: {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Value}}{{end}}", {{if eq .Type "string"}}string(e){{else}}e{{end}})
+		return fmt.Errorf("%w {{if .IsNumeric}}%v{{else}}%q{{end}} for {{.Name}}, must be one of: {{.ValueList}}", ErrInvalidEnum, {{.Type}}(e))
+	}
+}
+
+// Parse{{.Name}} returns s as a {{.Name}}, or the Validate() error when it is not a valid value
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+{{- if .IsNumeric}}
+	value, err := {{if eq .Type "int"}}strconv.Atoi(s){{else}}strconv.ParseFloat(s, 64){{end}}
+	if err != nil {
+		return 0, fmt.Errorf("%w %q for {{.Name}}, must be one of: {{.ValueList}}", ErrInvalidEnum, s)
+	}
+
+	e := {{.Name}}(value)
+{{- else}}
+	e := {{.Name}}(s)
+{{- end}}
+	if err := e.Validate(); err != nil {
+		return {{if .IsNumeric}}0{{else}}""{{end}}, err
 	}
+
+	return e, nil
 }
 
+// MustParse{{.Name}} is like Parse{{.Name}} but panics when s is not a valid value, e.g. in test fixtures
+func MustParse{{.Name}}(s string) {{.Name}} {
+	e, err := Parse{{.Name}}(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+{{if not .HasCustomBase}}
+// String returns the underlying {{.Type}} value of the {{.Name}}
+func (e {{.Name}}) String() string {
+	return {{if .IsNumeric}}fmt.Sprint({{.Type}}(e)){{else}}string(e){{end}}
+}
+{{end}}
+// {{.ValuesFuncName}} returns a copy of All{{.Name}} that callers may modify
+func {{.ValuesFuncName}}() []{{.Name}} {
+	return append([]{{.Name}}(nil), All{{.Name}}...)
+}
+{{if $.StrictEnums}}
+// MarshalJSON encodes the {{.Name}} value as its underlying {{.Type}}
+func (e {{.Name}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal({{.Type}}(e))
+}
+
+// UnmarshalJSON decodes a {{.Name}} value and rejects values that are not valid {{.Name}} constants
+func (e *{{.Name}}) UnmarshalJSON(data []byte) error {
+	var value {{.Type}}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode {{.Name}}: %w", err)
+	}
+
+	decoded := {{.Name}}(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
+{{end}}{{if .SetHelpers}}
+// Validate{{.Name}}Set checks that s holds valid {{.Name}} values, each at most once
+func Validate{{.Name}}Set(s []{{.Name}}) error {
+	seen := make(map[{{.Name}}]bool, len(s))
+
+	for i, e := range s {
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+
+		if seen[e] {
+			return fmt.Errorf("duplicate {{.Name}} value {{if .IsNumeric}}%v{{else}}%q{{end}} at index %d", {{.Type}}(e), i)
+		}
+
+		seen[e] = true
+	}
+
+	return nil
+}
+
+// Has{{.Name}} reports whether s contains e
+func Has{{.Name}}(s []{{.Name}}, e {{.Name}}) bool {
+	return slices.Contains(s, e)
+}
+{{end}}{{if .ErrorSet}}
+// Typed errors for each {{.Name}} value, usable with errors.Is
+var (
+{{range .Values}}	{{.ErrorName}} = errors.New({{printf "%q" .Value}})
+{{end}})
+
+// Err returns the typed error for the {{.Name}} value, or nil for unknown values
+func (e {{.Name}}) Err() error {
+	switch e {
+{{range .Values}}	case {{.ConstName}}:
+		return {{.ErrorName}}
+{{end}}	default:
+		return nil
+	}
+}
+{{end}}
 {{end}}`
 
+// errorCodeFieldName is the output field name whose enum is turned into a typed error set.
+const errorCodeFieldName = "error_code"
+
+// ErrStaleOutput is returned in dry-run mode when writing the generated code would change a file.
+var ErrStaleOutput = errors.New("generated code would change")
+
 // GenerateGoCode generates Go code from structs and enums.
 func GenerateGoCode(
 	structs []codegen.GoStruct,
 	enums []codegen.GoEnum,
 	packageName string,
 ) ([]byte, error) {
-	tmpl := template.Must(template.New("gocode").Parse(goStructTemplate))
+	return GenerateGoCodeWithOptions(codegen.Generator{PackageName: packageName}, structs, enums)
+}
+
+// GenerateGoCodeWithOptions generates Go code from structs and enums using generator options.
+func GenerateGoCodeWithOptions(
+	g codegen.Generator,
+	structs []codegen.GoStruct,
+	enums []codegen.GoEnum,
+) ([]byte, error) {
+	return generateGoCode(g, structs, enums, fileHeader{})
+}
+
+// generateGoCode generates Go code whose header names the prompt files it is generated from.
+func generateGoCode(g codegen.Generator, structs []codegen.GoStruct, enums []codegen.GoEnum, header fileHeader) ([]byte, error) {
+	tmpl, err := goCodeTemplate(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkMethodNames(g, structs); err != nil {
+		return nil, err
+	}
+
+	enums = withValuesFuncNames(structs, enums)
 
 	// Determine required imports
 	var imports []string
 
-	// Add fmt import if we have enums (needed for validation error messages)
-	if len(enums) > 0 {
+	unions := collectUnions(structs)
+	declareOptional := usesOptional(structs) && !header.sharedDecls
+
+	// Add encoding/json import if strict enums, discriminated unions, tuples or Optional encode themselves
+	hasTuples := slices.ContainsFunc(structs, func(s codegen.GoStruct) bool { return s.Tuple })
+	if (g.StrictEnums && len(enums) > 0) || len(unions) > 0 || hasTuples || declareOptional {
+		imports = append(imports, "encoding/json")
+	}
+
+	declareEnumErrors := len(enums) > 0 && !header.sharedDecls
+
+	// Add errors import if ErrInvalidEnum is declared or any enum generates typed error values
+	if declareEnumErrors || hasErrorSetEnum(enums) {
+		imports = append(imports, "errors")
+	}
+
+	// Add fmt import if we have enums (needed for validation error messages), union decode errors,
+	// tuple decode errors, primary fields that are formatted by String() or ValidateAll() methods wrapping field errors
+	if len(enums) > 0 || len(unions) > 0 || hasTuples || hasFormattedPrimaryField(structs) || hasValidateAllStatements(structs) {
 		imports = append(imports, "fmt")
 	}
 
+	// Add strconv import for Parse functions of numeric enums
+	if slices.ContainsFunc(enums, codegen.GoEnum.IsNumeric) {
+		imports = append(imports, "strconv")
+	}
+
+	// Add slices import for the Has<Enum> helpers of enum sets
+	if slices.ContainsFunc(enums, func(e codegen.GoEnum) bool { return e.SetHelpers }) {
+		imports = append(imports, "slices")
+	}
+
+	// Add reflect import for IsZero() conditions on types the generator does not know
+	if g.EmitIsZero && usesReflectIsZero(structs) {
+		imports = append(imports, "reflect")
+	}
+
+	// Add time import if any field uses time.Time or time.Duration
+	if usesTimeTypes(structs) {
+		imports = append(imports, "time")
+	}
+
+	// Add validator import for struct-level Validate() methods
+	if g.StructValidate && slices.ContainsFunc(structs, codegen.GoStruct.NeedsValidation) {
+		imports = append(imports, validatorImportPath)
+	}
+
+	// Add render import for Render() methods executing the prompt template
+	if hasRenderTemplate(structs) {
+		imports = append(imports, renderImportPath)
+	}
+
+	// Add imports contributed by x-codegen-import and -import, skipping ones already present
+	for _, importPath := range slices.Concat(fieldImports(structs), g.Imports) {
+		if !slices.Contains(imports, importPath) {
+			imports = append(imports, importPath)
+		}
+	}
+
+	if err := checkImportPaths(g.Imports); err != nil {
+		return nil, err
+	}
+
+	sortImports(imports)
+
 	templateData := codegen.TemplateData{
-		Version: Version,
-		Package: packageName,
-		Imports: imports,
-		Enums:   enums,
-		Structs: structs,
+		Version:          Version,
+		Source:           sourceHeader(header.sources),
+		InputHash:        header.inputHash,
+		Package:          g.PackageName,
+		Imports:          imports,
+		Enums:            enums,
+		Structs:          structs,
+		Unions:           unions,
+		EmitReset:        g.Reset,
+		EmitExamples:     g.Examples,
+		EmitValidateAll:  g.ValidateAll,
+		StrictEnums:      g.StrictEnums,
+		EmitConstructors: g.Constructors,
+		EmitValidate:     g.StructValidate && len(structs) > 0,
+		EmitGetters:      g.Getters,
+		EmitIsZero:       g.EmitIsZero,
+
+		DeclareEnumErrors: declareEnumErrors,
+		DeclareOptional:   declareOptional,
 	}
 
 	var buf bytes.Buffer
@@ -82,25 +473,101 @@ func GenerateGoCode(
 		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	// Format the generated code
-	formatted, err := format.Source(buf.Bytes())
+	formatted, err := formatGoCode(g, buf.Bytes())
 	if err != nil {
 		// Return unformatted code if formatting fails
-		return buf.Bytes(), fmt.Errorf("failed to format generated code: %w", err)
+		return buf.Bytes(), err
 	}
 
 	return formatted, nil
 }
 
-// ProcessFile processes a single prompt file.
+// goCodeTemplate returns the user template set with -template, or the built-in Go template.
+func goCodeTemplate(g codegen.Generator) (*template.Template, error) {
+	if g.Template == "" {
+		return template.Must(template.New("gocode").Parse(goStructTemplate)), nil
+	}
+
+	tmpl, err := template.New("custom").Parse(g.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// LoadTemplate reads a custom code generation template and checks that it parses, so a broken
+// template is reported before any prompt file is processed.
+func LoadTemplate(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+
+	if _, err := template.New(filepath.Base(path)).Parse(string(data)); err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return string(data), nil
+}
+
+// ProcessFile processes a single prompt file. With g.Verbose a summary of the generated file is
+// printed after it is written.
 func ProcessFile(g codegen.Generator, inputFile string) error {
+	file, err := renderFile(g, inputFile)
+	if err != nil || file == nil {
+		return err
+	}
+
+	if err := writeFiles(g, file); err != nil {
+		return err
+	}
+
+	if g.Verbose {
+		return writeSummary(g, os.Stdout, []*generatedFile{file})
+	}
+
+	return nil
+}
+
+// writeFiles writes generated files together with the shared files they depend on.
+func writeFiles(g codegen.Generator, files ...*generatedFile) error {
+	files, err := withSharedFiles(g, files)
+	if err != nil {
+		return err
+	}
+
+	if err := checkOutputPackages(g, files); err != nil {
+		return err
+	}
+
+	if err := ensureOutputDirs(g, files...); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := file.write(g); err != nil {
+			return err
+		}
+	}
+
+	return verifyOutputPackages(g, files)
+}
+
+// renderFile parses a single prompt file and renders its generated code without writing it.
+// It returns nil when the prompt file has nothing to generate, and the existing output marked as
+// cached when it records the current input hash unless g.Force is set. Dry runs never trust the
+// hash, they diff the rendered code so hand edits below an unchanged header are reported.
+func renderFile(g codegen.Generator, inputFile string) (*generatedFile, error) {
 	if g.Verbose {
 		fmt.Printf("Processing file: %s\n", inputFile)
 	}
 
+	start := time.Now()
+
 	promptFile, err := parser.ParsePromptFile(inputFile)
 	if err != nil {
-		return fmt.Errorf("failed to parse prompt file: %w", err)
+		return nil, fmt.Errorf("failed to parse prompt file: %w", err)
 	}
 
 	if !promptFile.HasSchema() {
@@ -108,57 +575,284 @@ func ProcessFile(g codegen.Generator, inputFile string) error {
 			fmt.Printf("Skipping %s: no schema found\n", inputFile)
 		}
 
-		return nil
+		return nil, nil
 	}
 
-	return generateFromPromptFile(g, promptFile)
+	if !g.Force && !g.DryRun {
+		if file := cachedFile(g, promptFile); file != nil {
+			file.stats.duration = time.Since(start)
+
+			return file, nil
+		}
+	}
+
+	file, err := renderPromptFile(g, promptFile)
+	if err != nil {
+		return nil, writeRawOutput(g, err)
+	}
+
+	if file != nil {
+		file.stats.duration = time.Since(start)
+	}
+
+	return file, nil
 }
 
 // ProcessDirectory processes all .prompt files in a directory.
+// Files are rendered concurrently on g.Jobs workers and every file is rendered before anything
+// is written, so type names that would be declared twice in the same output directory are
+// reported without touching existing files.
 func ProcessDirectory(g codegen.Generator, inputDir string) error {
 	if g.Verbose {
 		fmt.Printf("Processing directory: %s\n", inputDir)
 	}
 
-	err := filepath.Walk(inputDir, func(path string, _ os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	if g.SingleFile != "" {
+		return processDirectorySingleFile(g, inputDir)
+	}
 
-		if !strings.HasSuffix(path, ".prompt") {
-			return nil
-		}
+	var paths []string
 
+	err := walkPrompts(g, inputDir, func(path string, _ fs.DirEntry) error {
 		if g.Verbose {
 			fmt.Printf("Found prompt file: %s\n", path)
 		}
 
-		return ProcessFile(g, path)
+		paths = append(paths, path)
+
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
 	}
 
+	var (
+		files      []*generatedFile
+		fileErrors []error
+	)
+
+	for _, result := range renderFiles(g, inputDir, paths) {
+		if result.err != nil {
+			fileErrors = append(fileErrors, result.err)
+		} else if result.file != nil {
+			files = append(files, result.file)
+		}
+	}
+
+	// Without -keep-going nothing is written once a prompt fails
+	if len(fileErrors) > 0 && !g.KeepGoing {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, errors.Join(fileErrors...))
+	}
+
+	if !g.AllowDuplicateTypes {
+		if err := checkDuplicateTypes(files); err != nil {
+			return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+		}
+	}
+
+	promptFiles := files
+
+	files, err = withSharedFiles(g, files)
+	if err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	if err := checkOutputPackages(g, files); err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	if err := ensureOutputDirs(g, files...); err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	staleFiles := 0
+
+	for _, file := range files {
+		if err := file.write(g); err != nil {
+			if !errors.Is(err, ErrStaleOutput) {
+				return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+			}
+
+			staleFiles++
+		}
+	}
+
+	if err := verifyOutputPackages(g, files); err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	if g.Verbose {
+		if err := writeSummary(g, os.Stdout, promptFiles); err != nil {
+			return err
+		}
+	}
+
+	if len(fileErrors) > 0 {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, errors.Join(fileErrors...))
+	}
+
+	if staleFiles > 0 {
+		return fmt.Errorf("%d generated files in %s: %w", staleFiles, inputDir, ErrStaleOutput)
+	}
+
 	return nil
 }
 
 // generateFromPromptFile generates Go code from a parsed prompt file.
 func generateFromPromptFile(g codegen.Generator, promptFile *ast.PromptFile) error {
-	requestName, responseName := FilenameToStructNames(promptFile.Filename)
+	file, err := renderPromptFile(g, promptFile)
+	if err != nil || file == nil {
+		return writeRawOutput(g, err)
+	}
+
+	return writeFiles(g, file)
+}
+
+// renderPromptFile renders the generated code for a parsed prompt file without writing it.
+// It returns nil when the prompt file produces no structs.
+func renderPromptFile(g codegen.Generator, promptFile *ast.PromptFile) (*generatedFile, error) {
+	packageName, err := promptPackage(g, promptFile)
+	if err != nil {
+		return nil, err
+	}
+
+	g.PackageName = packageName
+
+	structs, allEnums, err := buildPromptTypes(g, promptFile)
+	if err != nil || len(structs) == 0 {
+		return nil, err
+	}
 
+	header := fileHeader{
+		sources:     []string{promptFile.Filename},
+		inputHash:   inputHash(g, promptFile),
+		sharedDecls: true,
+	}
+
+	file, err := renderGeneratedCode(g, structs, allEnums, header, getOutputFilePath(g, promptFile.Filename))
+	if err != nil {
+		return nil, err
+	}
+
+	file.stats = fileStats{structs: len(structs), enums: len(allEnums), depth: structDepth(structs)}
+
+	return file, nil
+}
+
+// promptPackage returns the package a prompt file is generated into: its ext.codegen.package
+// metadata when set, otherwise the -pkg package or the name of its output directory.
+func promptPackage(g codegen.Generator, promptFile *ast.PromptFile) (string, error) {
+	value := promptFile.ExtValue("codegen", "package")
+	if value == nil {
+		return outputPackage(g, getOutputFilePath(g, promptFile.Filename))
+	}
+
+	packageName, ok := value.(string)
+	if !ok || !token.IsIdentifier(packageName) {
+		return "", fmt.Errorf("invalid ext.codegen.package %v: must be a Go package name", value)
+	}
+
+	return packageName, nil
+}
+
+// outputPackage returns g.PackageName, or when it is empty the package named after the directory
+// outputPath is written to, so "internal/chat-models" generates package chatmodels.
+func outputPackage(g codegen.Generator, outputPath string) (string, error) {
+	if g.PackageName != "" || g.Language == LanguageZod {
+		return g.PackageName, nil
+	}
+
+	outputDir, err := filepath.Abs(filepath.Dir(outputPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the output directory of %s: %w", outputPath, err)
+	}
+
+	packageName, err := packageNameFromDir(filepath.Base(outputDir))
+	if err != nil {
+		return "", fmt.Errorf("%w, set it with -pkg", err)
+	}
+
+	return packageName, nil
+}
+
+// buildPromptTypes parses the schemas of a prompt file into the structs and enums to generate and
+// runs every generation pass over them. It returns no structs when the prompt file produces none.
+func buildPromptTypes(g codegen.Generator, promptFile *ast.PromptFile) ([]codegen.GoStruct, []codegen.GoEnum, error) {
+	inputSuffix, outputSuffix, err := structSuffixes(g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestName, responseName := promptStructNames(initialisms(g), promptFile.Filename, inputSuffix, outputSuffix)
+
+	return buildTypes(g, promptFile, requestName, responseName)
+}
+
+// buildTypes builds the input and output structs of a prompt file under the given names, together
+// with their nested structs and enums.
+func buildTypes(
+	g codegen.Generator,
+	promptFile *ast.PromptFile,
+	requestName, responseName string,
+) ([]codegen.GoStruct, []codegen.GoEnum, error) {
 	var (
 		structs  []codegen.GoStruct
 		allEnums []codegen.GoEnum
 	)
 
+	names := initialisms(g)
+
+	// In keep-going mode independent problems are collected instead of aborting on the first one
+	var problems []error
+
 	// Generate input struct if schema exists
-	if err := generateInputStruct(promptFile, requestName, &structs, &allEnums); err != nil {
-		return fmt.Errorf("failed to generate input struct: %w", err)
+	if err := generateInputStruct(promptFile, requestName, names, &structs, &allEnums); err != nil {
+		problems = append(problems, fmt.Errorf("failed to generate input struct: %w", err))
+		if !g.KeepGoing {
+			return nil, nil, problems[0]
+		}
 	}
 
+	inputStructs := len(structs)
+
 	// Generate output struct if schema exists
-	if err := generateOutputStruct(promptFile, responseName, &structs, &allEnums); err != nil {
-		return fmt.Errorf("failed to generate output struct: %w", err)
+	outputType := parser.SchemaTypeOutput
+	if g.AllRequired {
+		outputType = parser.SchemaTypeRequiredOutput
+	}
+
+	if err := generateOutputStruct(promptFile, responseName, outputType, names, &structs, &allEnums); err != nil {
+		problems = append(problems, fmt.Errorf("failed to generate output struct: %w", err))
+		if !g.KeepGoing {
+			return nil, nil, problems[0]
+		}
+	}
+
+	// Definitions referenced by both schemas can differ per direction, e.g. in their pointer fields
+	if inputStructs > 0 {
+		_, outputSuffix, err := structSuffixes(g)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		renameOutputCopies(structs[:inputStructs], structs[inputStructs:], outputSuffix)
+	}
+
+	// Template issues fail generation in strict and keep-going mode and are only reported otherwise
+	if issues := templateProblems(g, promptFile); g.KeepGoing || g.StrictTemplate {
+		problems = append(problems, issues...)
+	} else {
+		warnProblems(promptFile, issues, "-strict-template")
+	}
+
+	if issues := requiredFieldProblems(promptFile); g.Strict {
+		problems = append(problems, issues...)
+	} else {
+		warnProblems(promptFile, issues, "-strict")
+	}
+
+	if len(problems) > 0 {
+		return nil, nil, errors.Join(problems...)
 	}
 
 	if len(structs) == 0 {
@@ -166,18 +860,206 @@ func generateFromPromptFile(g codegen.Generator, promptFile *ast.PromptFile) err
 			fmt.Printf("No structs to generate for %s\n", promptFile.Filename)
 		}
 
-		return nil
+		return nil, nil, nil
+	}
+
+	// Nested enums are prefixed with the struct names from the parser, so before titles rename them
+	if g.PrefixEnums {
+		applyEnumPrefixes(structs, allEnums)
+	}
+
+	// Structs are renamed after their schema title before the duplicate checks below see them
+	if !g.IgnoreTitle {
+		structs = applyTitleNames(structs)
+	}
+
+	structs, allEnums = applyUnions(structs, allEnums, g.ExperimentalUnions && g.Language != LanguageZod)
+
+	structs, err := dedupeStructs(structs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate structs for %s: %w", promptFile.Filename, err)
+	}
+
+	if g.DedupeStructs {
+		structs = collapseIdenticalStructs(structs)
+	}
+
+	if err := checkPrimaryFields(structs); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate structs for %s: %w", promptFile.Filename, err)
+	}
+
+	allEnums, err = dedupeEnums(allEnums)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate enums for %s: %w", promptFile.Filename, err)
+	}
+
+	warnEnumDescriptions(promptFile, allEnums)
+
+	if g.MergeEnums {
+		allEnums, err = mergeIdenticalEnums(structs, allEnums, names)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to merge enums for %s: %w", promptFile.Filename, err)
+		}
+	}
+
+	if g.StrictEnumNames {
+		if err := checkEnumConstNames(allEnums, names); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate enums for %s: %w", promptFile.Filename, err)
+		}
+	}
+
+	applyEnumBaseType(allEnums, g.EnumBase)
+	if !g.NoTimeTypes && g.Language != LanguageZod {
+		applyTimeTypes(structs)
+	}
+
+	applyTypeMappings(structs, g.TypeMappings)
+
+	if g.Language != LanguageZod {
+		if err := applyOptionalStyle(g, structs); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate structs for %s: %w", promptFile.Filename, err)
+		}
 	}
 
-	return writeGeneratedCode(g, structs, allEnums, promptFile.Filename)
+	if g.ConstraintTags {
+		if err := applyConstraintTags(structs); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate structs for %s: %w", promptFile.Filename, err)
+		}
+	}
+
+	if !g.NoOmitEmpty {
+		markOptionalFieldsOmitEmpty(structs)
+	}
+
+	if g.ErrorTypes {
+		markErrorSetEnums(structs, allEnums)
+	}
+
+	if g.StrictEnums {
+		markSetEnums(structs, allEnums)
+	}
+
+	if g.Language != LanguageZod {
+		assignDefaultStatements(structs, allEnums, promptFile)
+	}
+
+	if g.Examples {
+		assignFieldExamples(structs, allEnums)
+	}
+
+	if g.SchemaExamples && g.Language != LanguageZod {
+		if err := assignSchemaFixtures(structs, allEnums, promptFile); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate examples for %s: %w", promptFile.Filename, err)
+		}
+	}
+
+	if g.ValidateAll {
+		assignValidateAllStatements(structs, allEnums)
+	}
+
+	if g.StructValidate {
+		assignStructValidateStatements(structs, allEnums)
+	}
+
+	if g.Getters {
+		assignGetters(structs, allEnums)
+	}
+
+	if g.EmitIsZero {
+		assignIsZeroConditions(structs, allEnums)
+	}
+
+	if g.Constructors {
+		assignParamNames(structs, names)
+	}
+
+	if g.EmitRender && g.Language != LanguageZod {
+		if err := embedRenderTemplate(structs, promptFile, names); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate Render() for %s: %w", promptFile.Filename, err)
+		}
+	}
+
+	if g.EmitMetadata && g.Language != LanguageZod {
+		if err := embedPromptMetadata(structs, promptFile, names); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate metadata for %s: %w", promptFile.Filename, err)
+		}
+	}
+
+	return structs, allEnums, nil
+}
+
+// markOptionalFieldsOmitEmpty adds omitempty to optional fields so nil values are left out of JSON.
+// Pointers, slices, maps and union interfaces get it unless required: required recursive and nullable
+// pointers keep the plain tag, so a nil value is encoded as null instead of dropping the key.
+func markOptionalFieldsOmitEmpty(structs []codegen.GoStruct) {
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+
+			nillable := strings.HasPrefix(field.GoType, "[]") || strings.HasPrefix(field.GoType, "map[") || field.Union != nil
+			if (field.IsPointer || field.IsOptional || nillable) && !field.Required {
+				field.OmitEmpty = true
+			}
+		}
+	}
+}
+
+// markErrorSetEnums marks the enum backing the output error_code field as a typed error set.
+func markErrorSetEnums(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	for _, goStruct := range structs {
+		if !goStruct.IsOutput {
+			continue
+		}
+
+		for _, field := range goStruct.Fields {
+			if field.JSONTag != errorCodeFieldName || !field.IsEnum {
+				continue
+			}
+
+			enumName := field.ValueType()
+			for i := range enums {
+				if enums[i].Name == enumName {
+					markErrorSetEnum(&enums[i])
+				}
+			}
+		}
+	}
+}
+
+// markErrorSetEnum flags an enum as an error set and assigns error variable names to its values.
+func markErrorSetEnum(enum *codegen.GoEnum) {
+	enum.ErrorSet = true
+	baseName := strings.TrimSuffix(enum.Name, "Enum")
+
+	for i, value := range enum.Values {
+		enum.Values[i].ErrorName = "Err" + baseName + strings.TrimPrefix(value.ConstName, enum.Name)
+	}
+}
+
+// hasErrorSetEnum checks if any enum generates typed error values.
+func hasErrorSetEnum(enums []codegen.GoEnum) bool {
+	for _, enum := range enums {
+		if enum.ErrorSet {
+			return true
+		}
+	}
+
+	return false
 }
 
 // generateInputStruct generates the input struct from prompt file schema.
-func generateInputStruct(promptFile *ast.PromptFile, requestName string, structs *[]codegen.GoStruct, allEnums *[]codegen.GoEnum) error {
+func generateInputStruct(
+	promptFile *ast.PromptFile,
+	requestName string,
+	names naming.Initialisms,
+	structs *[]codegen.GoStruct,
+	allEnums *[]codegen.GoEnum,
+) error {
 	return generateStruct(
 		promptFile.GetInputSchema(),
 		promptFile.GetRequiredInputFields(),
 		parser.SchemaTypeInput,
+		names,
 		promptFile.InputFieldOrder,
 		promptFile.InputNestedFieldOrder,
 		requestName,
@@ -189,12 +1071,21 @@ func generateInputStruct(promptFile *ast.PromptFile, requestName string, structs
 	)
 }
 
-// generateOutputStruct generates the output struct from prompt file schema.
-func generateOutputStruct(promptFile *ast.PromptFile, responseName string, structs *[]codegen.GoStruct, allEnums *[]codegen.GoEnum) error {
+// generateOutputStruct generates the output struct from prompt file schema. schemaType is
+// SchemaTypeRequiredOutput with -all-required.
+func generateOutputStruct(
+	promptFile *ast.PromptFile,
+	responseName string,
+	schemaType parser.SchemaType,
+	names naming.Initialisms,
+	structs *[]codegen.GoStruct,
+	allEnums *[]codegen.GoEnum,
+) error {
 	return generateStruct(
 		promptFile.GetOutputSchema(),
 		promptFile.GetRequiredOutputFields(),
-		parser.SchemaTypeOutput,
+		schemaType,
+		names,
 		promptFile.OutputFieldOrder,
 		promptFile.OutputNestedFieldOrder,
 		responseName,
@@ -211,6 +1102,7 @@ func generateStruct(
 	schema any,
 	requiredFields []string,
 	schemaType parser.SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
 	nestedFieldOrder map[string][]string,
 	structName string,
@@ -228,6 +1120,7 @@ func generateStruct(
 		schema,
 		requiredFields,
 		schemaType,
+		names,
 		fieldOrder,
 		nestedFieldOrder,
 	)
@@ -235,15 +1128,16 @@ func generateStruct(
 		return err
 	}
 
+	bindRootRefs(fields, nestedStructs, structName)
+
 	if len(fields) > 0 {
 		*structs = append(*structs, codegen.GoStruct{
-			Name: structName,
-			Comments: []string{
-				fmt.Sprintf("%s represents the %s for %s", structName, getStructType(isInput), getPromptDescription(promptFile)),
-			},
+			Name:     structName,
+			Comments: rootStructComments(schema, structName, promptFile, isInput),
 			Fields:   fields,
 			IsInput:  isInput,
 			IsOutput: isOutput,
+			Title:    parser.SchemaTitleName(schema, names),
 		})
 	}
 
@@ -253,6 +1147,35 @@ func generateStruct(
 	return nil
 }
 
+// bindRootRefs points the fields referencing the root schema with $ref "#" at its struct.
+func bindRootRefs(fields []codegen.GoField, nestedStructs []codegen.GoStruct, structName string) {
+	renames := map[string]string{parser.RootRefType: structName}
+
+	for i := range fields {
+		fields[i].GoType = renameType(fields[i].GoType, renames)
+	}
+
+	for i := range nestedStructs {
+		for j := range nestedStructs[i].Fields {
+			nestedStructs[i].Fields[j].GoType = renameType(nestedStructs[i].Fields[j].GoType, renames)
+		}
+	}
+}
+
+// rootStructComments returns the doc comment of an input or output struct: the root schema
+// description like nested structs get, or a generic comment naming the prompt.
+func rootStructComments(schema any, structName string, promptFile *ast.PromptFile, isInput bool) []string {
+	description := parser.SchemaDescription(schema)
+	if description == "" {
+		return []string{fmt.Sprintf("%s represents the %s for %s", structName, getStructType(isInput), getPromptDescription(promptFile))}
+	}
+
+	lines := strings.Split(description, "\n")
+	lines[0] = fmt.Sprintf("%s represents %s", structName, lines[0])
+
+	return lines
+}
+
 // getStructType returns "input" or "output" based on the isInput flag.
 func getStructType(isInput bool) string {
 	if isInput {
@@ -273,37 +1196,270 @@ func addNestedStructs(structs *[]codegen.GoStruct, nestedStructs []codegen.GoStr
 
 // writeGeneratedCode generates and writes the Go code to file.
 func writeGeneratedCode(g codegen.Generator, structs []codegen.GoStruct, allEnums []codegen.GoEnum, filename string) error {
-	// Generate Go code
-	code, err := GenerateGoCode(structs, allEnums, g.PackageName)
+	file, err := renderGeneratedCode(g, structs, allEnums, fileHeader{sources: []string{filename}}, getOutputFilePath(g, filename))
+	if err != nil {
+		return err
+	}
+
+	if err := ensureOutputDirs(g, file); err != nil {
+		return err
+	}
+
+	if err := file.write(g); err != nil {
+		return err
+	}
+
+	return verifyOutputPackages(g, []*generatedFile{file})
+}
+
+// renderGeneratedCode renders structs and enums into a file that is ready to be written to outputFile.
+func renderGeneratedCode(
+	g codegen.Generator,
+	structs []codegen.GoStruct,
+	allEnums []codegen.GoEnum,
+	header fileHeader,
+	outputFile string,
+) (*generatedFile, error) {
+	var (
+		code, enumCode []byte
+		err            error
+	)
+
+	if g.SplitEnums && g.Language != LanguageZod && len(allEnums) > 0 {
+		code, enumCode, err = generateSplitCode(g, structs, allEnums, header, outputFile)
+	} else {
+		code, err = generateCodeForLanguage(g, structs, allEnums, header)
+		err = withFormatErrorPath(err, outputFile)
+	}
+
 	if err != nil {
-		return fmt.Errorf("failed to generate Go code: %w", err)
+		return nil, err
+	}
+
+	file := &generatedFile{
+		source:      strings.Join(header.sources, ", "),
+		outputPath:  outputFile,
+		packageName: g.PackageName,
+		code:        code,
+		enumCode:    enumCode,
+	}
+
+	if g.BuildCheck && g.Language != LanguageZod {
+		sources := []fileContent{{path: outputFile, code: code}}
+		if enumCode != nil {
+			sources = append(sources, fileContent{path: enumsFilePath(outputFile), code: enumCode})
+		}
+
+		if err := checkGoFilesCompile(sources); err != nil {
+			return nil, err
+		}
+	}
+
+	// Zod schemas are module scoped, so only Go declarations can collide across files
+	if g.Language != LanguageZod {
+		file.typeNames = declaredTypeNames(structs, allEnums)
+		file.usesEnumErrors = header.sharedDecls && len(allEnums) > 0
+		file.usesOptional = header.sharedDecls && usesOptional(structs)
+	}
+
+	if g.EmitTests && g.Language != LanguageZod {
+		file.testCode, err = generateGoTests(g, structs, allEnums)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
+
+// fileHeader is the provenance written below the generated code marker.
+type fileHeader struct {
+	sources   []string // prompt files the code is generated from, see sourceHeader
+	inputHash string   // inputHash of the prompt file, empty when the file is not cached
+
+	// ErrInvalidEnum and Optional are declared by the shared files of the output directory instead
+	sharedDecls bool
+}
+
+// sourceHeader returns the prompt files named in the generated file header: paths as they were
+// given when relative, so go:generate output is reproducible, and base names when absolute.
+func sourceHeader(sources []string) string {
+	names := make([]string, 0, len(sources))
+
+	for _, source := range sources {
+		if filepath.IsAbs(source) {
+			source = filepath.Base(source)
+		}
+
+		names = append(names, filepath.ToSlash(source))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// generatedFile is rendered code waiting to be written to its output path.
+type generatedFile struct {
+	source     string
+	outputPath string
+	code       []byte
+	testCode   []byte // round-trip tests written next to the code with -emit-tests
+	enumCode   []byte // enums written to their own file with -split-enums
+	typeNames  []string
+	cached     bool // the existing output is up to date, code is not rendered
+
+	packageName    string
+	usesEnumErrors bool // the code references ErrInvalidEnum declared by withSharedFiles
+	usesOptional   bool // the code references Optional declared by withSharedFiles
+
+	stats fileStats // reported in the -v summary
+}
+
+// fileContent is one file written for a generatedFile.
+type fileContent struct {
+	path string
+	code []byte
+}
+
+// contents returns the generated code and, when present, its enums and test files.
+func (f *generatedFile) contents() []fileContent {
+	contents := []fileContent{{path: f.outputPath, code: f.code}}
+	if f.enumCode != nil {
+		contents = append(contents, fileContent{path: enumsFilePath(f.outputPath), code: f.enumCode})
+	}
+
+	if f.testCode != nil {
+		contents = append(contents, fileContent{path: testFilePath(f.outputPath), code: f.testCode})
+	}
+
+	return contents
+}
+
+// write writes the generated code to its output path, or previews the change in dry-run mode.
+func (f *generatedFile) write(g codegen.Generator) error {
+	if f.cached {
+		if g.Verbose {
+			fmt.Printf("Unchanged %s (input hash matches)\n", f.outputPath)
+		}
+
+		return nil
+	}
+
+	if g.DryRun {
+		return f.preview(g)
+	}
+
+	for _, content := range f.contents() {
+		if err := os.WriteFile(content.path, content.code, 0o600); err != nil {
+			return fmt.Errorf("failed to write output file %s: %w", content.path, err)
+		}
+
+		fmt.Printf("Generated %s\n", content.path)
+	}
+
+	if g.SplitEnums && f.enumCode == nil && g.Language != LanguageZod {
+		return removeStaleEnumsFile(f.outputPath)
 	}
 
-	// Determine output file path
-	outputFile := getOutputFilePath(g, filename)
+	return nil
+}
+
+// preview prints the target path and a unified diff against the existing output file.
+// It returns ErrStaleOutput when writing the file would change it.
+func (f *generatedFile) preview(g codegen.Generator) error {
+	var stale []string
+
+	for _, content := range f.contents() {
+		changed, err := previewContent(g, content)
+		if err != nil {
+			return err
+		}
 
-	// Write generated code to file
-	if err := os.WriteFile(outputFile, code, 0o600); err != nil {
-		return fmt.Errorf("failed to write output file %s: %w", outputFile, err)
+		if changed {
+			stale = append(stale, content.path)
+		}
 	}
 
-	fmt.Printf("Generated %s\n", outputFile)
+	if len(stale) > 0 {
+		return fmt.Errorf("%s: %w", strings.Join(stale, ", "), ErrStaleOutput)
+	}
 
 	return nil
 }
 
+// previewContent prints the diff of one file and reports whether writing it would change it.
+func previewContent(g codegen.Generator, content fileContent) (bool, error) {
+	existing, err := os.ReadFile(content.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("failed to read output file %s: %w", content.path, err)
+	}
+
+	oldName := content.path
+	if err != nil {
+		oldName = os.DevNull
+	}
+
+	diff := unifiedDiff(oldName, content.path, existing, content.code)
+	if diff == "" {
+		if g.Verbose {
+			fmt.Printf("Unchanged %s\n", content.path)
+		}
+
+		return false, nil
+	}
+
+	fmt.Printf("Would write %s\n", content.path)
+	fmt.Print(diff)
+
+	return true, nil
+}
+
+// generateCodeForLanguage renders the structs and enums in the configured target language.
+func generateCodeForLanguage(
+	g codegen.Generator,
+	structs []codegen.GoStruct,
+	allEnums []codegen.GoEnum,
+	header fileHeader,
+) ([]byte, error) {
+	switch g.Language {
+	case "", LanguageGo:
+		code, err := generateGoCode(g, structs, allEnums, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Go code: %w", err)
+		}
+
+		return code, nil
+	case LanguageZod:
+		code, err := generateZodCode(structs, allEnums, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Zod code: %w", err)
+		}
+
+		return code, nil
+	default:
+		return nil, fmt.Errorf("unsupported language %q: expected %s or %s", g.Language, LanguageGo, LanguageZod)
+	}
+}
+
 // parseSchemaWithNestedFieldOrder is a wrapper that calls the appropriate parser with nested field order support.
 func parseSchemaWithNestedFieldOrder(
 	schema any,
 	requiredFields []string,
 	schemaType parser.SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
 	nestedFieldOrder map[string][]string,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
-	// For now, we only support nested field order for JSON Schema
-	// Picoschema doesn't support nested objects yet
+	if parser.IsPicoschema(schema) {
+		fields, enums, structs, err := parser.ParsePicoschemaWithNestedFieldOrder(schema, requiredFields, schemaType, names, fieldOrder, nestedFieldOrder)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse Picoschema with nested field order: %w", err)
+		}
+
+		return fields, enums, structs, nil
+	}
+
 	if parser.IsJSONSchema(schema) {
-		fields, enums, structs, err := parser.ParseJSONSchemaWithNestedFieldOrder(schema, requiredFields, schemaType, fieldOrder, nestedFieldOrder)
+		fields, enums, structs, err := parser.ParseJSONSchemaWithNestedFieldOrder(schema, requiredFields, schemaType, names, fieldOrder, nestedFieldOrder)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to parse JSON schema with nested field order: %w", err)
 		}
@@ -312,7 +1468,7 @@ func parseSchemaWithNestedFieldOrder(
 	}
 
 	// Fall back to regular parsing for other schema types
-	fields, enums, structs, err := parser.ParseSchemaWithStructsAndFieldOrder(schema, requiredFields, schemaType, fieldOrder)
+	fields, enums, structs, err := parser.ParseSchemaWithStructsAndFieldOrder(schema, requiredFields, schemaType, names, fieldOrder)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to parse schema with structs and field order: %w", err)
 	}
@@ -323,7 +1479,7 @@ func parseSchemaWithNestedFieldOrder(
 // getOutputFilePath determines the output file path.
 func getOutputFilePath(g codegen.Generator, inputFile string) string {
 	baseName := strings.TrimSuffix(filepath.Base(inputFile), ".prompt")
-	outputFileName := baseName + ".gen.go"
+	outputFileName := baseName + outputFileExtension(g)
 
 	if g.OutputDir != "" {
 		return filepath.Join(g.OutputDir, outputFileName)
@@ -335,6 +1491,15 @@ func getOutputFilePath(g codegen.Generator, inputFile string) string {
 	return filepath.Join(inputDir, outputFileName)
 }
 
+// outputFileExtension returns the generated file extension for the target language.
+func outputFileExtension(g codegen.Generator) string {
+	if g.Language == LanguageZod {
+		return ".zod.ts"
+	}
+
+	return ".gen.go"
+}
+
 // getPromptDescription extracts a description from the prompt file.
 func getPromptDescription(promptFile *ast.PromptFile) string {
 	baseName := strings.TrimSuffix(filepath.Base(promptFile.Filename), ".prompt")
@@ -1,10 +1,13 @@
 package generator
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/oter/dotprompt-gen-go/internal/codegen"
 	"github.com/oter/dotprompt-gen-go/internal/naming"
@@ -44,6 +47,21 @@ func processTestPrompt(t *testing.T, gen codegen.Generator, promptFileName strin
 	return string(generatedCode)
 }
 
+// processPromptContent writes prompt content to a temp file, processes it and returns the generated code
+func processPromptContent(t *testing.T, gen codegen.Generator, promptFileName, content string) string {
+	t.Helper()
+
+	inputFile := filepath.Join(t.TempDir(), promptFileName)
+	require.NoError(t, os.WriteFile(inputFile, []byte(content), 0o600))
+	require.NoError(t, ProcessFile(gen, inputFile), "Failed to process prompt file %s", promptFileName)
+
+	outputFileName := strings.TrimSuffix(promptFileName, ".prompt") + ".gen.go"
+	generatedCode, err := os.ReadFile(filepath.Join(gen.OutputDir, outputFileName))
+	require.NoError(t, err, "Failed to read generated file")
+
+	return string(generatedCode)
+}
+
 // TestGeneratedCodeCompiles tests that deeply nested generated code actually compiles
 func TestGeneratedCodeCompiles(t *testing.T) {
 	deepSchema := map[string]any{
@@ -84,6 +102,9 @@ func TestGeneratedCodeCompiles(t *testing.T) {
 	assert.Contains(t, codeStr, "Level2 Level1Level2", "Generated code missing correct field type reference")
 	assert.Contains(t, codeStr, "Status StatusEnum", "Generated code missing correct enum field reference")
 
+	// Substrings do not catch bad type references or duplicate identifiers
+	require.NoError(t, CheckGoCompiles("testpkg.gen.go", code))
+
 	t.Logf("Generated code length: %d bytes", len(code))
 	t.Logf("Generated %d root fields, %d structs, %d enums", len(fields), len(structs), len(enums))
 }
@@ -191,9 +212,9 @@ func TestEnumValidationGeneration(t *testing.T) {
 
 	// Verify error messages include valid values list
 	expectedErrorMessages := []string{
-		`"invalid PriorityEnum value: %q, must be one of: low, medium, high"`,
-		`"invalid StatusEnum value: %q, must be one of: pending, approved, rejected"`,
-		`"invalid LevelEnum value: %q, must be one of: 1, 2, 3"`,
+		`"%w %q for PriorityEnum, must be one of: low, medium, high", ErrInvalidEnum`,
+		`"%w %q for StatusEnum, must be one of: pending, approved, rejected", ErrInvalidEnum`,
+		`"%w %v for LevelEnum, must be one of: 1, 2, 3", ErrInvalidEnum`,
 	}
 
 	for _, errorMsg := range expectedErrorMessages {
@@ -202,3 +223,2612 @@ func TestEnumValidationGeneration(t *testing.T) {
 
 	t.Logf("Generated code with validation methods: %d bytes", len(code))
 }
+
+// TestErrorTypesGeneration tests that an output error_code enum produces typed error values
+func TestErrorTypesGeneration(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.ErrorTypes = true
+
+	codeStr := processPromptContent(t, gen, "lookup_user.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      name:
+        type: string
+      error_code:
+        type: string
+        enum: [not_found, rate_limited]
+    required: [name]
+---
+Look up the user.
+`)
+
+	assert.Contains(t, codeStr, `import "errors"`)
+	assert.Contains(t, codeStr, `ErrErrorCodeNotFound    = errors.New("not_found")`)
+	assert.Contains(t, codeStr, `ErrErrorCodeRateLimited = errors.New("rate_limited")`)
+	assert.Contains(t, codeStr, "func (e ErrorCodeEnum) Err() error")
+	assert.Contains(t, codeStr, "case ErrorCodeEnumNotFound:\n\t\treturn ErrErrorCodeNotFound")
+
+	// Without the flag no error values are generated
+	gen.ErrorTypes = false
+	codeStr = processPromptContent(t, gen, "lookup_user.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      error_code:
+        type: string
+        enum: [not_found]
+---
+Look up the user.
+`)
+	assert.NotContains(t, codeStr, "errors.New")
+}
+
+// TestStrictEnumsSetHelpers tests that array-of-enum fields get set helpers with -strict-enums
+func TestStrictEnumsSetHelpers(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.StrictEnums = true
+
+	content := `---
+output:
+  schema:
+    type: object
+    properties:
+      tags:
+        type: array
+        items:
+          type: string
+          enum: [urgent, billing]
+      priority:
+        type: string
+        enum: [low, high]
+---
+Tag the ticket.
+`
+
+	codeStr := processPromptContent(t, gen, "ticket.prompt", content)
+	assert.Contains(t, codeStr, "func ValidateTagsItemEnumSet(s []TagsItemEnum) error")
+	assert.Contains(t, codeStr, "func HasTagsItemEnum(s []TagsItemEnum, e TagsItemEnum) bool")
+	assert.Contains(t, codeStr, "func (e TagsItemEnum) Validate() error")
+	assert.NotContains(t, codeStr, "ValidatePriorityEnumSet")
+	require.NoError(t, CheckGoCompiles("ticket.gen.go", []byte(codeStr)))
+
+	// Without the flag no set helpers are generated
+	gen.StrictEnums = false
+	codeStr = processPromptContent(t, gen, "ticket.prompt", content)
+	assert.NotContains(t, codeStr, "ValidateTagsItemEnumSet")
+}
+
+// TestEnumTitleNaming tests that a titled enum is named after its title and shared across fields
+func TestEnumTitleNaming(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	codeStr := processPromptContent(t, gen, "triage.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      priority:
+        type: string
+        title: Priority Level
+        enum: [low, high]
+      escalation_priority:
+        type: string
+        title: Priority Level
+        enum: [low, high]
+    required: [priority, escalation_priority]
+---
+Triage the ticket.
+`)
+
+	assert.Contains(t, codeStr, "type PriorityLevelEnum string")
+	assert.Contains(t, codeStr, "Priority PriorityLevelEnum")
+	assert.Contains(t, codeStr, "EscalationPriority PriorityLevelEnum")
+	assert.NotContains(t, codeStr, "PriorityEnum")
+	assert.Equal(t, 1, strings.Count(codeStr, "type PriorityLevelEnum string"), "Shared enum should be emitted once")
+
+	// Conflicting values under the same title cannot share a type
+	inputFile := filepath.Join(t.TempDir(), "conflict.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      a:
+        type: string
+        title: Level
+        enum: [low, high]
+      b:
+        type: string
+        title: Level
+        enum: [one, two]
+---
+Conflict.
+`), 0o600))
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "enum LevelEnum is defined with conflicting values")
+}
+
+// TestPrefixEnumsNamesNestedEnumsAfterStruct tests that enums of array items with the same field name do not collide
+func TestPrefixEnumsNamesNestedEnumsAfterStruct(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+
+	inputFile := filepath.Join(tempDir, "board.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      status:
+        type: string
+        enum: [active, archived]
+      tasks:
+        type: array
+        items:
+          type: object
+          properties:
+            status:
+              type: string
+              enum: [todo, done]
+            labels:
+              type: array
+              items:
+                type: string
+                enum: [ui, api]
+          required: [status]
+      bugs:
+        type: array
+        items:
+          type: object
+          properties:
+            status:
+              type: string
+              enum: [open, closed]
+          required: [status]
+    required: [status]
+---
+Summarize the board.
+`), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "enum StatusEnum is defined with conflicting values: [active, archived] and [todo, done] (-prefix-enums")
+
+	gen.PrefixEnums = true
+	require.NoError(t, ProcessFile(gen, inputFile))
+
+	code, err := os.ReadFile(filepath.Join(tempDir, "board.gen.go"))
+	require.NoError(t, err)
+
+	codeStr := string(code)
+	assert.Contains(t, codeStr, "\tStatus StatusEnum ", "Top-level enums keep their short name")
+	assert.Contains(t, codeStr, "\tStatus TasksItemStatusEnum ")
+	assert.Contains(t, codeStr, "\tLabels []TasksItemLabelsItemEnum ")
+	assert.Contains(t, codeStr, "\tStatus BugsItemStatusEnum ")
+	assert.Contains(t, codeStr, "TasksItemStatusEnumTodo TasksItemStatusEnum = \"todo\"")
+	assert.Contains(t, codeStr, "BugsItemStatusEnumOpen   BugsItemStatusEnum = \"open\"")
+	assert.NoError(t, CheckGoCompiles("board.gen.go", code))
+}
+
+// TestEnumCustomBaseType tests that string enums can be declared on a shared base type
+func TestEnumCustomBaseType(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.EnumBase = "EnumBase"
+
+	codeStr := processPromptContent(t, gen, "ticket.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      status:
+        type: string
+        enum: [open, closed]
+    required: [status]
+---
+Ticket.
+`)
+
+	assert.Contains(t, codeStr, "type StatusEnum EnumBase")
+	assert.Contains(t, codeStr, `StatusEnumOpen   StatusEnum = "open"`)
+	assert.Contains(t, codeStr, "func (e StatusEnum) Validate() error")
+}
+
+// TestKeepGoingReportsAllProblems tests that independent problems in one file are reported together
+func TestKeepGoingReportsAllProblems(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	inputFile := filepath.Join(t.TempDir(), "broken.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+input:
+  schema:
+    type: object
+    properties:
+      habit:
+        type: string
+output:
+  schema:
+    type: object
+    properties:
+      category:
+        type: string
+        enum: not-a-list
+---
+Classify {{habbit}}
+`), 0o600))
+
+	// Default mode stops at the first problem
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "enum values must be an array")
+	assert.NotContains(t, err.Error(), "habbit")
+
+	gen.KeepGoing = true
+	err = ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to generate output struct")
+	assert.Contains(t, err.Error(), "enum values must be an array")
+	assert.Contains(t, err.Error(), "Variable 'habbit' not found in input schema")
+}
+
+// TestExampleStructsUseValidEnumValues tests that examples use the schema default or the first enum value
+func TestExampleStructsUseValidEnumValues(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.Examples = true
+
+	codeStr := processPromptContent(t, gen, "triage.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      severity:
+        type: string
+        enum: [low, medium, high]
+        default: high
+      category:
+        type: string
+        enum: [bug, feature]
+    required: [severity, category]
+---
+Triage the ticket.
+`)
+
+	assert.Contains(t, codeStr, "func ExampleTriageOutput() TriageOutput {")
+	assert.Contains(t, codeStr, "Severity: SeverityEnumHigh,")
+	assert.Contains(t, codeStr, "Category: CategoryEnumBug,")
+}
+
+// TestRecursiveSchemaUsesPointerFields tests that a tree node schema referencing itself compiles
+func TestRecursiveSchemaUsesPointerFields(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.Examples = true
+
+	codeStr := processPromptContent(t, gen, "tree.prompt", `---
+output:
+  schema:
+    type: object
+    title: TreeNode
+    properties:
+      label:
+        type: string
+      children:
+        type: array
+        items:
+          $ref: "#"
+      parent:
+        $ref: "#"
+    required: [label, children, parent]
+---
+Build the tree.
+`)
+
+	assert.Contains(t, codeStr, "type TreeNode struct {")
+	assert.Contains(t, codeStr, "Children []*TreeNode `json:\"children\"`")
+	assert.Contains(t, codeStr, "Parent   *TreeNode   `json:\"parent\"`", "Required pointers keep the plain tag")
+	assert.Equal(t, 1, strings.Count(codeStr, "type TreeNode struct"), "The recursive type is generated exactly once")
+	assert.Contains(t, codeStr, "return TreeNode{}", "Recursive fields keep their zero value in examples")
+	assert.NoError(t, CheckGoCompiles("tree.gen.go", []byte(codeStr)))
+}
+
+// TestMultiplePrimaryFieldsRejected tests that marking two fields as primary fails generation
+func TestMultiplePrimaryFieldsRejected(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+
+	inputFile := filepath.Join(tempDir, "contact.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      name:
+        type: string
+        x-codegen-primary: true
+      email:
+        type: string
+        x-codegen-primary: true
+---
+Find the contact.
+`), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "struct ContactOutput has multiple primary fields: name, email")
+}
+
+// TestMethodNameCollisionsRejected tests that fields named like a generated method fail generation
+func TestMethodNameCollisionsRejected(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+	gen.Reset = true
+	gen.StructValidate = true
+
+	inputFile := filepath.Join(tempDir, "settings.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      reset:
+        type: boolean
+    required: [reset]
+---
+Pick the settings.
+`), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.ErrorContains(t, err, `field Reset of struct SettingsOutput collides with the Reset() method generated for -reset, rename the "reset" property`)
+
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      validate:
+        type: boolean
+      level:
+        type: string
+        enum: [low, high]
+    required: [validate]
+---
+Pick the settings.
+`), 0o600))
+
+	err = ProcessFile(gen, inputFile)
+	require.ErrorContains(t, err, `field Validate of struct SettingsOutput collides with the Validate() method generated for -struct-validate`)
+}
+
+// TestStructValidateSkipsStructsWithoutValidatedFields tests that -struct-validate only generates Validate() on structs with something to check
+func TestStructValidateSkipsStructsWithoutValidatedFields(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.StructValidate = true
+
+	code := processPromptContent(t, gen, "plain.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      query: {type: string}
+      validate: {type: boolean}
+output:
+  schema:
+    type: object
+    properties:
+      note:
+        type: object
+        properties:
+          text: {type: string}
+      review:
+        type: object
+        properties:
+          level: {type: string, enum: [low, high]}
+---
+Search {{query}}.
+`)
+
+	assert.NotContains(t, code, "func (x PlainInput) Validate() error")
+	assert.NotContains(t, code, "func (x Note) Validate() error")
+	assert.NotContains(t, code, "x.Note", "Structs without Validate() are not collected")
+	assert.Contains(t, code, "func (x Review) Validate() error")
+	assert.Contains(t, code, "func (x PlainOutput) Validate() error", "Nested structs with validated fields make their parent validated")
+	require.NoError(t, CheckGoCompiles("plain.gen.go", []byte(code)))
+
+	code = processPromptContent(t, gen, "plain.prompt", "---\ninput:\n  schema:\n    query: string\n---\nSearch {{query}}.\n")
+	assert.NotContains(t, code, "Validate()")
+	assert.NotContains(t, code, validatorImportPath, "The validator package is only imported when used")
+	require.NoError(t, CheckGoCompiles("plain.gen.go", []byte(code)))
+}
+
+// TestCommentsWrappedAtMaxLineLength tests that no generated comment line exceeds the configured width
+func TestCommentsWrappedAtMaxLineLength(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.MaxLineLength = 60
+	gen.Reset = true
+
+	codeStr := processPromptContent(t, gen, "long_descriptions.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      verdict:
+        type: string
+        enum: [accept, reject]
+        description: The final verdict the reviewer reached after carefully reading every part of the submission
+      reasoning:
+        type: string
+        description: A detailed explanation of why the verdict was reached, referencing specific sections of the submission
+    required: [verdict, reasoning]
+---
+Review the submission.
+`)
+
+	for _, line := range strings.Split(codeStr, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") && !strings.HasPrefix(line, "// Code generated") {
+			assert.LessOrEqual(t, len(line), 60, "Comment line too long: %q", line)
+		}
+	}
+
+	assert.Contains(t, codeStr, "\t// A detailed explanation of why the verdict was reached,\n\t// referencing")
+}
+
+// TestSharedDefinitionAcrossInputAndOutput tests that a definition used by both schemas is emitted once
+func TestSharedDefinitionAcrossInputAndOutput(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	codeStr := processPromptContent(t, gen, "relocate.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      from:
+        $ref: "#/definitions/Address"
+    definitions:
+      Address:
+        type: object
+        properties:
+          city:
+            type: string
+        required: [city]
+output:
+  schema:
+    type: object
+    properties:
+      to:
+        $ref: "#/definitions/Address"
+    required: [to]
+    definitions:
+      Address:
+        type: object
+        properties:
+          city:
+            type: string
+        required: [city]
+---
+Relocate from {{from.city}}.
+`)
+
+	assert.Equal(t, 1, strings.Count(codeStr, "type Address struct"))
+	assert.Contains(t, codeStr, "From Address `json:\"from\"`")
+	assert.Contains(t, codeStr, "To Address `json:\"to\"`")
+	assert.NoError(t, CheckGoCompiles("relocate.gen.go", []byte(codeStr)))
+}
+
+// TestSharedDefinitionFollowsReferencingSchema tests that $ref'd definitions are parsed like inline
+// objects of the schema using them, getting one copy per direction when the copies differ
+func TestSharedDefinitionFollowsReferencingSchema(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "order.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      item:
+        $ref: "#/$defs/Item"
+      inline:
+        type: object
+        properties:
+          sku: {type: string}
+    $defs:
+      Item:
+        type: object
+        properties:
+          id: {type: string, readOnly: true}
+          sku: {type: string}
+output:
+  schema:
+    type: object
+    properties:
+      item:
+        $ref: "#/$defs/Item"
+    required: [item]
+    $defs:
+      Item:
+        type: object
+        properties:
+          id: {type: string, readOnly: true}
+          sku: {type: string}
+---
+Order {{item.sku}}.
+`)
+
+	assert.Contains(t, code, "type Item struct {\n\tSku string `json:\"sku\"`\n}", "Input copies drop readOnly fields and pointers")
+	assert.Contains(t, code, "type Inline struct {\n\tSku string `json:\"sku\"`\n}")
+	assert.Contains(t, code, "Item ItemOutput `json:\"item\"`")
+	assert.Contains(t, code, "type ItemOutput struct {")
+	assert.Contains(t, code, "ID  *string `json:\"id,omitempty\"`")
+	require.NoError(t, CheckGoCompiles("order.gen.go", []byte(code)))
+}
+
+// TestTypeMappingsOverridePrimitiveTypes tests that configured type mappings replace default Go types
+func TestTypeMappingsOverridePrimitiveTypes(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.TypeMappings = map[string]string{"number": "float32", "integer": "int64"}
+
+	codeStr := processPromptContent(t, gen, "measure.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      weight:
+        type: number
+      count:
+        type: integer
+      samples:
+        type: array
+        items:
+          type: number
+      label:
+        type: string
+    required: [weight]
+---
+Measure it.
+`)
+
+	assert.Contains(t, codeStr, "Weight  float32 ")
+	assert.Contains(t, codeStr, "Count   *int64 ")
+	assert.Contains(t, codeStr, "Samples []float32 ")
+	assert.Contains(t, codeStr, "Label   *string ")
+}
+
+// TestEnumStringAndValuesHelpers tests String() and the Values() helper, including name collisions
+func TestEnumStringAndValuesHelpers(t *testing.T) {
+	enums := []codegen.GoEnum{
+		{
+			Name: "ModeEnum",
+			Type: "string",
+			Values: []codegen.EnumValue{
+				{ConstName: "ModeEnumValues", Value: "values"},
+				{ConstName: "ModeEnumKeys", Value: "keys"},
+			},
+		},
+		{
+			Name:   "LevelEnum",
+			Type:   "int",
+			Values: []codegen.EnumValue{{ConstName: "LevelEnum2", Value: "2"}, {ConstName: "LevelEnum1", Value: "1"}},
+		},
+	}
+
+	code, err := GenerateGoCode(nil, enums, "models")
+	require.NoError(t, err)
+
+	codeStr := string(code)
+	assert.Contains(t, codeStr, "func (e ModeEnum) String() string {\n\treturn string(e)\n}")
+	assert.Contains(t, codeStr, "func (e LevelEnum) String() string {\n\treturn fmt.Sprint(int(e))\n}")
+	assert.Contains(t, codeStr, "var AllModeEnum = []ModeEnum{ModeEnumValues, ModeEnumKeys}\n")
+	assert.Contains(t, codeStr, "var AllLevelEnum = []LevelEnum{LevelEnum2, LevelEnum1}\n", "Values keep the schema order")
+	assert.Contains(t, codeStr, "func ModeEnumValueList() []ModeEnum {\n\treturn append([]ModeEnum(nil), AllModeEnum...)\n}")
+	assert.Contains(t, codeStr, "func LevelEnumValues() []LevelEnum {\n\treturn append([]LevelEnum(nil), AllLevelEnum...)\n}")
+	assert.NoError(t, CheckGoCompiles("modes.gen.go", code))
+}
+
+// TestDuplicateTypesAcrossDirectoryRejected tests that a directory run fails before writing when
+// two prompt files declare the same type name in one output directory
+func TestDuplicateTypesAcrossDirectoryRejected(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	inputDir := t.TempDir()
+
+	prompt := []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      result:
+        type: object
+        properties:
+          score:
+            type: number
+---
+Score it.
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "first.prompt"), prompt, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "second.prompt"), prompt, 0o600))
+
+	err := ProcessDirectory(gen, inputDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Result in "+outDir)
+	assert.Contains(t, err.Error(), filepath.Join(inputDir, "first.prompt")+", "+filepath.Join(inputDir, "second.prompt"))
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "Nothing should be written when type names collide")
+
+	gen.AllowDuplicateTypes = true
+	require.NoError(t, ProcessDirectory(gen, inputDir))
+	assert.FileExists(t, filepath.Join(outDir, "first.gen.go"))
+	assert.FileExists(t, filepath.Join(outDir, "second.gen.go"))
+}
+
+// TestDryRunReportsStaleOutput tests that dry-run mode diffs against existing files without writing them
+func TestDryRunReportsStaleOutput(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+	inputFile := filepath.Join("..", "integration_tests", "prompts", "simple_types.prompt")
+	outputFile := filepath.Join(tempDir, "simple_types.gen.go")
+
+	gen.DryRun = true
+	err := ProcessFile(gen, inputFile)
+	require.ErrorIs(t, err, ErrStaleOutput, "A missing output file should be reported as stale")
+	assert.NoFileExists(t, outputFile)
+
+	gen.DryRun = false
+	require.NoError(t, ProcessFile(gen, inputFile))
+
+	gen.DryRun = true
+	require.NoError(t, ProcessFile(gen, inputFile), "An up to date output file should not be reported")
+
+	code, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(outputFile, append(code, "// edited\n"...), 0o600))
+	require.ErrorIs(t, ProcessFile(gen, inputFile), ErrStaleOutput, "Hand edits below an unchanged input hash are reported")
+
+	require.NoError(t, os.WriteFile(outputFile, []byte("package models\n"), 0o600))
+	require.ErrorIs(t, ProcessDirectory(gen, filepath.Dir(inputFile)), ErrStaleOutput)
+
+	content, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, "package models\n", string(content), "Dry run must not overwrite existing files")
+}
+
+// TestDeprecatedFieldComments tests that deprecated fields get a Deprecated: doc comment in both schema formats
+func TestDeprecatedFieldComments(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "legacy.prompt", `---
+input:
+  schema:
+    query: string, search query
+    limit(deprecated): integer, use page_size instead
+    tags(array)(deprecated): string
+output:
+  schema:
+    type: object
+    properties:
+      score:
+        type: number
+        description: relevance score
+        deprecated: true
+      rank:
+        type: integer
+        description: result rank
+---
+Search for {{query}}.
+`)
+
+	assert.Contains(t, code, "\t// search query\n\tQuery string")
+	assert.Contains(t, code, "\t// Deprecated: use page_size instead\n\tLimit int `json:\"limit\"`")
+	assert.Contains(t, code, "\t// Deprecated: do not use in new code.\n\tTags []string `json:\"tags\"`")
+	assert.Contains(t, code, "\t// Deprecated: relevance score\n\tScore *float64")
+	assert.Contains(t, code, "\t// result rank\n\tRank *int")
+}
+
+// TestConstructorsForInputStructsOnly tests that constructors are generated for input structs with safe parameter names
+func TestConstructorsForInputStructsOnly(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.Constructors = true
+
+	code := processPromptContent(t, gen, "lookup.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      type:
+        type: string
+      user_name:
+        type: string
+      id:
+        type: string
+      api_url:
+        type: string
+output:
+  schema:
+    type: object
+    properties:
+      found:
+        type: boolean
+---
+Look up {{user_name}} {{id}} at {{api_url}}.
+`)
+
+	assert.Contains(t, code, "func NewLookupInput(typeValue string, userName string, id string, apiURL string) LookupInput {")
+	assert.Contains(t, code, "\t\tType:     typeValue,\n\t\tUserName: userName,\n\t\tID:       id,\n\t\tAPIURL:   apiURL,\n")
+	assert.NotContains(t, code, "func NewLookupOutput")
+}
+
+// TestTimeFormatsGenerateTimeTypes tests that date-time formats map to time.Time while date and duration stay strings
+func TestTimeFormatsGenerateTimeTypes(t *testing.T) {
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      created_at:
+        type: string
+        format: date-time
+      due_on:
+        type: string
+        format: date
+      timeout:
+        type: string
+        format: duration
+      contact:
+        type: string
+        format: email
+    required: [created_at]
+---
+Schedule it.
+`
+
+	gen, _ := createTempGenerator(t, "models")
+	code := processPromptContent(t, gen, "schedule.prompt", prompt)
+
+	assert.Contains(t, code, `import "time"`)
+	assert.Contains(t, code, "CreatedAt time.Time ")
+	assert.Contains(t, code, "DueOn     *string ", "time.Time cannot decode bare dates")
+	assert.Contains(t, code, "Timeout   *string ", "time.Duration cannot decode duration strings")
+	assert.Contains(t, code, "Contact   *string ")
+
+	gen.NoTimeTypes = true
+	code = processPromptContent(t, gen, "schedule.prompt", prompt)
+
+	assert.NotContains(t, code, `import "time"`)
+	assert.Contains(t, code, "CreatedAt string ")
+	assert.Contains(t, code, "Timeout   *string ")
+}
+
+// TestOmitEmptyOnOptionalFields tests that optional pointer, slice and map fields get omitempty unless disabled
+// or the json tag is overridden with x-codegen-extra-tags
+func TestOmitEmptyOnOptionalFields(t *testing.T) {
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      note:
+        type: string
+      tags:
+        type: array
+        items:
+          type: string
+      scores:
+        type: object
+        additionalProperties:
+          type: number
+      results:
+        type: array
+        items:
+          type: string
+      alias:
+        type: string
+        x-codegen-extra-tags:
+          json: "nickname"
+    required: [results]
+---
+Annotate it.
+`
+
+	gen, _ := createTempGenerator(t, "models")
+	code := processPromptContent(t, gen, "annotate.prompt", prompt)
+
+	assert.Contains(t, code, "`json:\"note,omitempty\"`")
+	assert.Contains(t, code, "`json:\"tags,omitempty\"`")
+	assert.Contains(t, code, "`json:\"scores,omitempty\"`")
+	assert.Contains(t, code, "`json:\"results\"`", "Required slices keep empty values")
+	assert.Contains(t, code, "`json:\"nickname\"`", "Custom json tags are used as given")
+
+	gen.NoOmitEmpty = true
+	code = processPromptContent(t, gen, "annotate.prompt", prompt)
+
+	assert.NotContains(t, code, "omitempty")
+}
+
+// TestUnionsRequireExperimentalFlag tests that discriminated unions stay any unless experimental unions are enabled
+func TestUnionsRequireExperimentalFlag(t *testing.T) {
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      event:
+        oneOf:
+          - type: object
+            properties:
+              type:
+                const: click
+              x:
+                type: integer
+          - type: object
+            properties:
+              type:
+                const: key-press
+              key:
+                type: string
+        discriminator:
+          propertyName: type
+---
+Describe the event.
+`
+
+	gen, _ := createTempGenerator(t, "models")
+	code := processPromptContent(t, gen, "event.prompt", prompt)
+
+	assert.Contains(t, code, "Event *any `json:\"event,omitempty\"`")
+	assert.NotContains(t, code, "EventClick")
+
+	gen.ExperimentalUnions = true
+	gen.BuildCheck = true
+	code = processPromptContent(t, gen, "event.prompt", prompt)
+
+	assert.Contains(t, code, "Event Event `json:\"event,omitempty\"`")
+	assert.Contains(t, code, "type Event interface {")
+	assert.Contains(t, code, "type EventClick struct {")
+	assert.Contains(t, code, "type EventKeyPress struct {")
+	assert.Contains(t, code, "case \"key-press\":\n\t\tvar variant EventKeyPress")
+	assert.Contains(t, code, "func (x *EventOutput) UnmarshalJSON(data []byte) error {")
+}
+
+// TestGoTypeOverrides tests that x-codegen-go-type replaces computed types and x-codegen-import imports are de-duplicated
+func TestGoTypeOverrides(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "payment.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      id:
+        type: string
+        x-codegen-go-type: uuid.UUID
+        x-codegen-import: github.com/google/uuid
+      parent_id:
+        type: string
+        x-codegen-go-type: uuid.UUID
+        x-codegen-import: github.com/google/uuid
+      amount:
+        type: string
+        x-codegen-go-type: decimal.Decimal
+        x-codegen-import: github.com/shopspring/decimal
+      currency:
+        type: string
+        enum: [usd, eur]
+        x-codegen-go-type: Currency
+      note:
+        type: string
+        x-codegen-go-type: "  "
+    required: [id, amount, currency]
+---
+Record the payment.
+`)
+
+	assert.Contains(t, code, "import \"github.com/google/uuid\"\nimport \"github.com/shopspring/decimal\"\n")
+	assert.Equal(t, 1, strings.Count(code, "github.com/google/uuid"), "Imports should be de-duplicated")
+	assert.Contains(t, code, "ID       uuid.UUID ")
+	assert.Contains(t, code, "ParentID *uuid.UUID ")
+	assert.Contains(t, code, "Amount   decimal.Decimal ")
+	assert.Contains(t, code, "Currency Currency ")
+	assert.NotContains(t, code, "CurrencyEnum", "Overridden enums should not generate an enum type")
+	assert.Contains(t, code, "Note     *string ", "Blank overrides should be ignored")
+}
+
+// TestNullableTypeArrays tests that ["<type>", "null"] type arrays become optional pointer fields
+func TestNullableTypeArrays(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "nullable.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      limit:
+        type: [integer, "null"]
+output:
+  schema:
+    type: object
+    properties:
+      title:
+        type: ["string", "null"]
+      tags:
+        type: ["array", "null"]
+        items:
+          type: string
+      mood:
+        type: [string, "null"]
+        enum: [happy, sad, null]
+      value:
+        type: [string, integer]
+    required: [title, tags, mood, value]
+---
+Limit {{limit}}.
+`)
+
+	assert.Contains(t, code, "Limit *int `json:\"limit,omitempty\"`")
+	assert.Contains(t, code, "Title *string   `json:\"title,omitempty\"`")
+	assert.Contains(t, code, "Tags  []string  `json:\"tags,omitempty\"`")
+	assert.Contains(t, code, "Mood  *MoodEnum `json:\"mood,omitempty\"`")
+	assert.Contains(t, code, "Value any       `json:\"value\"`")
+	assert.NotContains(t, code, "MoodEnumNull")
+}
+
+// TestNullableArrayItemEnum tests that a null member of an array items enum is not generated as a value
+func TestNullableArrayItemEnum(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.ValidateAll = true
+
+	code := processPromptContent(t, gen, "nullable.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      tags:
+        type: array
+        items:
+          type: [string, "null"]
+          enum: [a, b, null]
+    required: [tags]
+---
+Tag it.
+`)
+
+	assert.Contains(t, code, "TagsItemEnumA TagsItemEnum = \"a\"")
+	assert.Contains(t, code, "TagsItemEnumB TagsItemEnum = \"b\"")
+	assert.NotContains(t, code, "<nil>")
+	assert.NotContains(t, code, "TagsItemEnumNil")
+	require.NoError(t, CheckGoCompiles("nullable.gen.go", []byte(code)))
+}
+
+// TestWatchRegeneratesChangedPrompts tests that watch mode regenerates new prompts and removes output of deleted ones
+func TestWatchRegeneratesChangedPrompts(t *testing.T) {
+	promptDir := t.TempDir()
+	nestedDir := filepath.Join(promptDir, "nested")
+	require.NoError(t, os.MkdirAll(nestedDir, 0o750))
+
+	prompt := "---\ninput:\n  schema:\n    name: string\n---\nHello {{name}}.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "greet.prompt"), []byte(prompt), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := watcher{
+		g:        codegen.Generator{PackageName: "models"},
+		dir:      promptDir,
+		interval: 10 * time.Millisecond,
+		debounce: 30 * time.Millisecond,
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- w.run(ctx) }()
+
+	greetOutput := filepath.Join(promptDir, "greet.gen.go")
+	assert.Eventually(t, func() bool { return fileExists(greetOutput) }, 2*time.Second, 10*time.Millisecond, "Initial generation")
+
+	nestedPrompt := filepath.Join(nestedDir, "farewell.prompt")
+	nestedOutput := filepath.Join(nestedDir, "farewell.gen.go")
+	require.NoError(t, os.WriteFile(nestedPrompt, []byte(strings.Replace(prompt, "Hello", "Bye", 1)), 0o600))
+	assert.Eventually(t, func() bool { return fileExists(nestedOutput) }, 2*time.Second, 10*time.Millisecond, "New nested prompt")
+
+	require.NoError(t, os.Remove(nestedPrompt))
+	assert.Eventually(t, func() bool { return !fileExists(nestedOutput) }, 2*time.Second, 10*time.Millisecond, "Deleted prompt")
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.FileExists(t, greetOutput)
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
+
+// TestStrictEnumNamesRejectsCollisions tests that colliding enum constants compile by default and fail in strict mode
+func TestStrictEnumNamesRejectsCollisions(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	content := `---
+output:
+  schema:
+    type: object
+    properties:
+      difficulty:
+        type: string
+        enum: [very-easy, very_easy]
+---
+Rate it.`
+
+	code := processPromptContent(t, gen, "rating.prompt", content)
+	assert.Contains(t, code, `DifficultyEnumVeryEasy  DifficultyEnum = "very-easy"`)
+	assert.Contains(t, code, `DifficultyEnumVeryEasy2 DifficultyEnum = "very_easy"`)
+	require.NoError(t, CheckGoCompiles("rating.gen.go", []byte(code)))
+
+	gen.StrictEnumNames = true
+	promptFile, err := parser.ParsePromptContent(content, "rating.prompt")
+	require.NoError(t, err)
+
+	err = generateFromPromptFile(gen, promptFile)
+	require.ErrorContains(t, err, `enum DifficultyEnum values "very-easy" and "very_easy" both map to the constant DifficultyEnumVeryEasy`)
+}
+
+// TestInitialismsInGeneratedNames tests that initialisms are upper-cased by default, extendable and can be disabled
+func TestInitialismsInGeneratedNames(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	content := `---
+output:
+  schema:
+    type: object
+    properties:
+      user_id:
+        type: string
+      product_sku:
+        type: string
+      api_status:
+        type: string
+        enum: [ok, http_error]
+    required: [user_id, product_sku, api_status]
+---
+Look it up.`
+
+	code := processPromptContent(t, gen, "api_lookup.prompt", content)
+	assert.Contains(t, code, "type APILookupOutput struct")
+	assert.Contains(t, code, "UserID ")
+	assert.Contains(t, code, "ProductSku ")
+	assert.Contains(t, code, "APIStatusEnumHTTPError APIStatusEnum")
+
+	gen.Initialisms = []string{"SKU"}
+	code = processPromptContent(t, gen, "api_lookup.prompt", content)
+	assert.Contains(t, code, "ProductSKU ")
+
+	gen.NoInitialisms = true
+	code = processPromptContent(t, gen, "api_lookup.prompt", content)
+	assert.Contains(t, code, "type ApiLookupOutput struct")
+	assert.Contains(t, code, "UserId ")
+	assert.Contains(t, code, "ApiStatusEnumHttpError ApiStatusEnum")
+
+	// The initialisms of one run do not leak into the next
+	defaultGen, _ := createTempGenerator(t, "models")
+	code = processPromptContent(t, defaultGen, "api_lookup.prompt", content)
+	assert.Contains(t, code, "UserID ")
+}
+
+// TestRootSchemaDescriptionDocComment tests that a root JSON Schema description documents the generated struct
+func TestRootSchemaDescriptionDocComment(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "triage.prompt", `---
+input:
+  schema:
+    type: object
+    description: |
+      the ticket to triage.
+
+      Only open tickets are accepted.
+    properties:
+      title:
+        type: string
+output:
+  schema:
+    summary: string
+---
+Triage {{title}}.`)
+
+	assert.Contains(t, code, "// TriageInput represents the ticket to triage.\n//\n// Only open tickets are accepted.\ntype TriageInput struct")
+	assert.Contains(t, code, "// TriageOutput represents the output for triage\ntype TriageOutput struct", "Picoschema falls back to the generic comment")
+}
+
+// TestEnumDescriptionDocComment tests that an enum field description documents the generated enum type
+func TestEnumDescriptionDocComment(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "triage.prompt", `---
+input:
+  schema:
+    channel: "string(enum): [email, chat], how the ticket arrived"
+output:
+  schema:
+    type: object
+    properties:
+      priority:
+        type: string
+        description: Task priority, set by the triage agent
+        enum: [low, high]
+      status:
+        type: string
+        enum: [open, closed]
+---
+Triage {{channel}}.`)
+
+	assert.Contains(t, code, "// PriorityEnum represents Task priority, set by the triage agent\ntype PriorityEnum string")
+	assert.Contains(t, code, "// ChannelEnum represents how the ticket arrived\ntype ChannelEnum string")
+	assert.Contains(t, code, "// StatusEnum represents valid status values\ntype StatusEnum string", "Enums without a description keep the generic comment")
+}
+
+// TestConstraintTags tests that schema constraints become validate tags only with ConstraintTags
+func TestConstraintTags(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	content := `---
+output:
+  schema:
+    type: object
+    properties:
+      tags:
+        type: array
+        items:
+          type: string
+        minItems: 1
+        maxItems: 10
+      tweet:
+        type: string
+        minLength: 1
+        maxLength: 280
+      score:
+        type: integer
+        minimum: 0
+        maximum: 100
+    required: [tags, tweet]
+---
+Post it.`
+
+	code := processPromptContent(t, gen, "post.prompt", content)
+	assert.Contains(t, code, "`json:\"tags\"`")
+	assert.Contains(t, code, "`json:\"tweet\" validate:\"required,min=1\"`", "minLength is translated without the flag")
+
+	gen.ConstraintTags = true
+	code = processPromptContent(t, gen, "post.prompt", content)
+	assert.Contains(t, code, "`json:\"tags\" validate:\"min=1,max=10\"`")
+	assert.Contains(t, code, "`json:\"tweet\" validate:\"required,min=1,max=280\"`")
+	assert.Contains(t, code, "`json:\"score,omitempty\" validate:\"omitempty,gte=0,lte=100\"`")
+}
+
+// TestSingleFileMergesDirectory tests that -single-file generates one file sharing identical enums
+func TestSingleFileMergesDirectory(t *testing.T) {
+	promptDir := t.TempDir()
+	gen, outDir := createTempGenerator(t, "models")
+	gen.SingleFile = "models.gen.go"
+
+	promptWithPriority := func(name, values string) string {
+		return "---\noutput:\n  schema:\n    type: object\n    properties:\n      " + name +
+			":\n        type: string\n      priority:\n        type: string\n        enum: " + values + "\n---\nRate.\n"
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "bug.prompt"), []byte(promptWithPriority("title", "[low, high]")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "task.prompt"), []byte(promptWithPriority("summary", "[low, high]")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "notes.prompt"), []byte("---\nmodel: test\n---\nNo schema.\n"), 0o600))
+
+	require.NoError(t, ProcessDirectory(gen, promptDir))
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "Only the single file is written")
+
+	code, err := os.ReadFile(filepath.Join(outDir, "models.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "type BugOutput struct")
+	assert.Contains(t, string(code), "type TaskOutput struct")
+	assert.Equal(t, 1, strings.Count(string(code), "type PriorityEnum string"), "The shared enum is declared once")
+	assert.Contains(t, string(code), "var ErrInvalidEnum = errors.New(\"invalid enum value\")", "The single file declares the sentinel itself")
+	require.NoError(t, CheckGoCompiles("models.gen.go", code))
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "task.prompt"), []byte(promptWithPriority("summary", "[low, urgent]")), 0o600))
+	err = ProcessDirectory(gen, promptDir)
+	require.ErrorContains(t, err, "enum PriorityEnum is defined with conflicting values: [low, high] and [low, urgent]")
+}
+
+// TestDedupeStructsCollapsesIdenticalNestedStructs tests that -dedupe-structs shares one type between identical objects
+func TestDedupeStructsCollapsesIdenticalNestedStructs(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	address := `
+        type: object
+        properties:
+          street:
+            type: string
+          city:
+            type: string`
+	content := "---\noutput:\n  schema:\n    type: object\n    properties:\n      billing:" + address +
+		"\n      shipping:" + address + "\n      history:\n        type: array\n        items:" +
+		strings.ReplaceAll(address, "\n  ", "\n    ") + "\n---\nShip it.\n"
+
+	code := processPromptContent(t, gen, "order.prompt", content)
+	assert.Contains(t, code, "type Shipping struct", "Without the flag every object gets its own type")
+
+	gen.DedupeStructs = true
+	code = processPromptContent(t, gen, "order.prompt", content)
+	assert.Equal(t, 1, strings.Count(code, "Street *string"), "The identical objects share one struct")
+	assert.Contains(t, code, "type Billing struct")
+	assert.NotContains(t, code, "type Shipping struct")
+	assert.NotContains(t, code, "type HistoryItem struct")
+	assert.Contains(t, code, "Shipping Billing ")
+	assert.Contains(t, code, "History  []Billing ")
+	require.NoError(t, CheckGoCompiles("order.gen.go", []byte(code)))
+}
+
+// TestExtCodegenPackageOverride tests that ext.codegen.package overrides the package of a single prompt file
+func TestExtCodegenPackageOverride(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "invoice.prompt", "---\next:\n  codegen:\n    package: billing\noutput:\n  schema:\n    total: number\n---\nBill.")
+	assert.Contains(t, code, "package billing\n")
+
+	// Each package needs its own output directory
+	gen.OutputDir = t.TempDir()
+	code = processPromptContent(t, gen, "refund.prompt", "---\ncodegen.package: payments\noutput:\n  schema:\n    total: number\n---\nRefund.")
+	assert.Contains(t, code, "package payments\n")
+
+	gen.OutputDir = t.TempDir()
+	code = processPromptContent(t, gen, "receipt.prompt", "---\noutput:\n  schema:\n    total: number\n---\nReceipt.")
+	assert.Contains(t, code, "package models\n", "Prompts without the override use -pkg")
+
+	promptPath := filepath.Join(t.TempDir(), "bad.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte("---\ncodegen.package: my-models\noutput:\n  schema:\n    total: number\n---\nBad."), 0o600))
+	require.ErrorContains(t, ProcessFile(gen, promptPath), "invalid ext.codegen.package my-models: must be a Go package name")
+}
+
+// TestExtCodegenPackageMixedDirectoryRejected tests that a directory run fails before writing when
+// an ext.codegen.package prompt would share its output directory with prompts using -pkg
+func TestExtCodegenPackageMixedDirectoryRejected(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	inputDir := t.TempDir()
+
+	invoice := "---\ncodegen.package: billing\noutput:\n  schema:\n    type: object\n    properties:\n      status:\n        enum: [paid, open]\n---\nBill."
+	other := "---\noutput:\n  schema:\n    total: number\n---\nOther."
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "invoice.prompt"), []byte(invoice), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "other.prompt"), []byte(other), 0o600))
+
+	err := ProcessDirectory(gen, inputDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), outDir+": package billing ("+filepath.Join(inputDir, "invoice.prompt")+", enum_errors)")
+	assert.Contains(t, err.Error(), "package models ("+filepath.Join(inputDir, "other.prompt")+")")
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "Nothing should be written when packages would mix")
+
+	// Go files already in the directory count too
+	require.NoError(t, os.Remove(filepath.Join(inputDir, "invoice.prompt")))
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "billing.go"), []byte("package billing\n"), 0o600))
+
+	err = ProcessDirectory(gen, inputDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "package billing ("+filepath.Join(outDir, "billing.go")+")")
+}
+
+// TestGettersAreNilSafe tests that -getters generates accessors returning zero values for nil pointers
+func TestGettersAreNilSafe(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.Getters = true
+
+	code := processPromptContent(t, gen, "review.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      summary:
+        type: string
+      confidence:
+        type: number
+      verdict:
+        type: string
+        enum: [approve, reject]
+      tags:
+        type: array
+        items:
+          type: string
+      author:
+        type: object
+        properties:
+          name:
+            type: string
+    required: [summary]
+---
+Review.`)
+
+	assert.Contains(t, code, "// GetConfidence returns the Confidence field of ReviewOutput, or the zero value when x or the field is nil\n"+
+		"func (x *ReviewOutput) GetConfidence() float64 {\n\tif x == nil || x.Confidence == nil {\n\t\treturn 0\n\t}\n\n\treturn *x.Confidence\n}")
+	assert.Contains(t, code, "func (x *ReviewOutput) GetSummary() string {\n\tif x == nil {\n\t\treturn \"\"\n\t}\n\n\treturn x.Summary\n}")
+	assert.Contains(t, code, "func (x *ReviewOutput) GetVerdict() VerdictEnum {\n\tif x == nil || x.Verdict == nil {\n\t\treturn \"\"")
+	assert.Contains(t, code, "func (x *ReviewOutput) GetTags() []string {\n\tif x == nil {\n\t\treturn nil")
+	assert.Contains(t, code, "func (x *ReviewOutput) GetAuthor() Author {\n\tif x == nil {\n\t\treturn Author{}")
+	assert.Contains(t, code, "func (x *Author) GetName() string {")
+	require.NoError(t, CheckGoCompiles("review.gen.go", []byte(code)))
+}
+
+// TestStrictTemplateFailsOnUndefinedVariables tests that -strict-template turns template warnings into errors
+func TestStrictTemplateFailsOnUndefinedVariables(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+
+	promptPath := filepath.Join(t.TempDir(), "habits.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte(`---
+input:
+  schema:
+    habit: string
+    steps(array):
+      name: string
+output:
+  schema:
+    category: string
+---
+Classify {{habbit}}.
+{{#each steps}}{{name}} {{@index}}{{/each}}`), 0o600))
+
+	require.NoError(t, ProcessFile(gen, promptPath), "Template issues only warn by default")
+	assert.True(t, fileExists(filepath.Join(outDir, "habits.gen.go")))
+
+	gen.StrictTemplate = true
+	err := ProcessFile(gen, promptPath)
+	require.ErrorContains(t, err, "Variable 'habbit' not found in input schema")
+	assert.NotContains(t, err.Error(), "'name'", "Variables of each items are not reported")
+}
+
+// TestApplyDefaults tests that schema defaults generate an ApplyDefaults() method, skipping unsupported ones
+func TestApplyDefaults(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "rank.prompt", `---
+input:
+  schema:
+    query: string
+  default:
+    query: everything
+output:
+  schema:
+    type: object
+    properties:
+      score:
+        type: number
+        default: 1
+      tier:
+        type: string
+        enum: [free, pro]
+        default: pro
+      tags:
+        type: array
+        items:
+          type: string
+        default: [new]
+---
+Rank {{query}}.`)
+
+	assert.Contains(t, code, "func (x *RankInput) ApplyDefaults() {\n\tif x.Query == \"\" {\n\t\tx.Query = \"everything\"\n\t}\n}",
+		"Frontmatter defaults apply to root fields")
+	assert.Contains(t, code, "\tif x.Score == nil {\n\t\tvalue := float64(1)\n\t\tx.Score = &value\n\t}\n")
+	assert.Contains(t, code, "\tif x.Tier == nil {\n\t\tvalue := TierEnumPro\n\t\tx.Tier = &value\n\t}\n")
+	assert.NotContains(t, code, "x.Tags", "Array defaults are skipped")
+	require.NoError(t, CheckGoCompiles("rank.gen.go", []byte(code)))
+
+	code = processPromptContent(t, gen, "plain.prompt", "---\noutput:\n  schema:\n    score: number\n---\nScore.")
+	assert.NotContains(t, code, "ApplyDefaults", "Structs without defaults get no method")
+}
+
+// TestMirrorTreeGeneratesPackagePerSubdirectory tests that -mirror-tree keeps the input layout and names packages after folders
+func TestMirrorTreeGeneratesPackagePerSubdirectory(t *testing.T) {
+	promptDir := t.TempDir()
+	gen, outDir := createTempGenerator(t, "models")
+	gen.MirrorTree = true
+
+	prompt := "---\noutput:\n  schema:\n    summary: string\n---\nRun.\n"
+	for _, dir := range []string{"classify", "Text-Summarize", "empty"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(promptDir, dir), 0o750))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "root.prompt"), []byte(prompt), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "classify", "habit.prompt"), []byte(prompt), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "Text-Summarize", "article.prompt"), []byte(prompt), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "empty", "notes.txt"), []byte("not a prompt"), 0o600))
+
+	require.NoError(t, ProcessDirectory(gen, promptDir))
+
+	packages := map[string]string{
+		"root.gen.go":                   "models",
+		"classify/habit.gen.go":         "classify",
+		"Text-Summarize/article.gen.go": "textsummarize",
+	}
+	for path, packageName := range packages {
+		code, err := os.ReadFile(filepath.Join(outDir, path))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "package "+packageName+"\n", path)
+	}
+
+	assert.NoDirExists(t, filepath.Join(outDir, "empty"), "Folders without prompts are skipped")
+}
+
+// TestEmitRenderRequiresValidTemplate tests that -emit-render embeds the template and rejects invalid ones
+func TestEmitRenderRequiresValidTemplate(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.EmitRender = true
+
+	code := processPromptContent(t, gen, "greet.prompt", "---\ninput:\n  schema:\n    name: string\n---\n{{role \"user\"}}Say `hi` to {{name}}.")
+	assert.Contains(t, code, "const GreetTemplate = \"{{role \\\"user\\\"}}Say `hi` to {{name}}.\"", "Templates with backticks are quoted")
+	assert.Contains(t, code, "func (x GreetInput) Render() (string, error) {\n\treturn render.Execute(GreetTemplate, x)\n}")
+	assert.Contains(t, code, "import \"github.com/oter/dotprompt-gen-go/pkg/render\"")
+
+	code = processPromptContent(t, gen, "score.prompt", "---\noutput:\n  schema:\n    score: number\n---\nScore.")
+	assert.NotContains(t, code, "Render", "Prompts without input get no Render() method")
+
+	promptPath := filepath.Join(t.TempDir(), "broken.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte("---\ninput:\n  schema:\n    name: string\n---\n{{#each name}}"), 0o600))
+	require.ErrorContains(t, ProcessFile(gen, promptPath), "failed to generate Render() for")
+}
+
+// TestStrictFailsOnUnknownRequiredFields tests that -strict turns misspelled required fields into errors
+func TestStrictFailsOnUnknownRequiredFields(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	promptPath := filepath.Join(t.TempDir(), "habits.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      habit:
+        type: string
+    required: [habitt]
+---
+Classify.`), 0o600))
+
+	require.NoError(t, ProcessFile(gen, promptPath), "Unknown required fields only warn by default")
+
+	gen.Strict = true
+	require.ErrorContains(t, ProcessFile(gen, promptPath), "required output field habitt is not defined in the schema")
+}
+
+// TestOutputDirectoryIsCreated tests that a missing -out directory is created unless -no-mkdir is set
+func TestOutputDirectoryIsCreated(t *testing.T) {
+	promptDir := t.TempDir()
+	prompt := "---\noutput:\n  schema:\n    summary: string\n---\nRun.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "a.prompt"), []byte(prompt), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "b.prompt"), []byte(prompt), 0o600))
+
+	t.Run("created", func(t *testing.T) {
+		gen, outDir := createTempGenerator(t, "models")
+		gen.OutputDir = filepath.Join(outDir, "generated", "models")
+		gen.AllowDuplicateTypes = true
+		gen.DirMode = 0o700
+
+		require.NoError(t, ProcessDirectory(gen, promptDir))
+		assert.FileExists(t, filepath.Join(gen.OutputDir, "a.gen.go"))
+		assert.FileExists(t, filepath.Join(gen.OutputDir, "b.gen.go"))
+
+		info, err := os.Stat(gen.OutputDir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+	})
+
+	t.Run("no-mkdir", func(t *testing.T) {
+		gen, outDir := createTempGenerator(t, "models")
+		gen.OutputDir = filepath.Join(outDir, "missing")
+		gen.NoMkdir = true
+
+		err := ProcessFile(gen, filepath.Join(promptDir, "a.prompt"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exist and -no-mkdir is set")
+		assert.NoDirExists(t, gen.OutputDir)
+	})
+
+	t.Run("out is a file", func(t *testing.T) {
+		gen, outDir := createTempGenerator(t, "models")
+		gen.OutputDir = filepath.Join(outDir, "models.go")
+		require.NoError(t, os.WriteFile(gen.OutputDir, []byte("package models\n"), 0o600))
+
+		err := ProcessFile(gen, filepath.Join(promptDir, "a.prompt"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exists but is not a directory")
+	})
+}
+
+// TestGeneratedCodeIsReproducible tests that generating the same prompt twice yields identical bytes
+func TestGeneratedCodeIsReproducible(t *testing.T) {
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      user_id:
+        type: string
+        x-codegen-extra-tags:
+          yaml: user_id
+          db: user_id
+          xml: user
+          bson: uid
+      status:
+        type: string
+        enum: [active, banned, pending]
+      profile:
+        type: object
+        properties:
+          zeta: {type: string}
+          alpha: {type: integer}
+          mid: {type: boolean}
+      tags:
+        type: array
+        items:
+          type: string
+          enum: [new, hot]
+    required: [user_id]
+---
+Describe the user.`
+
+	gen, _ := createTempGenerator(t, "models")
+	gen.ValidateAll = true
+	gen.Getters = true
+
+	first := processPromptContent(t, gen, "user.prompt", prompt)
+	assert.True(t, strings.HasPrefix(first, "// Code generated by dotprompt-gen-go "+Version+". DO NOT EDIT.\n"))
+	assert.Contains(t, first, "`json:\"user_id\" bson:\"uid\" db:\"user_id\" xml:\"user\" yaml:\"user_id\"`")
+
+	for range 10 {
+		otherGen, _ := createTempGenerator(t, "models")
+		otherGen.ValidateAll = true
+		otherGen.Getters = true
+
+		require.Equal(t, first, processPromptContent(t, otherGen, "user.prompt", prompt))
+	}
+}
+
+// TestSchemaTitlesNameStructs tests that root and nested schema titles replace the generated struct names
+func TestSchemaTitlesNameStructs(t *testing.T) {
+	prompt := `---
+output:
+  schema:
+    type: object
+    title: Classification Result
+    properties:
+      label:
+        type: string
+      source:
+        type: object
+        title: DocumentRef
+        properties:
+          url: {type: string}
+      history:
+        type: array
+        items:
+          type: object
+          title: Revision
+          properties:
+            note: {type: string}
+---
+Classify.`
+
+	gen, _ := createTempGenerator(t, "models")
+	code := processPromptContent(t, gen, "classify.prompt", prompt)
+
+	assert.Contains(t, code, "// ClassificationResult represents the output for classify\ntype ClassificationResult struct {")
+	assert.Contains(t, code, "Source  DocumentRef `json:\"source\"`")
+	assert.Contains(t, code, "type DocumentRef struct {")
+	assert.Contains(t, code, "History []Revision  `json:\"history,omitempty\"`")
+	assert.Contains(t, code, "type Revision struct {")
+	assert.NotContains(t, code, "ClassifyOutput")
+	require.NoError(t, CheckGoCompiles("classify.gen.go", []byte(code)))
+
+	gen.IgnoreTitle = true
+	code = processPromptContent(t, gen, "classify.prompt", prompt)
+	assert.Contains(t, code, "type ClassifyOutput struct {")
+	assert.Contains(t, code, "Source  Source        `json:\"source\"`")
+	assert.NotContains(t, code, "ClassificationResult")
+
+	promptPath := filepath.Join(t.TempDir(), "clash.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      left:
+        type: object
+        title: Side
+        properties:
+          name: {type: string}
+      right:
+        type: object
+        title: Side
+        properties:
+          size: {type: integer}
+---
+Compare.`), 0o600))
+	require.NoError(t, ProcessFile(gen, promptPath), "Without titles the structs keep their field names")
+	gen.IgnoreTitle = false
+	require.ErrorContains(t, ProcessFile(gen, promptPath), "struct Side is defined with conflicting fields")
+}
+
+// TestExtraImportsAreAddedToGeneratedFiles tests that -import paths are de-duplicated and sorted into the import list
+func TestExtraImportsAreAddedToGeneratedFiles(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.TypeMappings = map[string]string{"number": "decimal.Decimal"}
+	gen.Imports = []string{"github.com/shopspring/decimal", "fmt"}
+
+	code := processPromptContent(t, gen, "invoice.prompt", `---
+output:
+  schema:
+    total: number
+    status(enum): open, paid
+---
+Invoice.`)
+
+	assert.Contains(t, code, "import \"fmt\"\nimport \"github.com/shopspring/decimal\"\n")
+	assert.Equal(t, 1, strings.Count(code, "import \"fmt\""))
+
+	gen.Imports = []string{"github.com/shopspring/decimal\""}
+	promptPath := filepath.Join(t.TempDir(), "invoice.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte("---\noutput:\n  schema:\n    total: number\n---\nInvoice."), 0o600))
+	require.ErrorContains(t, ProcessFile(gen, promptPath), `invalid import path "github.com/shopspring/decimal\""`)
+}
+
+// TestProcessDirectoryDepth tests that subdirectories are searched down to -max-depth, or not at all without -recursive
+func TestProcessDirectoryDepth(t *testing.T) {
+	promptDir := t.TempDir()
+	prompt := "---\noutput:\n  schema:\n    summary: string\n---\nRun.\n"
+
+	for _, path := range []string{"root.prompt", "a/one.prompt", "a/b/two.prompt", "a/b/c/three.prompt"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(promptDir, filepath.Dir(path)), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(promptDir, path), []byte(prompt), 0o600))
+	}
+
+	// A link back to the root must not make the walk recurse forever
+	require.NoError(t, os.Symlink(promptDir, filepath.Join(promptDir, "a", "loop")))
+
+	tests := []struct {
+		name        string
+		noRecursive bool
+		maxDepth    int
+		want        []string
+	}{
+		{name: "unlimited", want: []string{"one.gen.go", "root.gen.go", "three.gen.go", "two.gen.go"}},
+		{name: "max depth", maxDepth: 2, want: []string{"one.gen.go", "root.gen.go", "two.gen.go"}},
+		{name: "flat", noRecursive: true, want: []string{"root.gen.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, outDir := createTempGenerator(t, "models")
+			gen.AllowDuplicateTypes = true
+			gen.NoRecursive = tt.noRecursive
+			gen.MaxDepth = tt.maxDepth
+
+			require.NoError(t, ProcessDirectory(gen, promptDir))
+
+			entries, err := os.ReadDir(outDir)
+			require.NoError(t, err)
+
+			var generated []string
+			for _, entry := range entries {
+				generated = append(generated, entry.Name())
+			}
+
+			assert.Equal(t, tt.want, generated)
+		})
+	}
+}
+
+// TestProcessDirectoryIgnoreFile tests that .dotpromptignore patterns exclude prompts from directory runs
+func TestProcessDirectoryIgnoreFile(t *testing.T) {
+	promptDir := t.TempDir()
+	prompt := "---\noutput:\n  schema:\n    summary: string\n---\nRun.\n"
+
+	for _, path := range []string{"keep.prompt", "draft_idea.prompt", "experimental/a.prompt", "experimental/stable.prompt", "deep/x/wip/b.prompt"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(promptDir, filepath.Dir(path)), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(promptDir, path), []byte(prompt), 0o600))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, ignoreFileName), []byte(`# experimental prompts
+draft_*.prompt
+/experimental/
+!experimental/stable.prompt
+**/wip/**
+`), 0o600))
+
+	gen, outDir := createTempGenerator(t, "models")
+	gen.AllowDuplicateTypes = true
+
+	require.NoError(t, ProcessDirectory(gen, promptDir))
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+
+	var generated []string
+	for _, entry := range entries {
+		generated = append(generated, entry.Name())
+	}
+
+	assert.Equal(t, []string{"keep.gen.go", "stable.gen.go"}, generated)
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, ignoreFileName), []byte("[z-a].prompt\n"), 0o600))
+	require.ErrorContains(t, ProcessDirectory(gen, promptDir), "line 1: invalid pattern")
+}
+
+// TestEmitTestsWritesRoundTripTests tests that -emit-tests writes a JSON round-trip test for every generated struct
+func TestEmitTestsWritesRoundTripTests(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	gen.EmitTests = true
+
+	promptFile := filepath.Join(outDir, "review.prompt")
+	prompt := `---
+output:
+  schema:
+    type: object
+    required: [status, owner]
+    properties:
+      status:
+        type: string
+        enum: [open, closed]
+      owner:
+        type: object
+        required: [role]
+        properties:
+          role:
+            type: string
+            enum: [admin, member]
+---
+Review.
+`
+	require.NoError(t, os.WriteFile(promptFile, []byte(prompt), 0o600))
+	require.NoError(t, ProcessFile(gen, promptFile))
+
+	code, err := os.ReadFile(filepath.Join(outDir, "review.gen_test.go"))
+	require.NoError(t, err)
+
+	codeStr := string(code)
+	assert.Contains(t, codeStr, "package models")
+	assert.Contains(t, codeStr, "func TestReviewOutputRoundTrip(t *testing.T)")
+	assert.Contains(t, codeStr, "func TestOwnerRoundTrip(t *testing.T)")
+	assert.Contains(t, codeStr, "Owner:  sampleOwner(),")
+	assert.Contains(t, codeStr, "Status: StatusEnumOpen,")
+	assert.Contains(t, codeStr, "if err := decoded.Status.Validate(); err != nil {")
+	assert.NotContains(t, codeStr, "github.com/")
+
+	t.Run("dry run", func(t *testing.T) {
+		gen.DryRun = true
+
+		require.NoError(t, ProcessFile(gen, promptFile))
+	})
+}
+
+// TestCustomTemplate tests that a custom template renders codegen.TemplateData and that broken templates fail to load
+func TestCustomTemplate(t *testing.T) {
+	structs := []codegen.GoStruct{{
+		Name: "ReviewOutput",
+		Fields: []codegen.GoField{
+			{Name: "Summary", GoType: "string", JSONTag: "summary", ExtraTags: map[string]string{"validate": "required"}},
+			{Name: "Score", GoType: "*int", JSONTag: "score", IsPointer: true, OmitEmpty: true},
+		},
+	}}
+
+	gen := codegen.Generator{
+		PackageName: "models",
+		Template: `// Code generated by a custom template. DO NOT EDIT.
+
+package {{.Package}}
+{{range .Structs}}
+type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.GoType}} ` + "`{{.StructTags}}`" + `
+{{end}}}
+
+const {{.Name}}Validated = {{.HasValidationFields}}
+{{end}}`,
+	}
+
+	code, err := GenerateGoCodeWithOptions(gen, structs, nil)
+	require.NoError(t, err)
+
+	codeStr := string(code)
+	assert.True(t, strings.HasPrefix(codeStr, "// Code generated by a custom template. DO NOT EDIT."))
+	assert.Contains(t, codeStr, "Summary string `json:\"summary\" validate:\"required\"`")
+	assert.Contains(t, codeStr, "Score   *int   `json:\"score,omitempty\"`")
+	assert.Contains(t, codeStr, "const ReviewOutputValidated = false")
+
+	t.Run("parse error", func(t *testing.T) {
+		gen.Template = "package {{.Package"
+
+		_, err := GenerateGoCodeWithOptions(gen, structs, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse template")
+	})
+
+	t.Run("load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "models.gotmpl")
+		require.NoError(t, os.WriteFile(path, []byte("package {{.Package}}\n{{range .Structs}}"), 0o600))
+
+		_, err := LoadTemplate(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse template "+path)
+	})
+}
+
+// TestFormatErrorWritesRawOutput tests that code rejected by go/format is reported with its line and kept for inspection
+func TestFormatErrorWritesRawOutput(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	gen.Template = "package {{.Package}}\n{{range .Structs}}\ntype {{.Name}} struct {\n\tBroken(\n}\n{{end}}"
+
+	inputFile := filepath.Join(outDir, "review.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    summary: string
+---
+Review the code.
+`), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+
+	var formatErr *FormatError
+	require.ErrorAs(t, err, &formatErr)
+	assert.Equal(t, 5, formatErr.Line)
+	assert.Contains(t, err.Error(), "failed to format generated code: 5:1: expected type, found '}' (line 5: })")
+	assert.Contains(t, err.Error(), "unformatted code written to "+filepath.Join(outDir, "review.gen.go.raw"))
+
+	raw, err := os.ReadFile(filepath.Join(outDir, "review.gen.go.raw"))
+	require.NoError(t, err)
+	assert.Equal(t, string(formatErr.Code), string(raw))
+	assert.NoFileExists(t, filepath.Join(outDir, "review.gen.go"))
+
+	t.Run("gofmt=false", func(t *testing.T) {
+		gen.NoGofmt = true
+		gen.Template = "package {{.Package}}\n{{range .Structs}}\ntype   {{.Name}}   struct{ Raw  string }\n{{end}}"
+
+		require.NoError(t, ProcessFile(gen, inputFile))
+
+		code, err := os.ReadFile(filepath.Join(outDir, "review.gen.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package models\n\ntype   ReviewOutput   struct{ Raw  string }\n", string(code))
+	})
+}
+
+// TestEmitMetadata tests that -emit-metadata declares the frontmatter model and config of a prompt
+func TestEmitMetadata(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	gen.EmitMetadata = true
+
+	tests := []struct {
+		name     string
+		prompt   string
+		want     []string
+		wantNone []string
+		wantErr  string
+	}{
+		{
+			name:     "model only",
+			prompt:   "---\nmodel: googleai/gemini-2.0-flash\noutput:\n  schema:\n    summary: string\n---\nRun.\n",
+			want:     []string{`const ModelOnlyModel = "googleai/gemini-2.0-flash"`},
+			wantNone: []string{"ModelOnlyConfig"},
+		},
+		{
+			name:     "config only",
+			prompt:   "---\nconfig:\n  temperature: 1.0\n  topK: 40\noutput:\n  schema:\n    summary: string\n---\nRun.\n",
+			want:     []string{"var ConfigOnlyConfig = map[string]any{", `"temperature": 1.0,`, `"topK":        40,`},
+			wantNone: []string{"ConfigOnlyModel"},
+		},
+		{
+			name:     "neither",
+			prompt:   "---\noutput:\n  schema:\n    summary: string\n---\nRun.\n",
+			wantNone: []string{"NeitherModel", "NeitherConfig"},
+		},
+		{
+			name:    "config is not a mapping",
+			prompt:  "---\nconfig: [temperature]\noutput:\n  schema:\n    summary: string\n---\nRun.\n",
+			wantErr: "config must be a mapping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promptFile := filepath.Join(outDir, strings.ReplaceAll(tt.name, " ", "_")+".prompt")
+			require.NoError(t, os.WriteFile(promptFile, []byte(tt.prompt), 0o600))
+
+			err := ProcessFile(gen, promptFile)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			code, err := os.ReadFile(strings.TrimSuffix(promptFile, ".prompt") + ".gen.go")
+			require.NoError(t, err)
+
+			for _, want := range tt.want {
+				assert.Contains(t, string(code), want)
+			}
+
+			for _, unwanted := range tt.wantNone {
+				assert.NotContains(t, string(code), unwanted)
+			}
+		})
+	}
+}
+
+// TestGeneratedHeaderNamesSource tests that generated files carry the standard marker and their prompt paths
+func TestGeneratedHeaderNamesSource(t *testing.T) {
+	generatedMarker := regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+	promptDir := t.TempDir()
+	prompt := "---\noutput:\n  schema:\n    label: string\n---\nClassify.\n"
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "bug.prompt"), []byte(prompt), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "task.prompt"), []byte(prompt), 0o600))
+
+	gen := codegen.Generator{PackageName: "models", OutputDir: filepath.Join(promptDir, "models")}
+	require.NoError(t, ProcessFile(gen, filepath.Join(promptDir, "bug.prompt")))
+
+	code, err := os.ReadFile(filepath.Join(gen.OutputDir, "bug.gen.go"))
+	require.NoError(t, err)
+
+	lines := strings.Split(string(code), "\n")
+	assert.Regexp(t, generatedMarker, lines[0])
+	assert.Equal(t, "// Source: bug.prompt", lines[1])
+
+	gen.SingleFile = "models.gen.go"
+	require.NoError(t, ProcessDirectory(gen, promptDir))
+
+	code, err = os.ReadFile(filepath.Join(gen.OutputDir, "models.gen.go"))
+	require.NoError(t, err)
+
+	lines = strings.Split(string(code), "\n")
+	assert.Regexp(t, generatedMarker, lines[0])
+	assert.Equal(t, "// Source: bug.prompt, task.prompt", lines[1])
+}
+
+// TestSequentialIntEnumsUseIota tests that integer enums valued 0, 1, 2, ... are declared with iota
+func TestSequentialIntEnumsUseIota(t *testing.T) {
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      level:
+        type: integer
+        enum: [0, 1, 2]
+      tier:
+        type: integer
+        enum: [0, 1, 3]
+    required: [level, tier]
+---
+Rate.`
+
+	gen, _ := createTempGenerator(t, "models")
+	code := processPromptContent(t, gen, "rating.prompt", prompt)
+
+	assert.Contains(t, code, "\tLevelEnum0 LevelEnum = iota\n\tLevelEnum1\n\tLevelEnum2\n")
+	assert.Contains(t, code, "\tTierEnum0 TierEnum = 0\n\tTierEnum1 TierEnum = 1\n\tTierEnum3 TierEnum = 3\n")
+	assert.Contains(t, code, "case LevelEnum0, LevelEnum1, LevelEnum2:")
+	require.NoError(t, CheckGoCompiles("rating.gen.go", []byte(code)))
+}
+
+// TestProcessDirectoryJobs tests that concurrent rendering writes the same files as a sequential run
+// and reports every failing prompt by name
+func TestProcessDirectoryJobs(t *testing.T) {
+	promptDir := t.TempDir()
+	writeBenchmarkPrompts(t, promptDir, 20)
+
+	sequential, sequentialDir := createTempGenerator(t, "models")
+	sequential.Jobs = 1
+	require.NoError(t, ProcessDirectory(sequential, promptDir))
+
+	concurrent, concurrentDir := createTempGenerator(t, "models")
+	concurrent.Jobs = 8
+	require.NoError(t, ProcessDirectory(concurrent, promptDir))
+
+	entries, err := os.ReadDir(sequentialDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 21) // including enum_errors.gen.go
+
+	for _, entry := range entries {
+		want, err := os.ReadFile(filepath.Join(sequentialDir, entry.Name()))
+		require.NoError(t, err)
+
+		got, err := os.ReadFile(filepath.Join(concurrentDir, entry.Name()))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), entry.Name())
+	}
+
+	broken := "---\noutput:\n  schema:\n    type: object\n    properties:\n      level:\n        type: string\n        enum: not-a-list\n---\nRate.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "broken_a.prompt"), []byte(broken), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "broken_b.prompt"), []byte(broken), 0o600))
+
+	failing, failingDir := createTempGenerator(t, "models")
+	failing.Jobs = 8
+	err = ProcessDirectory(failing, promptDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken_a.prompt: ")
+	assert.Contains(t, err.Error(), "broken_b.prompt: ")
+
+	entries, err = os.ReadDir(failingDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "Nothing is written when a prompt fails without -keep-going")
+}
+
+// TestInputHashSkipsUnchangedPrompts tests which changes regenerate a file that records its input hash
+func TestInputHashSkipsUnchangedPrompts(t *testing.T) {
+	promptDir := t.TempDir()
+	promptFile := filepath.Join(promptDir, "review.prompt")
+	schemaFile := filepath.Join(promptDir, "review.schema.json")
+
+	require.NoError(t, os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"verdict": {"type": "string"}}}`), 0o600))
+	require.NoError(t, os.WriteFile(promptFile, []byte("---\noutput:\n  schema:\n    $ref: ./review.schema.json\n---\nReview.\n"), 0o600))
+
+	gen, outDir := createTempGenerator(t, "models")
+	outputFile := filepath.Join(outDir, "review.gen.go")
+
+	originalVersion := Version
+	Version = "v0.0.0-test"
+	t.Cleanup(func() { Version = originalVersion })
+
+	// regenerated marks the output so the next run shows whether it was written again
+	regenerated := func() bool {
+		t.Helper()
+
+		require.NoError(t, ProcessDirectory(gen, promptDir))
+
+		code, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(outputFile, append(code, "// marker\n"...), 0o600))
+
+		return !strings.HasSuffix(string(code), "// marker\n")
+	}
+
+	assert.True(t, regenerated(), "A missing output is generated")
+	assert.False(t, regenerated(), "Unchanged inputs are skipped")
+
+	gen.Getters = true
+	assert.True(t, regenerated(), "Changed options regenerate")
+	assert.False(t, regenerated())
+
+	require.NoError(t, os.WriteFile(schemaFile, []byte(`{"type": "object", "properties": {"score": {"type": "integer"}}}`), 0o600))
+	assert.True(t, regenerated(), "A changed external schema regenerates")
+
+	Version = "v0.0.1-test"
+	assert.True(t, regenerated(), "A new generator version regenerates")
+
+	gen.Force = true
+	gen.Verbose = true
+	assert.True(t, regenerated(), "-force regenerates")
+
+	gen.Force = false
+	assert.False(t, regenerated(), "Verbose output does not change the hash")
+
+	Version = devVersion
+	assert.True(t, regenerated(), "Dev builds do not use the cache")
+	assert.True(t, regenerated())
+	Version = "v0.0.1-test"
+
+	// Skipped files still take part in the duplicate type name check
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "other.prompt"), []byte("---\noutput:\n  schema:\n    title: ReviewOutput\n    type: object\n    properties:\n      x: {type: string}\n---\nOther.\n"), 0o600))
+	require.ErrorContains(t, ProcessDirectory(gen, promptDir), "ReviewOutput in "+outDir)
+}
+
+// TestReadOnlyAndWriteOnlySplitSharedSchema tests that readOnly fields are output-only and writeOnly fields input-only
+func TestReadOnlyAndWriteOnlySplitSharedSchema(t *testing.T) {
+	promptDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "account.schema.json"), []byte(`{
+  "type": "object",
+  "properties": {
+    "id": {"type": "string", "readOnly": true},
+    "name": {"type": "string"},
+    "password": {"type": "string", "writeOnly": true},
+    "role": {"type": "string", "enum": ["admin", "member"], "readOnly": true}
+  },
+  "required": ["id", "name", "password"]
+}`), 0o600))
+
+	promptFile := filepath.Join(promptDir, "account.prompt")
+	require.NoError(t, os.WriteFile(promptFile, []byte(`---
+input:
+  schema:
+    $ref: ./account.schema.json
+output:
+  schema:
+    $ref: ./account.schema.json
+---
+Create an account for {{name}}.
+`), 0o600))
+
+	gen, outDir := createTempGenerator(t, "models")
+	require.NoError(t, ProcessFile(gen, promptFile))
+
+	code, err := os.ReadFile(filepath.Join(outDir, "account.gen.go"))
+	require.NoError(t, err)
+
+	codeStr := string(code)
+	assert.Contains(t, codeStr, "type AccountInput struct {\n\tName     string `json:\"name\"`\n\tPassword string `json:\"password\"`\n}")
+	assert.Contains(t, codeStr, "type AccountOutput struct {\n\tID   string    `json:\"id\"`\n\tName string    `json:\"name\"`\n\tRole *RoleEnum `json:\"role,omitempty\"`\n}")
+	require.NoError(t, CheckGoCompiles("account.gen.go", code))
+}
+
+// TestEmitIsZeroConditions tests the zero checks generated for each kind of field
+func TestEmitIsZeroConditions(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.EmitIsZero = true
+
+	code := processPromptContent(t, gen, "event.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      name: {type: string}
+      count: {type: integer}
+      at: {type: string, format: date-time}
+      level: {type: string, enum: [low, high]}
+      labels:
+        type: object
+        additionalProperties: {type: string}
+      payload:
+        type: string
+        x-codegen-go-type: json.RawMessage
+        x-codegen-import: encoding/json
+    required: [name, count, at, level, labels, payload]
+---
+Record {{name}}.
+`)
+
+	assert.Contains(t, code, `func (x EventInput) IsZero() bool {
+	return x.Name == "" &&
+		x.Count == 0 &&
+		x.At.IsZero() &&
+		x.Level == "" &&
+		len(x.Labels) == 0 &&
+		reflect.ValueOf(x.Payload).IsZero()
+}`)
+	assert.Contains(t, code, "import \"reflect\"")
+	require.NoError(t, CheckGoCompiles("event.gen.go", []byte(code)))
+}
+
+// TestEnumValueDescriptions tests that x-enum-descriptions document each enum constant
+func TestEnumValueDescriptions(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	code := processPromptContent(t, gen, "triage.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      severity:
+        type: string
+        enum: [low, high]
+        x-enum-descriptions: [Can wait for the next release, Page the on-call engineer]
+      labels:
+        type: array
+        items:
+          type: string
+          enum: [bug, docs]
+          enumDescriptions: [Something is broken]
+      state:
+        type: string
+        enum: [open, closed]
+        x-enum-descriptions: [Still open, Resolved, Unused extra]
+    required: [severity, state]
+---
+Triage.
+`)
+
+	assert.Contains(t, code, "\t// Can wait for the next release\n\tSeverityEnumLow SeverityEnum = \"low\"\n\t// Page the on-call engineer\n\tSeverityEnumHigh SeverityEnum = \"high\"\n")
+	assert.Contains(t, code, "\t// Something is broken\n\tLabelsItemEnumBug  LabelsItemEnum = \"bug\"\n\tLabelsItemEnumDocs LabelsItemEnum = \"docs\"\n")
+	assert.Contains(t, code, "\t// Resolved\n\tStateEnumClosed StateEnum = \"closed\"\n)")
+}
+
+// TestEnumValuesWithSpecialCharacters tests that enum values containing quotes, backslashes
+// and percent signs are escaped in constants, error messages and typed errors
+func TestEnumValuesWithSpecialCharacters(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.ErrorTypes = true
+
+	code := processPromptContent(t, gen, "quoting.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      error_code:
+        type: string
+        enum: ['say "hi"', 'back\slash', '100%']
+    required: [error_code]
+---
+Quote.
+`)
+
+	assert.Contains(t, code, `"say \"hi\""`)
+	assert.Contains(t, code, `"back\\slash"`)
+	assert.Contains(t, code, `must be one of: say \"hi\", back\\slash, 100%%"`)
+	assert.Contains(t, code, `errors.New("say \"hi\"")`)
+	require.NoError(t, CheckGoCompiles("quoting.gen.go", []byte(code)))
+}
+
+// TestEnumErrorsFileSharedByPackage tests that per-prompt files share one ErrInvalidEnum declaration
+func TestEnumErrorsFileSharedByPackage(t *testing.T) {
+	promptDir := t.TempDir()
+	gen, outDir := createTempGenerator(t, "models")
+
+	prompt := func(name, values string) string {
+		return "---\noutput:\n  schema:\n    type: object\n    properties:\n      " + name +
+			":\n        type: string\n        enum: " + values + "\n---\nRate.\n"
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "bug.prompt"), []byte(prompt("severity", "[low, high]")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "task.prompt"), []byte(prompt("state", "[open, done]")), 0o600))
+	require.NoError(t, ProcessDirectory(gen, promptDir))
+
+	enumErrors, err := os.ReadFile(filepath.Join(outDir, "enum_errors.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(enumErrors), "package models")
+	assert.Contains(t, string(enumErrors), "var ErrInvalidEnum = errors.New(\"invalid enum value\")")
+
+	for _, name := range []string{"bug.gen.go", "task.gen.go"} {
+		code, err := os.ReadFile(filepath.Join(outDir, name))
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "var ErrInvalidEnum")
+		assert.Contains(t, string(code), "ErrInvalidEnum, string(e))")
+		require.NoError(t, CheckGoCompiles(name, code))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "enum_errors.prompt"), []byte(prompt("kind", "[a, b]")), 0o600))
+	err = ProcessDirectory(gen, promptDir)
+	require.ErrorContains(t, err, "is reserved for ErrInvalidEnum")
+}
+
+// TestAllRequiredOutputHasNoPointers tests that -all-required only keeps pointers for nullable output fields
+func TestAllRequiredOutputHasNoPointers(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.AllRequired = true
+
+	code := processPromptContent(t, gen, "review.prompt", `---
+output:
+  schema:
+    type: object
+    properties:
+      summary: {type: string}
+      score: {type: integer}
+      verdict: {type: string, enum: [accept, reject]}
+      note: {type: [string, "null"]}
+      tags:
+        type: array
+        items: {type: string}
+      weights:
+        type: object
+        additionalProperties: {type: number}
+      author:
+        type: object
+        properties:
+          name: {type: string}
+          email: {type: string}
+      first:
+        $ref: "#/$defs/Item"
+    $defs:
+      Item:
+        type: object
+        properties:
+          name: {type: string}
+---
+Review it.
+`)
+
+	assert.Contains(t, code, "Summary string             `json:\"summary\"`")
+	assert.Contains(t, code, "Score   int                `json:\"score\"`")
+	assert.Contains(t, code, "Verdict VerdictEnum        `json:\"verdict\"`")
+	assert.Contains(t, code, "Note    *string            `json:\"note,omitempty\"`", "Nullable fields stay pointers")
+	assert.Contains(t, code, "Tags    []string           `json:\"tags\"`", "Slices are not omitted when empty")
+	assert.Contains(t, code, "Weights map[string]float64 `json:\"weights\"`")
+	assert.Contains(t, code, "Author  Author             `json:\"author\"`")
+	assert.Contains(t, code, "Email string `json:\"email\"`")
+	assert.Contains(t, code, "type Item struct {\n\tName string `json:\"name\"`\n}", "Shared definitions are required too")
+	require.NoError(t, CheckGoCompiles("review.gen.go", []byte(code)))
+}
+
+// TestOptionalStyleWrapper tests that -optional-style=wrapper generates Optional[T] fields declared by a shared file
+func TestOptionalStyleWrapper(t *testing.T) {
+	gen, outDir := createTempGenerator(t, "models")
+	gen.OptionalStyle = OptionalStyleWrapper
+	gen.ValidateAll = true
+
+	content := `---
+output:
+  schema:
+    type: object
+    properties:
+      title:
+        type: string
+      note:
+        type: string
+        minLength: 3
+      score:
+        type: integer
+        default: 1
+      priority:
+        type: string
+        enum: [low, high]
+      parent:
+        $ref: "#"
+    required: [title]
+---
+Triage.`
+
+	code := processPromptContent(t, gen, "triage.prompt", content)
+	assert.Contains(t, code, "Title    string                 `json:\"title\"`")
+	assert.Contains(t, code, "Note     Optional[string]       `json:\"note,omitzero\"`", "Optional fields get no minLength rule")
+	assert.Contains(t, code, "Priority Optional[PriorityEnum] `json:\"priority,omitzero\"`")
+	assert.Contains(t, code, "Parent   *TriageOutput", "Struct pointers are kept")
+	assert.Contains(t, code, "if !x.Score.IsSet() {\n\t\tx.Score = Some[int](1)\n\t}")
+	assert.Contains(t, code, "if v, ok := x.Priority.Get(); ok {")
+	assert.NotContains(t, code, "type Optional[T any]", "Optional is declared by the shared file")
+
+	optional, err := os.ReadFile(filepath.Join(outDir, "optional.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(optional), "type Optional[T any] struct")
+	require.NoError(t, CheckGoCompiles("triage.gen.go", []byte(code)))
+
+	// Without the wrapper style optional fields stay pointers
+	gen.OptionalStyle = ""
+	code = processPromptContent(t, gen, "triage.prompt", content)
+	assert.Contains(t, code, "Note     *string")
+	assert.NotContains(t, code, "Optional[")
+}
+
+// TestOptionalStyleWrapperRejectsUncheckedOptions tests that options the Optional[T] wrapper cannot honor fail generation
+func TestOptionalStyleWrapperRejectsUncheckedOptions(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+	gen.OptionalStyle = OptionalStyleWrapper
+	gen.ConstraintTags = true
+
+	inputFile := filepath.Join(tempDir, "triage.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      title: {type: string, maxLength: 80}
+      note: {type: string, maxLength: 200}
+    required: [title]
+---
+Triage.
+`), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.ErrorContains(t, err, "field Note of struct TriageOutput has constraints that -constraint-tags cannot check inside Optional[string], use -optional-style=pointer")
+
+	gen.ConstraintTags = false
+	gen.NoOmitEmpty = true
+
+	err = ProcessFile(gen, inputFile)
+	require.ErrorContains(t, err, "-optional-style=wrapper cannot be combined with -no-omitempty, absent Optional fields would be encoded as null")
+
+	gen.NoOmitEmpty = false
+	require.NoError(t, ProcessFile(gen, inputFile))
+}
+
+// TestEmitExamplesGeneratesFixtures tests that -emit-examples turns schema examples into <Name>Examples fixtures
+func TestEmitExamplesGeneratesFixtures(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+	gen.SchemaExamples = true
+
+	code := processPromptContent(t, gen, "triage.prompt", `---
+input:
+  schema:
+    type: object
+    properties:
+      ticket: {type: string}
+    required: [ticket]
+    examples: []
+output:
+  schema:
+    type: object
+    properties:
+      priority: {type: string, enum: [low, high]}
+      score: {type: number}
+      retries: {type: integer}
+      note: {type: string}
+      due: {type: string, format: date-time}
+      tags:
+        type: array
+        items: {type: string}
+      owner:
+        type: object
+        properties:
+          name: {type: string}
+        required: [name]
+    required: [priority, owner]
+    examples:
+      - priority: high
+        score: 0.9
+        retries: 2
+        note: escalate
+        due: 2024-05-01T12:30:00Z
+        tags: [billing]
+        owner: {name: Ada}
+      - priority: low
+        owner: {name: Bob}
+---
+Triage {{ticket}}.
+`)
+
+	assert.NotContains(t, code, "TriageInputExamples", "Empty examples emit nothing")
+	assert.Contains(t, code, "var TriageOutputExamples = []TriageOutput{")
+	assert.Contains(t, code, "Priority: PriorityEnumHigh,")
+	assert.Contains(t, code, "Due:      func() *time.Time { value := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC); return &value }(),")
+	assert.Contains(t, code, `Tags:     []string{"billing"},`)
+	assert.Contains(t, code, "},\n\t{\n\t\tPriority: PriorityEnumLow,\n\t\tOwner: Owner{")
+	require.NoError(t, CheckGoCompiles("triage.gen.go", []byte(code)))
+
+	inputFile := filepath.Join(tempDir, "invalid.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      priority: {type: string, enum: [low, high]}
+    examples:
+      - priority: low
+      - priority: urgent
+---
+Triage.
+`), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to generate examples for")
+	assert.Contains(t, err.Error(), "output examples[1]: priority: urgent is not a PriorityEnum value")
+}
+
+// TestPackageDefaultsToOutputDirectoryName tests that the package is named after the output directory without -pkg
+func TestPackageDefaultsToOutputDirectoryName(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "")
+
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      answer: {type: string}
+---
+Answer.
+`
+
+	gen.OutputDir = filepath.Join(tempDir, "chat-models")
+	code := processPromptContent(t, gen, "answer.prompt", prompt)
+	assert.Contains(t, code, "\npackage chatmodels\n")
+	require.NoError(t, CheckGoCompiles("answer.gen.go", []byte(code)))
+
+	gen.PackageName = "models"
+	code = processPromptContent(t, gen, "answer.prompt", prompt)
+	assert.Contains(t, code, "\npackage models\n", "An explicit package wins")
+
+	gen.PackageName = ""
+	gen.OutputDir = filepath.Join(tempDir, "2024")
+	inputFile := filepath.Join(tempDir, "answer.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(prompt), 0o600))
+
+	err := ProcessFile(gen, inputFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot derive a package name from directory 2024, set it with -pkg")
+}
+
+// TestMergeEnumsCollapsesIdenticalValueSets tests that -merge-enums collapses enums with the same values into one type
+func TestMergeEnumsCollapsesIdenticalValueSets(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.MergeEnums = true
+
+	prompt := `---
+output:
+  schema:
+    type: object
+    properties:
+      approved: {type: string, enum: [yes, no]}
+      escalate: {type: string, enum: [no, yes]}
+      level: {type: string, enum: [low, high]}
+      reviews:
+        type: array
+        items:
+          type: object
+          properties:
+            passed:
+              type: array
+              items: {type: string, enum: [yes, no]}
+---
+Review it.
+`
+
+	code := processPromptContent(t, gen, "review.prompt", prompt)
+	assert.Contains(t, code, "\tApproved *NoYesEnum ")
+	assert.Contains(t, code, "\tEscalate *NoYesEnum ")
+	assert.Contains(t, code, "\tPassed []NoYesEnum ")
+	assert.Contains(t, code, "\tLevel    *LevelEnum ")
+	assert.Contains(t, code, "// NoYesEnum represents valid values of the approved, escalate, passed fields")
+	assert.Contains(t, code, "NoYesEnumYes NoYesEnum = \"yes\"")
+	assert.NotContains(t, code, "ApprovedEnum")
+	assert.NotContains(t, code, "EscalateEnum")
+	require.NoError(t, CheckGoCompiles("review.gen.go", []byte(code)))
+
+	again := processPromptContent(t, gen, "review.prompt", prompt)
+	assert.Equal(t, code, again, "Merging is deterministic")
+}
+
+// TestSplitEnumsWritesSeparateFile tests that -split-enums writes the enums of a prompt to <name>.enums.gen.go
+func TestSplitEnumsWritesSeparateFile(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+	gen.SplitEnums = true
+	gen.BuildCheck = true
+
+	inputFile := filepath.Join(tempDir, "ticket.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      title: {type: string}
+      due: {type: string, format: date-time}
+      priority: {type: integer, enum: [1, 2, 3]}
+---
+Create a ticket.
+`), 0o600))
+	require.NoError(t, ProcessFile(gen, inputFile))
+
+	code, err := os.ReadFile(filepath.Join(tempDir, "ticket.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "Priority *PriorityEnum")
+	assert.Contains(t, string(code), "import \"time\"")
+	assert.NotContains(t, string(code), "type PriorityEnum")
+	assert.NotContains(t, string(code), "\"fmt\"", "Imports of the enums file are not repeated")
+
+	enumCode, err := os.ReadFile(filepath.Join(tempDir, "ticket.enums.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(enumCode), "type PriorityEnum int")
+	assert.Contains(t, string(enumCode), "import \"strconv\"")
+	assert.NotContains(t, string(enumCode), "\"time\"")
+
+	// Removing the enum removes the enums file written by the previous run
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+output:
+  schema:
+    type: object
+    properties:
+      title: {type: string}
+---
+Create a ticket.
+`), 0o600))
+	require.NoError(t, ProcessFile(gen, inputFile))
+	assert.NoFileExists(t, filepath.Join(tempDir, "ticket.enums.gen.go"))
+}
+
+// TestCodegenCommentExtension tests that x-codegen-comment replaces the description, one "//" per line
+func TestCodegenCommentExtension(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+
+	content := `---
+output:
+  schema:
+    type: object
+    properties:
+      deadline:
+        type: string
+        description: When the task is due, as an ISO 8601 date
+        x-codegen-comment: |
+          Deadline is parsed by ParseDeadline.
+
+          Zero means no deadline.
+      title:
+        type: string
+        description: Short task title
+    required: [deadline, title]
+---
+Plan.`
+
+	code := processPromptContent(t, gen, "plan.prompt", content)
+	assert.Contains(t, code, "\t// Deadline is parsed by ParseDeadline.\n\t//\n\t// Zero means no deadline.\n\tDeadline string")
+	assert.Contains(t, code, "\t// Short task title\n\tTitle string")
+	assert.NotContains(t, code, "ISO 8601", "The description is replaced")
+	require.NoError(t, CheckGoCompiles("plan.gen.go", []byte(code)))
+}
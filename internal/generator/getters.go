@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// zeroLiterals are the zero values of the builtin types a field can hold.
+var zeroLiterals = map[string]string{ //nolint:gochecknoglobals // read-only lookup table
+	"string":        `""`,
+	"bool":          "false",
+	"int":           "0",
+	"int32":         "0",
+	"int64":         "0",
+	"float32":       "0",
+	"float64":       "0",
+	"time.Duration": "0",
+	"any":           "nil",
+	"interface{}":   "nil",
+}
+
+// assignGetters sets the type, zero value and dereferencing of the generated Get<Field>() method of
+// every field. Pointers to scalars and enums are dereferenced so callers get the zero value instead
+// of nil; pointers to structs are returned as they are, keeping getter chains nil-safe.
+func assignGetters(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	zeros := scalarZeros(structs, enums)
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+
+			field.GetterType = field.GoType
+			field.GetterDeref = false
+
+			if elemType, isPointer := strings.CutPrefix(field.GoType, "*"); isPointer {
+				if _, isScalar := zeros[elemType]; isScalar {
+					field.GetterType = elemType
+					field.GetterDeref = true
+				}
+			}
+
+			field.GetterZero = zeroValue(field.GetterType, zeros)
+		}
+	}
+}
+
+// scalarZeros returns the zero literals of the builtin types plus the enums and union interfaces
+// declared next to structs.
+func scalarZeros(structs []codegen.GoStruct, enums []codegen.GoEnum) map[string]string {
+	zeros := make(map[string]string, len(zeroLiterals)+len(enums))
+	for goType, zero := range zeroLiterals {
+		zeros[goType] = zero
+	}
+
+	for _, enum := range enums {
+		if enum.IsNumeric() {
+			zeros[enum.Name] = "0"
+		} else {
+			zeros[enum.Name] = `""`
+		}
+	}
+
+	for _, union := range collectUnions(structs) {
+		zeros[union.Name] = "nil"
+	}
+
+	return zeros
+}
+
+// zeroValue returns the Go expression for the zero value of goType.
+func zeroValue(goType string, zeros map[string]string) string {
+	if zero, found := zeros[goType]; found {
+		return zero
+	}
+
+	if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		return "nil"
+	}
+
+	// Structs and other composite types, e.g. time.Time or types mapped with x-codegen-go-type
+	return goType + "{}"
+}
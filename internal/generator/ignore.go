@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreFileName is the file at the root of an input directory listing prompts to skip.
+const ignoreFileName = ".dotpromptignore"
+
+// ignoreRule is one gitignore-style pattern of an ignore file.
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool // a "!" pattern re-includes matching prompts
+	dirOnly bool // a pattern ending in "/" only matches directories
+}
+
+// ignoreRules are the patterns of an ignore file in file order; the last matching pattern wins.
+type ignoreRules []ignoreRule
+
+// loadIgnoreFile reads the ignore file of an input directory. A directory without one ignores
+// nothing.
+func loadIgnoreFile(root string) (ignoreRules, error) {
+	data, err := os.ReadFile(filepath.Join(root, ignoreFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", ignoreFileName, err)
+	}
+
+	rules, err := parseIgnoreRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(root, ignoreFileName), err)
+	}
+
+	return rules, nil
+}
+
+// parseIgnoreRules parses gitignore-style patterns, one per line. Blank lines and lines starting
+// with "#" are skipped, "*" and "?" match within a path segment and "**" across segments. Patterns
+// containing a "/" other than a trailing one are relative to the input directory, the others
+// match a file or directory name at any depth.
+func parseIgnoreRules(data []byte) (ignoreRules, error) {
+	var rules ignoreRules
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var rule ignoreRule
+
+		line, rule.negate = strings.CutPrefix(line, "!")
+		line, rule.dirOnly = strings.CutSuffix(line, "/")
+
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		expr := globExpr(line)
+		if !anchored {
+			expr = "(?:.*/)?" + expr
+		}
+
+		pattern, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNumber, scanner.Text(), err)
+		}
+
+		rule.pattern = pattern
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read patterns: %w", err)
+	}
+
+	return rules, nil
+}
+
+// globExpr translates a glob into a regular expression, e.g. "drafts/**/*.prompt" into
+// "drafts/(?:.*/)?[^/]*\.prompt".
+func globExpr(glob string) string {
+	var expr strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		case glob[i] == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				expr.WriteString(regexp.QuoteMeta(glob[i:]))
+
+				return expr.String()
+			}
+
+			class := glob[i+1 : i+end]
+			if negated, ok := strings.CutPrefix(class, "!"); ok {
+				class = "^" + negated
+			}
+
+			expr.WriteString("[" + class + "]")
+			i += end
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	return expr.String()
+}
+
+// ignored reports whether the prompt at relPath, slash separated and relative to the input
+// directory, is excluded. A pattern matching one of its parent directories excludes it too.
+func (rules ignoreRules) ignored(relPath string) bool {
+	ignored := false
+
+	for _, rule := range rules {
+		if rule.matches(relPath) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+// matches reports whether the rule matches relPath or one of its parent directories.
+func (rule ignoreRule) matches(relPath string) bool {
+	if !rule.dirOnly && rule.pattern.MatchString(relPath) {
+		return true
+	}
+
+	for dir := path.Dir(relPath); dir != "."; dir = path.Dir(dir) {
+		if rule.pattern.MatchString(dir) {
+			return true
+		}
+	}
+
+	return false
+}
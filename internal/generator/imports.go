@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// checkImportPaths rejects -import paths that cannot be written as an import declaration.
+func checkImportPaths(paths []string) error {
+	for _, path := range paths {
+		if path == "" || strings.ContainsAny(path, "\"`\\ \t\n") {
+			return fmt.Errorf("invalid import path %q", path)
+		}
+	}
+
+	return nil
+}
+
+// sortImports orders import paths like goimports: standard library packages first, then all
+// others, each group sorted alphabetically.
+func sortImports(imports []string) {
+	slices.SortFunc(imports, func(a, b string) int {
+		if aStd, bStd := isStdImport(a), isStdImport(b); aStd != bStd {
+			if aStd {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+}
+
+// isStdImport reports whether path belongs to the standard library, whose first path element has no dot.
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// assignIsZeroConditions sets the condition each field contributes to the generated IsZero()
+// method. Pointers and interfaces are zero when nil, Optional fields when unset, slices and maps
+// when they are empty (nil or not), nested structs delegate to their own IsZero() and everything
+// else is compared with its zero literal. Types the generator knows nothing about fall back to
+// reflect.
+func assignIsZeroConditions(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	zeros := scalarZeros(structs, enums)
+
+	structNames := make(map[string]bool, len(structs))
+	for _, goStruct := range structs {
+		structNames[goStruct.Name] = true
+	}
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			field.IsZeroCond = isZeroCondition(field.GoType, "x."+field.Name, zeros, structNames)
+		}
+	}
+}
+
+// isZeroCondition returns the Go expression reporting whether value of goType is zero.
+func isZeroCondition(goType, value string, zeros map[string]string, structNames map[string]bool) string {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		return value + " == nil"
+	case strings.HasPrefix(goType, "Optional["):
+		return "!" + value + ".IsSet()"
+	case strings.HasPrefix(goType, "[]"), strings.HasPrefix(goType, "map["):
+		return "len(" + value + ") == 0"
+	case structNames[goType], goType == "time.Time":
+		return value + ".IsZero()"
+	}
+
+	if zero, found := zeros[goType]; found {
+		return value + " == " + zero
+	}
+
+	return "reflect.ValueOf(" + value + ").IsZero()"
+}
+
+// usesReflectIsZero checks if any generated IsZero() method needs the reflect package.
+func usesReflectIsZero(structs []codegen.GoStruct) bool {
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if strings.HasPrefix(field.IsZeroCond, "reflect.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
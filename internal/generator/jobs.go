@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// renderResult is the outcome of rendering one prompt file of a directory.
+type renderResult struct {
+	file *generatedFile // nil when the prompt has nothing to generate
+	err  error
+}
+
+// jobCount returns the number of prompt files rendered concurrently.
+func jobCount(g codegen.Generator) int {
+	if g.Jobs > 0 {
+		return g.Jobs
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// renderFiles renders the prompt files of inputDir on up to jobCount(g) workers. Results are
+// returned in the order of paths so output and error reports do not depend on scheduling.
+func renderFiles(g codegen.Generator, inputDir string, paths []string) []renderResult {
+	results := make([]renderResult, len(paths))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(jobCount(g), len(paths)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i] = renderDirectoryFile(g, inputDir, paths[i])
+			}
+		}()
+	}
+
+	for i := range paths {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// renderDirectoryFile renders one prompt file found in inputDir, naming it in the error.
+func renderDirectoryFile(g codegen.Generator, inputDir, path string) renderResult {
+	fileGen, err := fileGenerator(g, inputDir, path)
+	if err != nil {
+		return renderResult{err: fmt.Errorf("%s: %w", path, err)}
+	}
+
+	file, err := renderFile(fileGen, path)
+	if err != nil {
+		return renderResult{err: fmt.Errorf("%s: %w", path, err)}
+	}
+
+	return renderResult{file: file}
+}
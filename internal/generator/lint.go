@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+	"github.com/oter/dotprompt-gen-go/internal/template"
+)
+
+// LintTemplates validates the template of every prompt file under inputPath (a directory or a
+// single .prompt file) against its input schema. Syntax errors, undefined variables and helper
+// misuse are aggregated across all files and returned as a single error.
+func LintTemplates(g codegen.Generator, inputPath string) error {
+	var (
+		issues    []string
+		fileCount int
+	)
+
+	err := walkPrompts(g, inputPath, func(path string, _ fs.DirEntry) error {
+		fileCount++
+
+		if g.Verbose {
+			fmt.Printf("Linting template: %s\n", path)
+		}
+
+		issues = append(issues, lintPromptFile(g, path)...)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lint templates in %s: %w", inputPath, err)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("template lint found %d issue(s):\n%s", len(issues), strings.Join(issues, "\n"))
+	}
+
+	if g.Verbose {
+		fmt.Printf("Linted %d prompt files, no issues found\n", fileCount)
+	}
+
+	return nil
+}
+
+// lintPromptFile returns all template issues of a single prompt file, prefixed with its path.
+func lintPromptFile(g codegen.Generator, path string) []string {
+	promptFile, err := parser.ParsePromptFile(path)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", path, err)}
+	}
+
+	validationErrs := promptFile.ValidateTemplateWithSchema(g.Helpers)
+
+	if result := promptFile.ValidateTemplate(); result.Valid && len(result.Partials) > 0 {
+		partials, err := partialNames(filepath.Dir(path))
+		if err != nil {
+			return []string{fmt.Sprintf("%s: %v", path, err)}
+		}
+
+		validationErrs = append(validationErrs, template.ValidatePartials(result.Partials, partials)...)
+	}
+
+	var issues []string
+	for _, validationErr := range validationErrs {
+		issues = append(issues, fmt.Sprintf("%s: [%s] %s", path, validationErr.Type, validationErr.Message))
+	}
+
+	return issues
+}
+
+// partialNames returns the partials defined in dir. Following the dotprompt convention the
+// partial {{> header}} is the file _header.prompt.
+func partialNames(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partials in %s: %w", dir, err)
+	}
+
+	partials := make(map[string]bool)
+
+	for _, entry := range entries {
+		if !entry.IsDir() && isPartialFile(entry.Name()) {
+			partials[strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "_"), ".prompt")] = true
+		}
+	}
+
+	return partials, nil
+}
+
+// templateProblems returns the template validation issues of a parsed prompt file as errors.
+func templateProblems(g codegen.Generator, promptFile *ast.PromptFile) []error {
+	var problems []error
+	for _, validationErr := range promptFile.ValidateTemplateWithSchema(g.Helpers) {
+		problems = append(problems, fmt.Errorf("template %s error: %s", validationErr.Type, validationErr.Message))
+	}
+
+	return problems
+}
+
+// warnProblems reports problems of a prompt file on stderr without failing generation, naming
+// the flag that turns them into errors.
+func warnProblems(promptFile *ast.PromptFile, problems []error, strictFlag string) {
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %v (use %s to fail generation)\n", promptFile.Filename, problem, strictFlag)
+	}
+}
+
+// requiredFieldProblems returns an error for every required field name of the input and output
+// schemas that is not one of their properties, which would otherwise silently leave it optional.
+func requiredFieldProblems(promptFile *ast.PromptFile) []error {
+	var problems []error
+
+	for _, name := range parser.UnknownRequiredFields(promptFile.GetInputSchema(), promptFile.GetRequiredInputFields()) {
+		problems = append(problems, fmt.Errorf("required input field %s is not defined in the schema", name))
+	}
+
+	for _, name := range parser.UnknownRequiredFields(promptFile.GetOutputSchema(), promptFile.GetRequiredOutputFields()) {
+		problems = append(problems, fmt.Errorf("required output field %s is not defined in the schema", name))
+	}
+
+	return problems
+}
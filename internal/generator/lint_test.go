@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestLintTemplatesFlagsUndefinedVariable tests that lint reports non-schema variables with file names
+func TestLintTemplatesFlagsUndefinedVariable(t *testing.T) {
+	promptDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "clean.prompt"), []byte(`---
+input:
+  schema:
+    habit: string, the habit
+---
+{{role "user"}}Classify {{habit}}
+`), 0o600))
+
+	typoFile := filepath.Join(promptDir, "typo.prompt")
+	require.NoError(t, os.WriteFile(typoFile, []byte(`---
+input:
+  schema:
+    type: object
+    properties:
+      habit:
+        type: string
+---
+{{role "robot"}}Classify {{habbit}}
+`), 0o600))
+
+	err := LintTemplates(codegen.Generator{}, promptDir)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "2 issue(s)")
+	assert.Contains(t, err.Error(), typoFile+": [variable] Variable 'habbit' not found in input schema")
+	assert.Contains(t, err.Error(), typoFile+": [helper] Invalid role 'robot'")
+	assert.NotContains(t, err.Error(), "clean.prompt")
+}
+
+// TestLintTemplatesCleanDirectory tests that lint passes on the integration prompts
+func TestLintTemplatesCleanDirectory(t *testing.T) {
+	err := LintTemplates(codegen.Generator{}, filepath.Join("..", "integration_tests", "prompts"))
+	assert.NoError(t, err)
+}
+
+// TestLintTemplatesPartials tests that partials are not reported as helpers and must exist as _<name>.prompt files
+func TestLintTemplatesPartials(t *testing.T) {
+	promptDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "_systemHeader.prompt"), []byte("You are a habit coach.\n"), 0o600))
+
+	promptFile := filepath.Join(promptDir, "classify.prompt")
+	require.NoError(t, os.WriteFile(promptFile, []byte(`---
+input:
+  schema:
+    habit: string, the habit
+---
+{{role "system"}}{{> systemHeader}}
+{{role "user"}}{{#if habit}}Classify {{habit}}{{else}}{{> missingFooter}}{{/if}}
+`), 0o600))
+
+	err := LintTemplates(codegen.Generator{}, promptDir)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "1 issue(s)")
+	assert.Contains(t, err.Error(), promptFile+": [partial] Unknown partial 'missingFooter'")
+	assert.NotContains(t, err.Error(), "systemHeader")
+}
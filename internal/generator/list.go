@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+)
+
+// Schema formats reported by ListPrompts.
+const (
+	SchemaFormatPicoschema = "picoschema"
+	SchemaFormatJSONSchema = "json-schema"
+)
+
+// PromptListing describes the types generation would produce for one prompt file.
+type PromptListing struct {
+	File         string   `json:"file"`
+	InputFormat  string   `json:"input_format,omitempty"`  // schema format of the input, empty without one
+	OutputFormat string   `json:"output_format,omitempty"` // schema format of the output, empty without one
+	Structs      []string `json:"structs"`
+	Enums        []string `json:"enums"`
+	Error        string   `json:"error,omitempty"` // why the prompt cannot be generated
+}
+
+// ListPrompts parses every prompt file under inputPath (a directory or a single .prompt file) and
+// writes the schema formats, struct names and enum names generation would produce to w, as text or
+// as a JSON array. Nothing is written to disk. Prompts that fail to parse are listed with their
+// error and make ListPrompts return an error after the listing is written.
+func ListPrompts(g codegen.Generator, inputPath string, asJSON bool, w io.Writer) error {
+	var listings []PromptListing
+
+	err := walkPrompts(g, inputPath, func(path string, _ fs.DirEntry) error {
+		listings = append(listings, listPrompt(g, path))
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list prompts in %s: %w", inputPath, err)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(listings); err != nil {
+			return fmt.Errorf("failed to encode prompt listing: %w", err)
+		}
+	} else {
+		writePromptListings(w, listings)
+	}
+
+	failed := 0
+
+	for _, listing := range listings {
+		if listing.Error != "" {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to list %d of %d prompt files", failed, len(listings))
+	}
+
+	return nil
+}
+
+// listPrompt parses a prompt file and builds its types without rendering them.
+func listPrompt(g codegen.Generator, path string) PromptListing {
+	listing := PromptListing{File: path, Structs: []string{}, Enums: []string{}}
+
+	promptFile, err := parser.ParsePromptFile(path)
+	if err != nil {
+		listing.Error = err.Error()
+
+		return listing
+	}
+
+	listing.InputFormat = schemaFormat(promptFile.GetInputSchema())
+	listing.OutputFormat = schemaFormat(promptFile.GetOutputSchema())
+
+	if !promptFile.HasSchema() {
+		return listing
+	}
+
+	structs, enums, err := buildPromptTypes(g, promptFile)
+	if err != nil {
+		listing.Error = err.Error()
+
+		return listing
+	}
+
+	for _, goStruct := range structs {
+		listing.Structs = append(listing.Structs, goStruct.Name)
+	}
+
+	for _, enum := range enums {
+		listing.Enums = append(listing.Enums, enum.Name)
+	}
+
+	return listing
+}
+
+// schemaFormat returns the format of a frontmatter schema, or "" when there is none.
+func schemaFormat(schema any) string {
+	switch {
+	case schema == nil:
+		return ""
+	case parser.IsPicoschema(schema):
+		return SchemaFormatPicoschema
+	default:
+		return SchemaFormatJSONSchema
+	}
+}
+
+// writePromptListings writes one block per prompt file, omitting empty entries.
+func writePromptListings(w io.Writer, listings []PromptListing) {
+	for _, listing := range listings {
+		fmt.Fprintln(w, listing.File)
+
+		if listing.Error != "" {
+			fmt.Fprintf(w, "  error:   %s\n", listing.Error)
+
+			continue
+		}
+
+		if listing.InputFormat != "" {
+			fmt.Fprintf(w, "  input:   %s\n", listing.InputFormat)
+		}
+
+		if listing.OutputFormat != "" {
+			fmt.Fprintf(w, "  output:  %s\n", listing.OutputFormat)
+		}
+
+		if len(listing.Structs) > 0 {
+			fmt.Fprintf(w, "  structs: %s\n", strings.Join(listing.Structs, ", "))
+		}
+
+		if len(listing.Enums) > 0 {
+			fmt.Fprintf(w, "  enums:   %s\n", strings.Join(listing.Enums, ", "))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestListPrompts tests that the listing reports formats and type names without writing files
+func TestListPrompts(t *testing.T) {
+	promptDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "classify.prompt"), []byte(`---
+input:
+  schema:
+    habit: string
+output:
+  schema:
+    type: object
+    properties:
+      category:
+        type: string
+        enum: [health, work]
+---
+Classify {{habit}}.
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(promptDir, "plain.prompt"), []byte("---\nmodel: test\n---\nHello.\n"), 0o600))
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, ListPrompts(codegen.Generator{}, promptDir, false, &out))
+
+		assert.Equal(t, filepath.Join(promptDir, "classify.prompt")+`
+  input:   picoschema
+  output:  json-schema
+  structs: ClassifyInput, ClassifyOutput
+  enums:   CategoryEnum
+`+filepath.Join(promptDir, "plain.prompt")+"\n", out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, ListPrompts(codegen.Generator{}, promptDir, true, &out))
+
+		var listings []PromptListing
+		require.NoError(t, json.Unmarshal(out.Bytes(), &listings))
+		require.Len(t, listings, 2)
+		assert.Equal(t, PromptListing{
+			File:         filepath.Join(promptDir, "classify.prompt"),
+			InputFormat:  SchemaFormatPicoschema,
+			OutputFormat: SchemaFormatJSONSchema,
+			Structs:      []string{"ClassifyInput", "ClassifyOutput"},
+			Enums:        []string{"CategoryEnum"},
+		}, listings[0])
+		assert.Empty(t, listings[1].Structs)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		brokenFile := filepath.Join(promptDir, "broken.prompt")
+		require.NoError(t, os.WriteFile(brokenFile, []byte("no frontmatter"), 0o600))
+
+		var out bytes.Buffer
+		err := ListPrompts(codegen.Generator{}, promptDir, false, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list 1 of 3 prompt files")
+		assert.Contains(t, out.String(), brokenFile+"\n  error:   invalid dotprompt format")
+	})
+
+	entries, err := os.ReadDir(promptDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "listing must not write generated files")
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// embedPromptMetadata attaches the frontmatter model and config to the first struct of a prompt,
+// so <Prompt>Model and <Prompt>Config declarations are generated next to it. Prompts without a
+// model or config get no declaration for it.
+func embedPromptMetadata(structs []codegen.GoStruct, promptFile *ast.PromptFile, names naming.Initialisms) error {
+	if len(structs) == 0 {
+		return nil
+	}
+
+	baseName, _ := promptStructNames(names, promptFile.Filename, "", "")
+	metadata := &codegen.PromptMetadata{Name: baseName}
+
+	if promptFile.Frontmatter.Model != "" {
+		metadata.Model = strconv.Quote(promptFile.Frontmatter.Model)
+	}
+
+	if promptFile.Frontmatter.Config != nil {
+		config, ok := promptFile.Frontmatter.Config.(map[string]any)
+		if !ok {
+			return fmt.Errorf("config must be a mapping, got %T", promptFile.Frontmatter.Config)
+		}
+
+		literal, err := goValueLiteral(config, "\n")
+		if err != nil {
+			return fmt.Errorf("failed to generate config: %w", err)
+		}
+
+		metadata.Config = literal
+	}
+
+	if metadata.Model == "" && metadata.Config == "" {
+		return nil
+	}
+
+	// The input struct comes first, so prompts without input attach it to their output struct
+	structs[0].Metadata = metadata
+
+	return nil
+}
+
+// goValueLiteral returns the Go literal of a decoded YAML value. Mapping entries are separated by
+// sep and sorted by key so the output is reproducible.
+func goValueLiteral(value any, sep string) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "nil", nil
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return "", fmt.Errorf("unsupported number %v", v)
+		}
+
+		literal := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(literal, ".e") {
+			literal += ".0" // keep whole floats like 1.0 typed float64 inside map[string]any
+		}
+
+		return literal, nil
+	case []any:
+		elems := make([]string, 0, len(v))
+
+		for _, elem := range v {
+			literal, err := goValueLiteral(elem, " ")
+			if err != nil {
+				return "", err
+			}
+
+			elems = append(elems, literal)
+		}
+
+		return "[]any{" + strings.Join(elems, ", ") + "}", nil
+	case map[string]any:
+		var buf strings.Builder
+
+		buf.WriteString("map[string]any{" + sep)
+
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			literal, err := goValueLiteral(v[key], " ")
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", key, err)
+			}
+
+			buf.WriteString(strconv.Quote(key) + ": " + literal + "," + sep)
+		}
+
+		buf.WriteString("}")
+
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// generatedMethod is a method generated on a struct and the option or schema feature generating it.
+type generatedMethod struct {
+	name   string
+	source string
+}
+
+// structMethods returns the methods generated on goStruct.
+func structMethods(g codegen.Generator, goStruct codegen.GoStruct) []generatedMethod {
+	var methods []generatedMethod
+
+	if g.Reset {
+		methods = append(methods, generatedMethod{"Reset", "-reset"})
+	}
+
+	if g.EmitIsZero {
+		methods = append(methods, generatedMethod{"IsZero", "-emit-iszero"})
+	}
+
+	if g.StructValidate && goStruct.NeedsValidation() {
+		methods = append(methods, generatedMethod{"Validate", "-struct-validate"})
+	}
+
+	if g.ValidateAll {
+		methods = append(methods, generatedMethod{"ValidateAll", "-validate-all"})
+	}
+
+	if goStruct.TemplateConst != "" {
+		methods = append(methods, generatedMethod{"Render", "-emit-render"})
+	}
+
+	if len(goStruct.DefaultFields()) > 0 {
+		methods = append(methods, generatedMethod{"ApplyDefaults", "schema defaults"})
+	}
+
+	if goStruct.PrimaryField() != nil {
+		methods = append(methods, generatedMethod{"String", "x-codegen-primary"})
+	}
+
+	if len(goStruct.UnionFields()) > 0 {
+		methods = append(methods, generatedMethod{"UnmarshalJSON", "-experimental-unions"})
+	}
+
+	if g.Getters {
+		for _, field := range goStruct.Fields {
+			methods = append(methods, generatedMethod{"Get" + field.Name, "-getters"})
+		}
+	}
+
+	return methods
+}
+
+// checkMethodNames reports struct fields named like a method generated on their struct, e.g. a
+// reset property with -reset. Go does not allow a field and a method with the same name.
+func checkMethodNames(g codegen.Generator, structs []codegen.GoStruct) error {
+	for _, goStruct := range structs {
+		methods := structMethods(g, goStruct)
+
+		for _, field := range goStruct.Fields {
+			for _, method := range methods {
+				if field.Name == method.name {
+					return fmt.Errorf("field %s of struct %s collides with the %s() method generated for %s, rename the %q property",
+						field.Name, goStruct.Name, method.name, method.source, field.JSONKey())
+				}
+			}
+		}
+	}
+
+	return nil
+}
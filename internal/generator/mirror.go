@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// fileGenerator returns the configuration used for a prompt file found under inputDir. With
+// MirrorTree, prompts in subdirectories are generated into the same subdirectory of the output
+// directory, in a package named after their folder; prompts directly in inputDir are unchanged.
+func fileGenerator(g codegen.Generator, inputDir, promptPath string) (codegen.Generator, error) {
+	if !g.MirrorTree {
+		return g, nil
+	}
+
+	subdir, err := filepath.Rel(inputDir, filepath.Dir(promptPath))
+	if err != nil {
+		return g, fmt.Errorf("failed to resolve %s relative to %s: %w", promptPath, inputDir, err)
+	}
+
+	if subdir == "." {
+		return g, nil
+	}
+
+	packageName, err := packageNameFromDir(filepath.Base(subdir))
+	if err != nil {
+		return g, err
+	}
+
+	g.PackageName = packageName
+	if g.OutputDir != "" {
+		g.OutputDir = filepath.Join(g.OutputDir, subdir)
+	}
+
+	return g, nil
+}
+
+// packageNameFromDir derives a Go package name from a folder name by lower-casing it and dropping
+// characters that are not letters or digits, e.g. "Sentiment-Analysis" becomes "sentimentanalysis".
+func packageNameFromDir(dir string) (string, error) {
+	packageName := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, dir)
+
+	if !token.IsIdentifier(packageName) {
+		return "", fmt.Errorf("cannot derive a package name from directory %s", dir)
+	}
+
+	return packageName, nil
+}
@@ -1,18 +1,88 @@
 package generator
 
 import (
+	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
 	"github.com/oter/dotprompt-gen-go/internal/naming"
 )
 
+const (
+	// DefaultInputSuffix is appended to the prompt name to form the input struct name.
+	DefaultInputSuffix = "Input"
+	// DefaultOutputSuffix is appended to the prompt name to form the output struct name.
+	DefaultOutputSuffix = "Output"
+)
+
+// structSuffixPattern matches suffixes that keep the struct name a valid exported Go identifier.
+var structSuffixPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
 // FilenameToStructNames converts a filename to Go struct names.
 func FilenameToStructNames(filename string) (string, string) {
+	return FilenameToStructNamesWithSuffixes(filename, DefaultInputSuffix, DefaultOutputSuffix)
+}
+
+// FilenameToStructNamesWithSuffixes converts a filename to Go struct names using custom
+// input and output suffixes, e.g. Req/Resp.
+func FilenameToStructNamesWithSuffixes(filename, inputSuffix, outputSuffix string) (string, string) {
+	return promptStructNames(naming.DefaultSet(), filename, inputSuffix, outputSuffix)
+}
+
+// promptStructNames converts a filename to Go struct names with the given initialisms.
+func promptStructNames(names naming.Initialisms, filename, inputSuffix, outputSuffix string) (string, string) {
 	base := strings.TrimSuffix(filepath.Base(filename), ".prompt")
 
 	// Convert snake_case to PascalCase
-	pascal := naming.SnakeToPascalCase(base)
+	pascal := names.SnakeToPascalCase(base)
+
+	return pascal + inputSuffix, pascal + outputSuffix
+}
+
+// initialisms returns the words generated names write in upper case: none with NoInitialisms,
+// otherwise the defaults extended by the configured ones.
+func initialisms(g codegen.Generator) naming.Initialisms {
+	if g.NoInitialisms {
+		return nil
+	}
+
+	return naming.NewInitialisms(append(naming.DefaultInitialisms(), g.Initialisms...))
+}
+
+// assignParamNames sets the constructor parameter name of every field with the configured
+// initialisms, so a leading initialism is lower-cased as a whole, e.g. APIURL becomes apiURL.
+func assignParamNames(structs []codegen.GoStruct, names naming.Initialisms) {
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			field.ParamName = names.GoFieldToParamName(field.Name)
+		}
+	}
+}
+
+// structSuffixes returns the configured input and output struct suffixes, falling back to the
+// defaults, and validates that they produce distinct, valid Go identifiers.
+func structSuffixes(g codegen.Generator) (string, string, error) {
+	inputSuffix, outputSuffix := g.InputSuffix, g.OutputSuffix
+	if inputSuffix == "" {
+		inputSuffix = DefaultInputSuffix
+	}
+
+	if outputSuffix == "" {
+		outputSuffix = DefaultOutputSuffix
+	}
+
+	for _, suffix := range []string{inputSuffix, outputSuffix} {
+		if !structSuffixPattern.MatchString(suffix) {
+			return "", "", fmt.Errorf("invalid struct suffix %q: only letters, digits and underscores are allowed", suffix)
+		}
+	}
+
+	if inputSuffix == outputSuffix {
+		return "", "", fmt.Errorf("input and output struct suffixes must differ, both are %q", inputSuffix)
+	}
 
-	return pascal + "Input", pascal + "Output"
+	return inputSuffix, outputSuffix, nil
 }
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestFilenameToStructNamesWithSuffixes tests struct naming with custom suffixes
+func TestFilenameToStructNamesWithSuffixes(t *testing.T) {
+	input, output := FilenameToStructNames("prompts/classify_habits.prompt")
+	assert.Equal(t, "ClassifyHabitsInput", input)
+	assert.Equal(t, "ClassifyHabitsOutput", output)
+
+	input, output = FilenameToStructNamesWithSuffixes("prompts/classify_habits.prompt", "Req", "Resp")
+	assert.Equal(t, "ClassifyHabitsReq", input)
+	assert.Equal(t, "ClassifyHabitsResp", output)
+}
+
+// TestStructSuffixValidation tests that suffixes producing invalid or clashing names are rejected
+func TestStructSuffixValidation(t *testing.T) {
+	inputSuffix, outputSuffix, err := structSuffixes(codegen.Generator{})
+	require.NoError(t, err)
+	assert.Equal(t, "Input", inputSuffix)
+	assert.Equal(t, "Output", outputSuffix)
+
+	inputSuffix, outputSuffix, err = structSuffixes(codegen.Generator{InputSuffix: "Params", OutputSuffix: "Result"})
+	require.NoError(t, err)
+	assert.Equal(t, "Params", inputSuffix)
+	assert.Equal(t, "Result", outputSuffix)
+
+	_, _, err = structSuffixes(codegen.Generator{InputSuffix: "Req-V2"})
+	assert.ErrorContains(t, err, `invalid struct suffix "Req-V2"`)
+
+	_, _, err = structSuffixes(codegen.Generator{InputSuffix: "Data", OutputSuffix: "Data"})
+	assert.ErrorContains(t, err, "must differ")
+}
+
+// TestCustomSuffixesGeneration tests that configured suffixes flow into generated struct names
+func TestCustomSuffixesGeneration(t *testing.T) {
+	gen, _ := createTempGenerator(t, "models")
+	gen.InputSuffix = "Req"
+	gen.OutputSuffix = "Resp"
+
+	codeStr := processTestPrompt(t, gen, "comprehensive_enums.prompt")
+
+	assert.Contains(t, codeStr, "type ComprehensiveEnumsReq struct")
+	assert.Contains(t, codeStr, "type ComprehensiveEnumsResp struct")
+	assert.NotContains(t, codeStr, "ComprehensiveEnumsInput")
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+const (
+	// OptionalStylePointer generates optional output fields as pointers (default).
+	OptionalStylePointer = "pointer"
+	// OptionalStyleWrapper generates optional output fields as Optional[T].
+	OptionalStyleWrapper = "wrapper"
+)
+
+// optionalFileName is the base name of the file declaring Optional for per-prompt output.
+const optionalFileName = "optional"
+
+// optionalDecl declares the Optional[T] wrapper, inline or in the shared optional file.
+const optionalDecl = `
+// Optional holds an optional field value, telling a field that is absent apart from one set to null
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Some returns an Optional holding value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Null returns an Optional explicitly set to null
+func Null[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// IsSet reports whether the value is present, holding a value or null
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull reports whether the value is present and null
+func (o Optional[T]) IsNull() bool {
+	return o.set && o.null
+}
+
+// Get returns the value and whether there is one, false for absent and null values
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set && !o.null
+}
+
+// IsZero reports whether the value is absent, so omitzero leaves it out of JSON
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// MarshalJSON encodes the value, or null when there is none
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set || o.null {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a present value, which is null or a T
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Null[T]()
+
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*o = Some(value)
+
+	return nil
+}
+`
+
+const optionalTemplate = `// Code generated by dotprompt-gen-go %s. DO NOT EDIT.
+
+package %s
+
+import "encoding/json"
+` + optionalDecl
+
+// applyOptionalStyle wraps the optional pointer fields in Optional[T] with the wrapper style.
+// Pointers to structs are kept, as they break recursive types which a struct wrapper cannot, and
+// so are pointer types forced with x-codegen-go-type. The wrapper style needs omitzero, without it
+// absent fields would be encoded as null, so it is rejected together with -no-omitempty.
+func applyOptionalStyle(g codegen.Generator, structs []codegen.GoStruct) error {
+	switch g.OptionalStyle {
+	case "", OptionalStylePointer:
+		return nil
+	case OptionalStyleWrapper:
+		if g.NoOmitEmpty {
+			return errors.New("-optional-style=wrapper cannot be combined with -no-omitempty, absent Optional fields would be encoded as null")
+		}
+	default:
+		return fmt.Errorf("unsupported optional style %q: expected %s or %s", g.OptionalStyle, OptionalStylePointer, OptionalStyleWrapper)
+	}
+
+	structNames := make(map[string]bool, len(structs))
+	for _, goStruct := range structs {
+		structNames[goStruct.Name] = true
+	}
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+
+			valueType, isPointer := strings.CutPrefix(field.GoType, "*")
+			if !isPointer || structNames[valueType] || strings.HasPrefix(field.TypeOverride, "*") {
+				continue
+			}
+
+			field.GoType = "Optional[" + valueType + "]"
+			field.IsPointer = false
+			field.IsOptional = true
+		}
+	}
+
+	return nil
+}
+
+// usesOptional checks if any field is wrapped in Optional[T].
+func usesOptional(structs []codegen.GoStruct) bool {
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if field.IsOptional {
+				return true
+			}
+		}
+	}
+
+	return false
+}
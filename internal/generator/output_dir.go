@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// DefaultDirMode is the permission of output directories created during generation.
+const DefaultDirMode fs.FileMode = 0o755
+
+// ensureOutputDirs makes sure the directory of every file exists before anything is written,
+// creating each missing directory once. With NoMkdir a missing directory is an error instead.
+func ensureOutputDirs(g codegen.Generator, files ...*generatedFile) error {
+	// A dry run only prints diffs, so nothing needs to exist
+	if g.DryRun {
+		return nil
+	}
+
+	checked := make(map[string]bool)
+
+	for _, file := range files {
+		dir := filepath.Dir(file.outputPath)
+		if checked[dir] {
+			continue
+		}
+
+		checked[dir] = true
+
+		if err := ensureOutputDir(g, dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureOutputDir creates dir and its parents unless it already exists as a directory.
+func ensureOutputDir(g codegen.Generator, dir string) error {
+	info, err := os.Stat(dir)
+
+	switch {
+	case err == nil:
+		if !info.IsDir() {
+			return fmt.Errorf("output path %s exists but is not a directory", dir)
+		}
+
+		return nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("failed to check output directory %s: %w", dir, err)
+	case g.NoMkdir:
+		return fmt.Errorf("output directory %s does not exist and -no-mkdir is set", dir)
+	}
+
+	mode := g.DirMode
+	if mode == 0 {
+		mode = DefaultDirMode
+	}
+
+	if err := os.MkdirAll(dir, mode); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", dir, err)
+	}
+
+	if g.Verbose {
+		fmt.Printf("Created output directory %s\n", dir)
+	}
+
+	return nil
+}
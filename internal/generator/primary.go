@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// checkPrimaryFields ensures at most one field per struct is marked with x-codegen-primary.
+func checkPrimaryFields(structs []codegen.GoStruct) error {
+	for _, goStruct := range structs {
+		var primaries []string
+
+		for _, field := range goStruct.Fields {
+			if field.Primary {
+				primaries = append(primaries, field.JSONTag)
+			}
+		}
+
+		if len(primaries) > 1 {
+			return fmt.Errorf("struct %s has multiple primary fields: %s", goStruct.Name, strings.Join(primaries, ", "))
+		}
+	}
+
+	return nil
+}
+
+// hasFormattedPrimaryField checks if any struct's String() method needs fmt to format its primary field.
+func hasFormattedPrimaryField(structs []codegen.GoStruct) bool {
+	for _, goStruct := range structs {
+		primary := goStruct.PrimaryField()
+		if primary != nil && strings.HasPrefix(primary.StringExpr(""), "fmt.") {
+			return true
+		}
+	}
+
+	return false
+}
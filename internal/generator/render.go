@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+)
+
+// PromptSource is a prompt to render in memory: the file at Path, or Content when it is set.
+// For in-memory content Path only names the prompt, which determines the generated type and file names.
+type PromptSource struct {
+	Path    string
+	Content string
+}
+
+// RenderPrompts renders every prompt source without writing anything and returns the generated
+// code keyed by output file name. Sources without a schema produce no entry. With SingleFile set
+// all prompts are merged into one entry under that name.
+func RenderPrompts(g codegen.Generator, sources []PromptSource) (map[string][]byte, error) {
+	if g.SingleFile != "" {
+		return renderPromptsSingleFile(g, sources)
+	}
+
+	var files []*generatedFile
+
+	for _, source := range sources {
+		promptFile, err := parseSource(source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Path, err)
+		}
+
+		if !promptFile.HasSchema() {
+			continue
+		}
+
+		file, err := renderPromptFile(g, promptFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Path, err)
+		}
+
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+
+	if !g.AllowDuplicateTypes {
+		if err := checkDuplicateTypes(files); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := withSharedFiles(g, files)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := make(map[string][]byte, len(files))
+
+	for _, file := range files {
+		for _, content := range file.contents() {
+			name := filepath.Base(content.path)
+
+			// Prompts from several directories each get an identical enum errors file
+			if existing, exists := generated[name]; exists && !bytes.Equal(existing, content.code) {
+				return nil, fmt.Errorf("%s: output file name %s is generated by more than one prompt", file.source, name)
+			}
+
+			generated[name] = content.code
+		}
+	}
+
+	return generated, nil
+}
+
+// renderPromptsSingleFile merges every prompt source into the single file named by g.SingleFile.
+func renderPromptsSingleFile(g codegen.Generator, sources []PromptSource) (map[string][]byte, error) {
+	var types []promptTypes
+
+	for _, source := range sources {
+		promptFile, err := parseSource(source)
+		if err == nil {
+			err = appendPromptTypes(g, promptFile, &types)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Path, err)
+		}
+	}
+
+	file, err := renderSingleFile(g, types, g.SingleFile)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := make(map[string][]byte, 2)
+	for _, content := range file.contents() {
+		generated[filepath.Base(content.path)] = content.code
+	}
+
+	return generated, nil
+}
+
+// parseSource parses a single prompt source, reading it from disk unless its content is given.
+func parseSource(source PromptSource) (*ast.PromptFile, error) {
+	if source.Path == "" {
+		return nil, errors.New("prompt source has no path")
+	}
+
+	if source.Content == "" {
+		promptFile, err := parser.ParsePromptFile(source.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prompt file: %w", err)
+		}
+
+		return promptFile, nil
+	}
+
+	promptFile, err := parser.ParsePromptContent(source.Content, source.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt content: %w", err)
+	}
+
+	return promptFile, nil
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// renderImportPath is the package executing prompt templates in generated Render() methods.
+const renderImportPath = "github.com/oter/dotprompt-gen-go/pkg/render"
+
+// embedRenderTemplate embeds the prompt template in the input struct so a Render() method is
+// generated for it. The template must be valid handlebars; prompts without input get no method.
+func embedRenderTemplate(structs []codegen.GoStruct, promptFile *ast.PromptFile, names naming.Initialisms) error {
+	if result := promptFile.ValidateTemplate(); !result.Valid {
+		problems := make([]error, 0, len(result.Errors))
+		for _, validationErr := range result.Errors {
+			problems = append(problems, errors.New(validationErr.Message))
+		}
+
+		return errors.Join(problems...)
+	}
+
+	for i := range structs {
+		if !structs[i].IsInput {
+			continue
+		}
+
+		baseName, _ := promptStructNames(names, promptFile.Filename, "", "")
+		structs[i].TemplateConst = baseName + "Template"
+		structs[i].TemplateLiteral = goStringLiteral(promptFile.Template)
+	}
+
+	return nil
+}
+
+// goStringLiteral returns a raw string literal for text, or a quoted one when text contains a backtick.
+func goStringLiteral(text string) string {
+	if strings.Contains(text, "`") {
+		return strconv.Quote(text)
+	}
+
+	return "`" + text + "`"
+}
+
+// hasRenderTemplate reports whether any struct gets a Render() method.
+func hasRenderTemplate(structs []codegen.GoStruct) bool {
+	for _, goStruct := range structs {
+		if goStruct.TemplateConst != "" {
+			return true
+		}
+	}
+
+	return false
+}
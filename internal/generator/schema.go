@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+)
+
+// GenerateFromSchema generates code for a bare JSON Schema (or Picoschema) that is not wrapped in
+// a prompt file. The root object is declared as typeName, and nested objects, enums and $ref
+// definitions are generated as they are for prompt output schemas.
+func GenerateFromSchema(g codegen.Generator, schema map[string]any, typeName string) ([]byte, error) {
+	promptFile := &ast.PromptFile{Filename: typeName}
+	promptFile.Frontmatter.Output.Schema = schema
+
+	structs, enums, err := buildSchemaTypes(g, promptFile, typeName, "a JSON Schema")
+	if err != nil {
+		return nil, err
+	}
+
+	return generateCodeForLanguage(g, structs, enums, fileHeader{})
+}
+
+// ProcessSchemaFile generates the types of a bare JSON Schema file into <name>.gen.go next to the
+// file, or in g.OutputDir, declaring the root object as typeName.
+func ProcessSchemaFile(g codegen.Generator, schemaFile, typeName string) error {
+	if g.Verbose {
+		fmt.Printf("Processing schema file: %s\n", schemaFile)
+	}
+
+	promptFile, err := parser.ParseSchemaFile(schemaFile)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaFile, err)
+	}
+
+	outputPath := getOutputFilePath(g, strings.TrimSuffix(schemaFile, filepath.Ext(schemaFile)))
+
+	g.PackageName, err = outputPackage(g, outputPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaFile, err)
+	}
+
+	structs, enums, err := buildSchemaTypes(g, promptFile, typeName, filepath.Base(schemaFile))
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaFile, err)
+	}
+
+	file, err := renderGeneratedCode(g, structs, enums, fileHeader{sources: []string{schemaFile}}, outputPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaFile, writeRawOutput(g, err))
+	}
+
+	if err := checkOutputPackages(g, []*generatedFile{file}); err != nil {
+		return err
+	}
+
+	if err := ensureOutputDirs(g, file); err != nil {
+		return err
+	}
+
+	if err := file.write(g); err != nil {
+		return err
+	}
+
+	return verifyOutputPackages(g, []*generatedFile{file})
+}
+
+// buildSchemaTypes builds the types of a prompt file holding only an output schema, naming the
+// root struct typeName even when the schema has a title. source describes the schema in the
+// root struct comment when the schema has no description.
+func buildSchemaTypes(
+	g codegen.Generator,
+	promptFile *ast.PromptFile,
+	typeName, source string,
+) ([]codegen.GoStruct, []codegen.GoEnum, error) {
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, nil, fmt.Errorf("invalid type name %q: must be an exported Go identifier", typeName)
+	}
+
+	schema, ok := promptFile.GetOutputSchema().(map[string]any)
+	if !ok {
+		return nil, nil, errors.New("schema must be an object")
+	}
+
+	// The explicit type name wins over the root title, nested titles still name their structs
+	if _, titled := schema["title"]; titled {
+		schema = maps.Clone(schema)
+		delete(schema, "title")
+		promptFile.Frontmatter.Output.Schema = schema
+	}
+
+	structs, enums, err := buildTypes(g, promptFile, "", typeName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(structs) == 0 {
+		return nil, nil, fmt.Errorf("schema for %s has no properties to generate", typeName)
+	}
+
+	if parser.SchemaDescription(schema) == "" {
+		for i := range structs {
+			if structs[i].IsOutput {
+				structs[i].Comments = []string{fmt.Sprintf("%s is generated from %s", typeName, source)}
+			}
+		}
+	}
+
+	return structs, enums, nil
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateFromSchema tests that a bare JSON Schema generates its root and nested types under the given name
+func TestGenerateFromSchema(t *testing.T) {
+	schema := map[string]any{
+		"title": "Purchase",
+		"type":  "object",
+		"properties": map[string]any{
+			"id":     map[string]any{"type": "string"},
+			"status": map[string]any{"type": "string", "enum": []any{"open", "shipped"}},
+			"items": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"sku": map[string]any{"type": "string"}},
+					"required":   []any{"sku"},
+				},
+			},
+		},
+		"required": []any{"id", "status"},
+	}
+
+	code, err := GenerateFromSchema(codegen.Generator{PackageName: "orders"}, schema, "Order")
+	require.NoError(t, err)
+
+	codeStr := string(code)
+	assert.Contains(t, codeStr, "package orders")
+	assert.Contains(t, codeStr, "// Order is generated from a JSON Schema\ntype Order struct {")
+	assert.Contains(t, codeStr, "Status StatusEnum")
+	assert.Contains(t, codeStr, "type ItemsItem struct {")
+	assert.NotContains(t, codeStr, "Purchase", "The type name wins over the root title")
+	assert.Equal(t, "Purchase", schema["title"], "The schema is not modified")
+	require.NoError(t, CheckGoCompiles("order.gen.go", code))
+
+	_, err = GenerateFromSchema(codegen.Generator{PackageName: "orders"}, schema, "order")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid type name "order": must be an exported Go identifier`)
+
+	t.Run("schema file", func(t *testing.T) {
+		gen, tempDir := createTempGenerator(t, "orders")
+
+		schemaFile := filepath.Join(tempDir, "order.json")
+		require.NoError(t, os.WriteFile(schemaFile, []byte(`{
+  "description": "A customer order.",
+  "type": "object",
+  "properties": {
+    "status": {"type": "string"},
+    "id": {"type": "string"}
+  },
+  "required": ["id"]
+}`), 0o600))
+
+		require.NoError(t, ProcessSchemaFile(gen, schemaFile, "Order"))
+
+		generated, err := os.ReadFile(filepath.Join(tempDir, "order.gen.go"))
+		require.NoError(t, err)
+
+		codeStr := string(generated)
+		assert.Contains(t, codeStr, "// Source: order.json")
+		assert.Contains(t, codeStr, "// Order represents A customer order.")
+		assert.Regexp(t, `(?s)Status \*string.*ID +string`, codeStr, "The property order of the file is kept")
+	})
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// sharedFile is a file declaring what the prompt files generated into one package use. They share
+// the package, so none of them can declare it itself.
+type sharedFile struct {
+	name     string // base name of the file, e.g. enum_errors
+	decl     string // name of the declaration, reported when a prompt file claims the output path
+	template string // code formatted with the generator version and the package name
+	usedBy   func(file *generatedFile) bool
+}
+
+// sharedFiles are the files written next to the prompt files that use them.
+var sharedFiles = []sharedFile{ //nolint:gochecknoglobals // read-only lookup table
+	{
+		name:     enumErrorsFileName,
+		decl:     "ErrInvalidEnum",
+		template: enumErrorsTemplate,
+		usedBy:   func(file *generatedFile) bool { return file.usesEnumErrors },
+	},
+	{
+		name:     optionalFileName,
+		decl:     "Optional",
+		template: optionalTemplate,
+		usedBy:   func(file *generatedFile) bool { return file.usesOptional },
+	},
+}
+
+// withSharedFiles appends the shared files used by generated files, once per output directory.
+func withSharedFiles(g codegen.Generator, files []*generatedFile) ([]*generatedFile, error) {
+	outputPaths := make(map[string]string, len(files))
+	for _, file := range files {
+		outputPaths[file.outputPath] = file.source
+	}
+
+	declared := make(map[string]bool)
+	result := files
+
+	for _, shared := range sharedFiles {
+		for _, file := range files {
+			if !shared.usedBy(file) {
+				continue
+			}
+
+			outputPath := filepath.Join(filepath.Dir(file.outputPath), shared.name+outputFileExtension(g))
+			if declared[outputPath] {
+				continue
+			}
+
+			if source, exists := outputPaths[outputPath]; exists {
+				return nil, fmt.Errorf("%s: output file %s is reserved for %s", source, outputPath, shared.decl)
+			}
+
+			declared[outputPath] = true
+			result = append(result, shared.render(g, file.packageName, outputPath))
+		}
+	}
+
+	return result, nil
+}
+
+// render renders the shared file of a package. An existing file with the same content is left
+// alone unless g.Force is set.
+func (shared sharedFile) render(g codegen.Generator, packageName, outputPath string) *generatedFile {
+	file := &generatedFile{
+		source:      shared.name,
+		outputPath:  outputPath,
+		packageName: packageName,
+		code:        fmt.Appendf(nil, shared.template, Version, packageName),
+	}
+
+	if !g.Force {
+		existing, err := os.ReadFile(outputPath)
+		file.cached = err == nil && bytes.Equal(existing, file.code)
+	}
+
+	return file
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/parser"
+)
+
+// promptTypes are the structs and enums built from one prompt file.
+type promptTypes struct {
+	source  string
+	structs []codegen.GoStruct
+	enums   []codegen.GoEnum
+}
+
+// processDirectorySingleFile generates the types of every prompt file under inputDir into the
+// single file named by g.SingleFile. Shared enums and structs declared identically by several
+// prompt files are generated once.
+func processDirectorySingleFile(g codegen.Generator, inputDir string) error {
+	var (
+		types      []promptTypes
+		fileErrors []error
+	)
+
+	outputPath := singleFilePath(g, inputDir)
+
+	packageName, err := outputPackage(g, outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	g.PackageName = packageName
+
+	err = walkPrompts(g, inputDir, func(path string, _ fs.DirEntry) error {
+		if g.Verbose {
+			fmt.Printf("Found prompt file: %s\n", path)
+		}
+
+		promptFile, err := parser.ParsePromptFile(path)
+		if err == nil {
+			err = appendPromptTypes(g, promptFile, &types)
+		}
+
+		if err != nil {
+			if !g.KeepGoing {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			fileErrors = append(fileErrors, fmt.Errorf("%s: %w", path, err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	if len(fileErrors) > 0 {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, errors.Join(fileErrors...))
+	}
+
+	file, err := renderSingleFile(g, types, outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, writeRawOutput(g, err))
+	}
+
+	if err := checkOutputPackages(g, []*generatedFile{file}); err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	if err := ensureOutputDirs(g, file); err != nil {
+		return fmt.Errorf("failed to process directory %s: %w", inputDir, err)
+	}
+
+	if err := file.write(g); err != nil {
+		return err
+	}
+
+	return verifyOutputPackages(g, []*generatedFile{file})
+}
+
+// appendPromptTypes builds the types of a parsed prompt file and appends them to types.
+// Prompt files without a schema or structs are skipped.
+func appendPromptTypes(g codegen.Generator, promptFile *ast.PromptFile, types *[]promptTypes) error {
+	if !promptFile.HasSchema() {
+		if g.Verbose {
+			fmt.Printf("Skipping %s: no schema found\n", promptFile.Filename)
+		}
+
+		return nil
+	}
+
+	packageName, err := promptPackage(g, promptFile)
+	if err != nil {
+		return err
+	}
+
+	if packageName != g.PackageName {
+		return fmt.Errorf("ext.codegen.package %s conflicts with the single file package %s", packageName, g.PackageName)
+	}
+
+	structs, enums, err := buildPromptTypes(g, promptFile)
+	if err != nil {
+		return err
+	}
+
+	if len(structs) > 0 {
+		*types = append(*types, promptTypes{source: promptFile.Filename, structs: structs, enums: enums})
+	}
+
+	return nil
+}
+
+// renderSingleFile merges the types of several prompt files and renders them into one file.
+// Enums and structs sharing a name must be declared identically.
+func renderSingleFile(g codegen.Generator, types []promptTypes, outputFile string) (*generatedFile, error) {
+	if len(types) == 0 {
+		return nil, errors.New("no prompt files with a schema to generate")
+	}
+
+	var (
+		structs []codegen.GoStruct
+		enums   []codegen.GoEnum
+		sources []string
+	)
+
+	for _, promptTypes := range types {
+		structs = append(structs, promptTypes.structs...)
+		enums = append(enums, promptTypes.enums...)
+		sources = append(sources, promptTypes.source)
+	}
+
+	structs, err := dedupeStructs(structs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge prompt files into %s: %w", outputFile, err)
+	}
+
+	enums, err = dedupeEnums(enums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge prompt files into %s: %w", outputFile, err)
+	}
+
+	// An enum shared by several prompts is an error set if any of them uses it as an error code
+	if g.ErrorTypes {
+		markErrorSetEnums(structs, enums)
+	}
+
+	return renderGeneratedCode(g, structs, enums, fileHeader{sources: sources}, outputFile)
+}
+
+// singleFilePath resolves the -single-file name against the output directory, which defaults to
+// the input directory. Absolute paths are used as given.
+func singleFilePath(g codegen.Generator, inputDir string) string {
+	if filepath.IsAbs(g.SingleFile) {
+		return g.SingleFile
+	}
+
+	if g.OutputDir != "" {
+		return filepath.Join(g.OutputDir, g.SingleFile)
+	}
+
+	return filepath.Join(inputDir, g.SingleFile)
+}
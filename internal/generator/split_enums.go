@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// generatedCodeMarker starts the first line of every file written by the generator.
+const generatedCodeMarker = "// Code generated by dotprompt-gen-go"
+
+// enumsFilePath returns the path of the enums file split from outputPath with -split-enums,
+// e.g. order.enums.gen.go next to order.gen.go.
+func enumsFilePath(outputPath string) string {
+	if base, ok := strings.CutSuffix(outputPath, ".gen.go"); ok {
+		return base + ".enums.gen.go"
+	}
+
+	ext := filepath.Ext(outputPath)
+
+	return strings.TrimSuffix(outputPath, ext) + ".enums" + ext
+}
+
+// generateSplitCode renders the structs and the enums of a file as two Go files of one package.
+// Each file computes its own imports, so neither imports packages only the other one uses.
+func generateSplitCode(
+	g codegen.Generator,
+	structs []codegen.GoStruct,
+	enums []codegen.GoEnum,
+	header fileHeader,
+	outputFile string,
+) ([]byte, []byte, error) {
+	// Values helpers are renamed against the struct names, which the enums file does not see
+	enums = withValuesFuncNames(structs, enums)
+
+	code, err := generateCodeForLanguage(g, structs, nil, header)
+	if err != nil {
+		return nil, nil, withFormatErrorPath(err, outputFile)
+	}
+
+	// -import and x-codegen-import paths are used by struct fields only
+	enumGen := g
+	enumGen.Imports = nil
+
+	enumCode, err := generateCodeForLanguage(enumGen, nil, enums, header)
+	if err != nil {
+		return nil, nil, withFormatErrorPath(err, enumsFilePath(outputFile))
+	}
+
+	return code, enumCode, nil
+}
+
+// withFormatErrorPath records the output path of a FormatError, so the unformatted code is
+// written next to it.
+func withFormatErrorPath(err error, outputPath string) error {
+	var formatErr *FormatError
+	if errors.As(err, &formatErr) {
+		formatErr.outputPath = outputPath
+	}
+
+	return err
+}
+
+// cachedEnumsFile reads the enums file split from a cached output. It returns nil code when there
+// is no enums file, and false when the file exists but records another input hash.
+func cachedEnumsFile(outputPath, hash string) ([]byte, bool) {
+	code, err := os.ReadFile(enumsFilePath(outputPath))
+	if err != nil {
+		return nil, true
+	}
+
+	return code, recordedInputHash(code) == hash
+}
+
+// removeStaleEnumsFile deletes the enums file split from outputPath by an earlier run when the
+// prompt no longer declares enums. Files not written by the generator are left alone.
+func removeStaleEnumsFile(outputPath string) error {
+	path := enumsFilePath(outputPath)
+
+	code, err := os.ReadFile(path)
+	if err != nil || !bytes.HasPrefix(code, []byte(generatedCodeMarker)) {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale enums file %s: %w", path, err)
+	}
+
+	fmt.Printf("Removed %s\n", path)
+
+	return nil
+}
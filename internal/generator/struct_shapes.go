@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// typeWrappers are the Go type prefixes a struct name can be wrapped in by a field type.
+var typeWrappers = []string{"*", "[]", "map[string]"} //nolint:gochecknoglobals // read-only lookup table
+
+// collapseIdenticalStructs merges nested structs that declare identical fields into one shared
+// type and rewrites the fields referencing them. The shortest name wins, ties going to the struct
+// declared first, so the output is deterministic. Collapsing repeats until no shapes match, since
+// parents become identical once their children are merged. Input and output structs and union
+// variants always keep their own types.
+func collapseIdenticalStructs(structs []codegen.GoStruct) []codegen.GoStruct {
+	for {
+		renames := identicalStructRenames(structs)
+		if len(renames) == 0 {
+			return structs
+		}
+
+		structs = renameStructs(structs, renames)
+	}
+}
+
+// identicalStructRenames maps every collapsible struct to the shared struct with the same shape.
+func identicalStructRenames(structs []codegen.GoStruct) map[string]string {
+	variants := unionVariantStructNames(structs)
+	collapsible := func(goStruct codegen.GoStruct) bool {
+		return !goStruct.IsInput && !goStruct.IsOutput && !variants[goStruct.Name]
+	}
+
+	shared := make(map[string]string) // struct shape -> shared struct name
+	for _, goStruct := range structs {
+		if !collapsible(goStruct) {
+			continue
+		}
+
+		shape := structShape(goStruct)
+		if name, found := shared[shape]; !found || len(goStruct.Name) < len(name) {
+			shared[shape] = goStruct.Name
+		}
+	}
+
+	renames := make(map[string]string)
+	for _, goStruct := range structs {
+		if !collapsible(goStruct) {
+			continue
+		}
+
+		if name := shared[structShape(goStruct)]; name != goStruct.Name {
+			renames[goStruct.Name] = name
+		}
+	}
+
+	return renames
+}
+
+// unionVariantStructNames returns the structs used as variants of a union field.
+func unionVariantStructNames(structs []codegen.GoStruct) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if field.Union == nil {
+				continue
+			}
+
+			for _, variant := range field.Union.Variants {
+				names[variant.StructName] = true
+			}
+		}
+	}
+
+	return names
+}
+
+// structShape describes the fields of a struct by name, type and tags.
+func structShape(goStruct codegen.GoStruct) string {
+	fields := make([]string, 0, len(goStruct.Fields))
+	for _, field := range goStruct.Fields {
+		fields = append(fields, field.Name+" "+field.GoType+" "+field.StructTags())
+	}
+
+	return strings.Join(fields, "\n")
+}
+
+// renameStructs drops the renamed structs and points the fields referencing them at their
+// replacement.
+func renameStructs(structs []codegen.GoStruct, renames map[string]string) []codegen.GoStruct {
+	kept := make([]codegen.GoStruct, 0, len(structs))
+
+	for _, goStruct := range structs {
+		if _, renamed := renames[goStruct.Name]; renamed {
+			continue
+		}
+
+		for i := range goStruct.Fields {
+			goStruct.Fields[i].GoType = renameType(goStruct.Fields[i].GoType, renames)
+		}
+
+		kept = append(kept, goStruct)
+	}
+
+	return kept
+}
+
+// renameType replaces the struct name inside a field type, keeping pointer, slice and map wrappers.
+func renameType(goType string, renames map[string]string) string {
+	wrapper, baseType := "", goType
+
+	for trimmed := true; trimmed; {
+		trimmed = false
+
+		for _, prefix := range typeWrappers {
+			if strings.HasPrefix(baseType, prefix) {
+				wrapper += prefix
+				baseType = strings.TrimPrefix(baseType, prefix)
+				trimmed = true
+			}
+		}
+	}
+
+	if name, renamed := renames[baseType]; renamed {
+		return wrapper + name
+	}
+
+	return goType
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// validatorImportPath is the package whose ValidateAll aggregates errors in generated Validate() methods.
+const validatorImportPath = "github.com/oter/dotprompt-gen-go/pkg/validator"
+
+// assignStructValidateStatements sets the statement each field contributes to the generated
+// struct-level Validate() method. Enum and nested struct values are appended to the validators
+// passed to validator.ValidateAll; nil pointers and Optional fields without a value are skipped and
+// slices contribute each element, skipping nil elements of recursive []*T slices. Structs without
+// such fields get no Validate() method, so fields holding them contribute nothing either.
+func assignStructValidateStatements(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	validatable := make(map[string]bool, len(enums)+len(structs))
+	for _, enum := range enums {
+		validatable[enum.Name] = true
+	}
+
+	// A struct is validatable once a field holds a validatable value, which can make its parents
+	// validatable too, so repeat until no struct is added
+	for changed := true; changed; {
+		changed = false
+
+		for _, goStruct := range structs {
+			if validatable[goStruct.Name] {
+				continue
+			}
+
+			for _, field := range goStruct.Fields {
+				if structValidateStatement(field, validatable) != "" {
+					validatable[goStruct.Name] = true
+					changed = true
+
+					break
+				}
+			}
+		}
+	}
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			field.ValidatorStmt = structValidateStatement(*field, validatable)
+		}
+	}
+}
+
+// structValidateStatement returns the Go statement collecting a field's validators, or "" if it has none.
+func structValidateStatement(field codegen.GoField, validatable map[string]bool) string {
+	elemType := strings.TrimPrefix(field.ValueType(), "[]")
+	if !validatable[strings.TrimPrefix(elemType, "*")] {
+		return ""
+	}
+
+	value := "x." + field.Name
+
+	switch {
+	case strings.HasPrefix(field.GoType, "[]*"):
+		return fmt.Sprintf("for _, v := range %s {\nif v != nil {\nvalidators = append(validators, *v)\n}\n}", value)
+	case strings.HasPrefix(field.GoType, "[]"):
+		return fmt.Sprintf("for _, v := range %s {\nvalidators = append(validators, v)\n}", value)
+	case field.IsPointer:
+		return fmt.Sprintf("if %s != nil {\nvalidators = append(validators, *%s)\n}", value, value)
+	case field.IsOptional:
+		return fmt.Sprintf("if v, ok := %s.Get(); ok {\nvalidators = append(validators, v)\n}", value)
+	default:
+		return fmt.Sprintf("validators = append(validators, %s)", value)
+	}
+}
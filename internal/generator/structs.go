@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// dedupeStructs removes repeated struct definitions that share a name, which happens when the
+// input and output schemas reference the same shared definition. Structs sharing a name but
+// declaring different fields cannot be represented by one Go type and are reported as an error.
+func dedupeStructs(structs []codegen.GoStruct) ([]codegen.GoStruct, error) {
+	var unique []codegen.GoStruct
+
+	seen := make(map[string]codegen.GoStruct)
+
+	for _, goStruct := range structs {
+		existing, found := seen[goStruct.Name]
+		if !found {
+			seen[goStruct.Name] = goStruct
+			unique = append(unique, goStruct)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(existing.Fields, goStruct.Fields) {
+			return nil, fmt.Errorf("struct %s is defined with conflicting fields", goStruct.Name)
+		}
+	}
+
+	return unique, nil
+}
+
+// renameOutputCopies renames the output structs sharing a name with an input struct but declaring
+// other fields, e.g. a $defs definition with optional fields, which only output structs declare as
+// pointers. Each direction then gets its own copy: the output one is named with the output suffix
+// (AddressOutput) and the output fields referencing it follow. Renaming a struct changes the fields
+// of the structs using it, so this repeats until no copy conflicts.
+func renameOutputCopies(input, output []codegen.GoStruct, suffix string) {
+	inputStructs := make(map[string]codegen.GoStruct, len(input))
+	for _, goStruct := range input {
+		inputStructs[goStruct.Name] = goStruct
+	}
+
+	for {
+		renames := make(map[string]string)
+
+		for _, goStruct := range output {
+			existing, found := inputStructs[goStruct.Name]
+			if found && !reflect.DeepEqual(existing.Fields, goStruct.Fields) {
+				renames[goStruct.Name] = goStruct.Name + suffix
+			}
+		}
+
+		if len(renames) == 0 {
+			return
+		}
+
+		for i := range output {
+			if name, ok := renames[output[i].Name]; ok {
+				output[i].Comments = renameComments(output[i].Comments, output[i].Name, name)
+				output[i].Name = name
+
+				if output[i].Title != "" {
+					output[i].Title += suffix
+				}
+			}
+
+			for j := range output[i].Fields {
+				output[i].Fields[j].GoType = renameType(output[i].Fields[j].GoType, renames)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// FileSummary describes the code generated for one prompt file, reported with -v.
+type FileSummary struct {
+	File       string  `json:"file"`
+	Output     string  `json:"output"`
+	Cached     bool    `json:"cached,omitempty"` // the output was up to date and not rendered again
+	Structs    int     `json:"structs"`
+	Enums      int     `json:"enums"`
+	Depth      int     `json:"depth"` // nesting levels of structs below and including the root structs
+	Bytes      int     `json:"bytes"` // generated code and test file size
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// GenerationSummary aggregates the file summaries of one run.
+type GenerationSummary struct {
+	Files      []FileSummary `json:"files"`
+	Structs    int           `json:"structs"`
+	Enums      int           `json:"enums"`
+	Bytes      int           `json:"bytes"`
+	DurationMS float64       `json:"duration_ms"`
+}
+
+// fileStats are the counts and timing of a rendered prompt file reported in the summary.
+type fileStats struct {
+	structs  int
+	enums    int
+	depth    int
+	duration time.Duration // parsing and rendering, writing is not included
+}
+
+// summarize builds the summary of generated prompt files.
+func summarize(files []*generatedFile) GenerationSummary {
+	summary := GenerationSummary{Files: []FileSummary{}}
+
+	var total time.Duration
+
+	for _, file := range files {
+		fileSummary := FileSummary{
+			File:       file.source,
+			Output:     file.outputPath,
+			Cached:     file.cached,
+			Structs:    file.stats.structs,
+			Enums:      file.stats.enums,
+			Depth:      file.stats.depth,
+			DurationMS: milliseconds(file.stats.duration),
+		}
+
+		for _, content := range file.contents() {
+			fileSummary.Bytes += len(content.code)
+		}
+
+		summary.Files = append(summary.Files, fileSummary)
+		summary.Structs += fileSummary.Structs
+		summary.Enums += fileSummary.Enums
+		summary.Bytes += fileSummary.Bytes
+		total += file.stats.duration
+	}
+
+	summary.DurationMS = milliseconds(total)
+
+	return summary
+}
+
+// writeSummary writes the summary of generated prompt files as one line per file and a total
+// line, or with g.SummaryJSON as a single line of JSON.
+func writeSummary(g codegen.Generator, w io.Writer, files []*generatedFile) error {
+	summary := summarize(files)
+
+	if g.SummaryJSON {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return fmt.Errorf("failed to encode generation summary: %w", err)
+		}
+
+		fmt.Fprintln(w, string(data))
+
+		return nil
+	}
+
+	for _, file := range summary.Files {
+		if file.Cached {
+			fmt.Fprintf(w, "Summary %s: unchanged\n", file.File)
+
+			continue
+		}
+
+		fmt.Fprintf(w, "Summary %s: %d structs, %d enums, depth %d, %d bytes, %.1fms\n",
+			file.File, file.Structs, file.Enums, file.Depth, file.Bytes, file.DurationMS)
+	}
+
+	fmt.Fprintf(w, "Summary total: %d files, %d structs, %d enums, %d bytes, %.1fms\n",
+		len(summary.Files), summary.Structs, summary.Enums, summary.Bytes, summary.DurationMS)
+
+	return nil
+}
+
+// milliseconds converts a duration to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// structDepth returns the nesting levels of structs reachable from the input and output structs,
+// 1 when the root structs have no nested structs. Recursive types are counted once per path.
+func structDepth(structs []codegen.GoStruct) int {
+	nested := make(map[string][]string, len(structs))
+
+	for _, goStruct := range structs {
+		nested[goStruct.Name] = nil
+	}
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if typeName := fieldTypeName(field); typeName != goStruct.Name {
+				if _, isStruct := nested[typeName]; isStruct {
+					nested[goStruct.Name] = append(nested[goStruct.Name], typeName)
+				}
+			}
+		}
+	}
+
+	var depthFrom func(name string, path map[string]bool) int
+
+	depthFrom = func(name string, path map[string]bool) int {
+		path[name] = true
+		defer delete(path, name)
+
+		deepest := 0
+
+		for _, child := range nested[name] {
+			if !path[child] {
+				deepest = max(deepest, depthFrom(child, path))
+			}
+		}
+
+		return deepest + 1
+	}
+
+	depth := 0
+
+	for _, goStruct := range structs {
+		if goStruct.IsInput || goStruct.IsOutput {
+			depth = max(depth, depthFrom(goStruct.Name, make(map[string]bool)))
+		}
+	}
+
+	return depth
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerationSummary tests the per-file and total lines of the verbose summary
+func TestGenerationSummary(t *testing.T) {
+	gen, tempDir := createTempGenerator(t, "models")
+
+	inputFile := filepath.Join(tempDir, "order.prompt")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`---
+input:
+  schema:
+    id: string
+output:
+  schema:
+    type: object
+    properties:
+      status: {type: string, enum: [open, closed]}
+      customer:
+        type: object
+        properties:
+          address:
+            type: object
+            properties:
+              city: {type: string}
+---
+Summarize order {{id}}.
+`), 0o600))
+
+	file, err := renderFile(gen, inputFile)
+	require.NoError(t, err)
+	require.NotNil(t, file)
+
+	var text bytes.Buffer
+	require.NoError(t, writeSummary(gen, &text, []*generatedFile{file}))
+	assert.Contains(t, text.String(), "Summary "+inputFile+": 4 structs, 1 enums, depth 3, ")
+	assert.Contains(t, text.String(), "Summary total: 1 files, 4 structs, 1 enums, ")
+
+	gen.SummaryJSON = true
+
+	var data bytes.Buffer
+	require.NoError(t, writeSummary(gen, &data, []*generatedFile{file}))
+
+	var summary GenerationSummary
+	require.NoError(t, json.Unmarshal(data.Bytes(), &summary))
+	require.Len(t, summary.Files, 1)
+	assert.Equal(t, inputFile, summary.Files[0].File)
+	assert.Equal(t, 3, summary.Files[0].Depth)
+	assert.Equal(t, len(file.code), summary.Bytes)
+	assert.Equal(t, 4, summary.Structs)
+}
@@ -0,0 +1,40 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: order_summary.prompt
+// Input hash: 1cbf8ee140e4dd8e943cf8d0c374b37d
+
+import { z } from "zod";
+
+// StatusEnum represents Current order status
+export const StatusEnumSchema = z.enum(["pending", "shipped", "delivered"]);
+export type StatusEnum = z.infer<typeof StatusEnumSchema>;
+
+// Shipping represents shipping details
+export const ShippingSchema = z.object({
+  carrier: z.string(),
+  tracking_number: z.string().optional(),
+});
+export type Shipping = z.infer<typeof ShippingSchema>;
+
+// OrderSummaryOutput represents the output for order summary
+export const OrderSummaryOutputSchema = z.object({
+  // Short order summary
+  summary: z.string(),
+  // Order total
+  total: z.number().optional(),
+  // Current order status
+  status: StatusEnumSchema.optional(),
+  // Classification tags
+  tags: z.array(z.string()).optional(),
+  // shipping details
+  shipping: ShippingSchema.optional(),
+});
+export type OrderSummaryOutput = z.infer<typeof OrderSummaryOutputSchema>;
+
+// OrderSummaryInput represents the input for order summary
+export const OrderSummaryInputSchema = z.object({
+  // The order identifier
+  order_id: z.string(),
+  // Ordered item names
+  items: z.array(z.string()),
+});
+export type OrderSummaryInput = z.infer<typeof OrderSummaryInputSchema>;
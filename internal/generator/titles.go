@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// applyTitleNames renames structs whose schema declares a title to the title's type name and
+// updates the field types referring to them. Titles that clash with other structs are left to
+// the duplicate checks that run afterwards.
+func applyTitleNames(structs []codegen.GoStruct) []codegen.GoStruct {
+	renames := make(map[string]string)
+
+	for _, goStruct := range structs {
+		if goStruct.Title != "" && goStruct.Title != goStruct.Name {
+			renames[goStruct.Name] = goStruct.Title
+		}
+	}
+
+	if len(renames) == 0 {
+		return structs
+	}
+
+	renamed := make([]codegen.GoStruct, len(structs))
+
+	for i, goStruct := range structs {
+		if title, ok := renames[goStruct.Name]; ok {
+			goStruct.Comments = renameComments(goStruct.Comments, goStruct.Name, title)
+			goStruct.Name = title
+		}
+
+		goStruct.Fields = append([]codegen.GoField(nil), goStruct.Fields...)
+		for j := range goStruct.Fields {
+			goStruct.Fields[j].GoType = renameType(goStruct.Fields[j].GoType, renames)
+		}
+
+		renamed[i] = goStruct
+	}
+
+	return renamed
+}
+
+// renameComments replaces the struct name that starts a struct's doc comment.
+func renameComments(comments []string, oldName, newName string) []string {
+	if len(comments) == 0 || !strings.HasPrefix(comments[0], oldName+" ") {
+		return comments
+	}
+
+	renamed := append([]string(nil), comments...)
+	renamed[0] = newName + strings.TrimPrefix(renamed[0], oldName)
+
+	return renamed
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/config"
+)
+
+// applyTypeMappings replaces the default Go type of primitive fields with the configured
+// override, e.g. number -> float32, keeping pointer and slice wrappers intact.
+func applyTypeMappings(structs []codegen.GoStruct, mappings map[string]string) {
+	if len(mappings) == 0 {
+		return
+	}
+
+	overrides := make(map[string]string, len(mappings))
+	for schemaType, goType := range config.MappableTypes() {
+		if override, ok := mappings[schemaType]; ok {
+			overrides[goType] = override
+		}
+	}
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			if field.IsEnum || field.IsObject || field.TypeOverride != "" {
+				continue
+			}
+
+			wrapper, baseType := splitTypeWrapper(field.GoType)
+			if override, ok := overrides[baseType]; ok {
+				field.GoType = wrapper + override
+			}
+		}
+	}
+}
+
+// timeFormatTypes maps JSON Schema string formats to the time types generated for them. Only
+// formats the type decodes from JSON are mapped: time.Time reads RFC 3339 date-times but not a
+// bare "2024-01-02" date, and time.Duration reads a number, not "PT5M", so date and duration stay
+// strings.
+func timeFormatTypes() map[string]string {
+	return map[string]string{
+		"date-time": "time.Time",
+	}
+}
+
+// applyTimeTypes replaces the string type of date-time formatted fields with time.Time, keeping
+// pointer and slice wrappers intact.
+func applyTimeTypes(structs []codegen.GoStruct) {
+	timeTypes := timeFormatTypes()
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			if field.IsEnum || field.IsObject || field.TypeOverride != "" {
+				continue
+			}
+
+			timeType, ok := timeTypes[field.Format]
+			if !ok {
+				continue
+			}
+
+			if wrapper, baseType := splitTypeWrapper(field.GoType); baseType == "string" {
+				field.GoType = wrapper + timeType
+			}
+		}
+	}
+}
+
+// usesTimeTypes checks if any struct field has a time package type.
+func usesTimeTypes(structs []codegen.GoStruct) bool {
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if _, baseType := splitTypeWrapper(field.ValueType()); strings.HasPrefix(baseType, "time.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fieldImports returns the sorted, de-duplicated import paths required by x-codegen-go-type overrides.
+func fieldImports(structs []codegen.GoStruct) []string {
+	seen := make(map[string]bool)
+
+	var imports []string
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if field.Import == "" || seen[field.Import] {
+				continue
+			}
+
+			seen[field.Import] = true
+			imports = append(imports, field.Import)
+		}
+	}
+
+	sort.Strings(imports)
+
+	return imports
+}
+
+// splitTypeWrapper splits a Go type into its pointer/slice wrapper and base type, e.g.
+// "[]float64" -> ("[]", "float64").
+func splitTypeWrapper(goType string) (string, string) {
+	baseType := strings.TrimLeft(goType, "*[]")
+
+	return goType[:len(goType)-len(baseType)], baseType
+}
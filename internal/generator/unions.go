@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// applyUnions gives discriminated union fields their interface type and adds the structs and
+// enums declared by inline variants. When unions are disabled the fields stay any.
+func applyUnions(structs []codegen.GoStruct, enums []codegen.GoEnum, enabled bool) ([]codegen.GoStruct, []codegen.GoEnum) {
+	// Variant structs are appended while iterating, so unions nested inside variants are handled too
+	for i := 0; i < len(structs); i++ {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			if field.Union == nil {
+				continue
+			}
+
+			if !enabled {
+				field.Union = nil
+
+				continue
+			}
+
+			field.GoType = field.Union.Name
+			field.IsPointer = false
+			union := field.Union
+
+			structs = append(structs, union.Structs...)
+			enums = append(enums, union.Enums...)
+		}
+	}
+
+	return structs, enums
+}
+
+// collectUnions returns the unions held by struct fields, once per union name.
+func collectUnions(structs []codegen.GoStruct) []codegen.GoUnion {
+	var unions []codegen.GoUnion
+
+	seen := make(map[string]bool)
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.UnionFields() {
+			if seen[field.Union.Name] {
+				continue
+			}
+
+			seen[field.Union.Name] = true
+			unions = append(unions, *field.Union)
+		}
+	}
+
+	return unions
+}
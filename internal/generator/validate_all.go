@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// assignValidateAllStatements sets the statement each field contributes to the generated
+// ValidateAll() method: enum fields are validated directly and nested struct fields delegate
+// to their own ValidateAll(), with nil checks for pointers and loops for slices.
+func assignValidateAllStatements(structs []codegen.GoStruct, enums []codegen.GoEnum) {
+	enumNames := make(map[string]bool, len(enums))
+	for _, enum := range enums {
+		enumNames[enum.Name] = true
+	}
+
+	structNames := make(map[string]bool, len(structs))
+	for _, goStruct := range structs {
+		structNames[goStruct.Name] = true
+	}
+
+	for i := range structs {
+		for j := range structs[i].Fields {
+			field := &structs[i].Fields[j]
+			field.ValidateStmt = validateAllStatement(*field, enumNames, structNames)
+		}
+	}
+}
+
+// validateAllStatement returns the Go statement validating a field, or "" if it needs no validation.
+func validateAllStatement(field codegen.GoField, enumNames, structNames map[string]bool) string {
+	isSlice := strings.HasPrefix(field.GoType, "[]")
+	elemType := strings.TrimPrefix(field.ValueType(), "[]")
+	typeName := strings.TrimPrefix(elemType, "*")
+	value := "x." + field.Name
+
+	var check func(value, path string) string
+
+	switch {
+	case enumNames[typeName]:
+		check = func(value, path string) string {
+			return fmt.Sprintf("if err := %s.Validate(); err != nil {\nerrs = append(errs, fmt.Errorf(%q, %serr))\n}", value, path+": %w", pathArgs(path))
+		}
+	case structNames[typeName]:
+		check = func(value, path string) string {
+			return fmt.Sprintf("for _, err := range %s.ValidateAll() {\nerrs = append(errs, fmt.Errorf(%q, %serr))\n}", value, path+".%w", pathArgs(path))
+		}
+	default:
+		return ""
+	}
+
+	switch {
+	case isSlice && strings.HasPrefix(elemType, "*"):
+		return fmt.Sprintf("for i, v := range %s {\nif v != nil {\n%s\n}\n}", value, check("v", field.JSONKey()+"[%d]"))
+	case isSlice:
+		return fmt.Sprintf("for i, v := range %s {\n%s\n}", value, check("v", field.JSONKey()+"[%d]"))
+	case field.IsPointer:
+		return fmt.Sprintf("if %s != nil {\n%s\n}", value, check(value, field.JSONKey()))
+	case field.IsOptional:
+		return fmt.Sprintf("if v, ok := %s.Get(); ok {\n%s\n}", value, check("v", field.JSONKey()))
+	default:
+		return check(value, field.JSONKey())
+	}
+}
+
+// pathArgs returns the format arguments preceding the error for a field path.
+func pathArgs(path string) string {
+	if strings.Contains(path, "%d") {
+		return "i, "
+	}
+
+	return ""
+}
+
+// hasValidateAllStatements checks if any generated ValidateAll() method formats errors with fmt.
+func hasValidateAllStatements(structs []codegen.GoStruct) bool {
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if field.ValidateStmt != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// walkPrompts calls fn for every .prompt file below root in lexical order, skipping partials and
+// prompts excluded by a .dotpromptignore file in root.
+// Subdirectories are searched unless g.NoRecursive is set, at most g.MaxDepth levels deep when it
+// is positive.
+// Symbolic links to directories are not followed, so link loops cannot make the walk recurse forever.
+func walkPrompts(g codegen.Generator, root string, fn func(path string, entry fs.DirEntry) error) error {
+	var rules ignoreRules
+
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Files deleted while walking are simply skipped, a missing root is still an error
+			if path != root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if entry.IsDir() {
+			if path == root {
+				rules, err = loadIgnoreFile(root)
+
+				return err
+			}
+
+			if !searchSubdirectory(g, root, path) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".prompt") {
+			return nil
+		}
+
+		// _<name>.prompt files are partials included by other templates, not prompts of their own
+		if path != root && isPartialFile(entry.Name()) {
+			return nil
+		}
+
+		if path != root && ignoredPrompt(g, rules, root, path) {
+			return nil
+		}
+
+		return fn(path, entry)
+	})
+}
+
+// searchSubdirectory reports whether the directory at path below root is within the search depth.
+func searchSubdirectory(g codegen.Generator, root, path string) bool {
+	if g.NoRecursive {
+		return false
+	}
+
+	if g.MaxDepth <= 0 {
+		return true
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return strings.Count(rel, string(filepath.Separator))+1 <= g.MaxDepth
+}
+
+// isPartialFile reports whether a file name follows the dotprompt partial convention _<name>.prompt.
+func isPartialFile(name string) bool {
+	return strings.HasPrefix(name, "_") && strings.HasSuffix(name, ".prompt")
+}
+
+// ignoredPrompt reports whether the .dotpromptignore rules of root exclude the prompt at path.
+func ignoredPrompt(g codegen.Generator, rules ignoreRules, root, path string) bool {
+	if len(rules) == 0 {
+		return false
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || !rules.ignored(filepath.ToSlash(rel)) {
+		return false
+	}
+
+	if g.Verbose {
+		fmt.Printf("Skipping %s: matched by %s\n", path, ignoreFileName)
+	}
+
+	return true
+}
@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+const (
+	// DefaultWatchInterval is how often watch mode scans the input directory for changes.
+	DefaultWatchInterval = 250 * time.Millisecond
+	// DefaultWatchDebounce is how long the input directory must stay unchanged before regenerating,
+	// so a burst of editor saves produces a single regeneration.
+	DefaultWatchDebounce = 300 * time.Millisecond
+)
+
+// promptState identifies one version of a prompt file on disk.
+type promptState struct {
+	modTime int64 // modification time in Unix nanoseconds
+	size    int64
+}
+
+// watcher polls a directory tree for .prompt changes and regenerates the affected files.
+type watcher struct {
+	g        codegen.Generator
+	dir      string
+	interval time.Duration
+	debounce time.Duration
+}
+
+// Watch generates all prompt files in inputDir and then keeps regenerating the ones that change
+// until ctx is cancelled. Deleted prompt files have their generated file removed. Generation
+// errors are reported without stopping the watch.
+func Watch(ctx context.Context, g codegen.Generator, inputDir string) error {
+	w := watcher{g: g, dir: inputDir, interval: DefaultWatchInterval, debounce: DefaultWatchDebounce}
+
+	return w.run(ctx)
+}
+
+// run performs the initial generation and then polls until ctx is cancelled.
+func (w watcher) run(ctx context.Context) error {
+	known, err := scanPrompts(w.g, w.dir)
+	if err != nil {
+		return fmt.Errorf("failed to watch directory %s: %w", w.dir, err)
+	}
+
+	if err := ProcessDirectory(w.g, w.dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	if w.g.Verbose {
+		fmt.Printf("Watching %s for changes\n", w.dir)
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var (
+		changed    = make(map[string]bool)
+		lastChange time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := scanPrompts(w.g, w.dir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to scan %s: %v\n", w.dir, err)
+
+				continue
+			}
+
+			if paths := changedPrompts(known, current); len(paths) > 0 {
+				for _, path := range paths {
+					changed[path] = true
+				}
+
+				known = current
+				lastChange = now
+			}
+
+			if len(changed) > 0 && now.Sub(lastChange) >= w.debounce {
+				w.regenerate(changed, current)
+				changed = make(map[string]bool)
+			}
+		}
+	}
+}
+
+// regenerate processes every changed prompt file that still exists and removes the generated
+// files of deleted ones.
+func (w watcher) regenerate(changed map[string]bool, current map[string]promptState) {
+	start := time.Now()
+
+	paths := make([]string, 0, len(changed))
+	for path := range changed {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	// A single generated file depends on every prompt, so any change regenerates all of them
+	if w.g.SingleFile != "" {
+		if err := ProcessDirectory(w.g, w.dir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+
+		return
+	}
+
+	var regenerated, removed []string
+
+	for _, path := range paths {
+		fileGen, err := fileGenerator(w.g, w.dir, path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+
+			continue
+		}
+
+		if _, exists := current[path]; !exists {
+			outputPath := getOutputFilePath(fileGen, path)
+			if err := removeOutput(outputPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+
+				continue
+			}
+
+			removed = append(removed, filepath.Base(outputPath))
+
+			continue
+		}
+
+		if err := ProcessFile(fileGen, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+
+			continue
+		}
+
+		regenerated = append(regenerated, filepath.Base(path))
+	}
+
+	if !w.g.Verbose {
+		return
+	}
+
+	summary := fmt.Sprintf("Regenerated %d file(s)", len(regenerated))
+	if len(regenerated) > 0 {
+		summary += ": " + strings.Join(regenerated, ", ")
+	}
+
+	if len(removed) > 0 {
+		summary += "; removed " + strings.Join(removed, ", ")
+	}
+
+	fmt.Printf("%s (%s)\n", summary, time.Since(start).Round(time.Millisecond))
+}
+
+// removeOutput removes a generated file and the enums and test files -split-enums and
+// -emit-tests write next to it. Files that do not exist are ignored.
+func removeOutput(outputPath string) error {
+	for _, path := range []string{outputPath, enumsFilePath(outputPath), testFilePath(outputPath)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// scanPrompts returns the state of every .prompt file under dir that a directory run processes.
+func scanPrompts(g codegen.Generator, dir string) (map[string]promptState, error) {
+	prompts := make(map[string]promptState)
+
+	err := walkPrompts(g, dir, func(path string, entry fs.DirEntry) error {
+		// Files deleted while walking are picked up as removed by the next scan
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+
+		prompts[path] = promptState{modTime: info.ModTime().UnixNano(), size: info.Size()}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan prompt files: %w", err)
+	}
+
+	return prompts, nil
+}
+
+// changedPrompts returns the prompt files added, modified or removed between two scans.
+func changedPrompts(previous, current map[string]promptState) []string {
+	var paths []string
+
+	for path, state := range current {
+		if previousState, ok := previous[path]; !ok || previousState != state {
+			paths = append(paths, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+)
+
+// DefaultMaxLineLength is the default width generated comment lines are wrapped at.
+const DefaultMaxLineLength = 120
+
+// wrapCommentLines wraps "//" comment lines longer than maxLineLength at word boundaries,
+// keeping their indentation. Words longer than the limit are left intact and the generated
+// file header is never wrapped. A maxLineLength of zero or less disables wrapping.
+func wrapCommentLines(code []byte, maxLineLength int) []byte {
+	if maxLineLength <= 0 {
+		return code
+	}
+
+	lines := strings.Split(string(code), "\n")
+
+	var buf bytes.Buffer
+
+	for i, line := range lines {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+
+		buf.WriteString(wrapCommentLine(line, maxLineLength))
+	}
+
+	return buf.Bytes()
+}
+
+// wrapCommentLine wraps a single line if it is an over-long comment line.
+func wrapCommentLine(line string, maxLineLength int) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	if len(line) <= maxLineLength || !strings.HasPrefix(trimmed, "// ") || strings.HasPrefix(trimmed, "// Code generated ") {
+		return line
+	}
+
+	prefix := line[:len(line)-len(trimmed)] + "// "
+
+	var (
+		wrapped []string
+		current string
+	)
+
+	for _, word := range strings.Fields(strings.TrimPrefix(trimmed, "// ")) {
+		if current != "" && len(prefix)+len(current)+1+len(word) > maxLineLength {
+			wrapped = append(wrapped, prefix+current)
+			current = ""
+		}
+
+		if current == "" {
+			current = word
+		} else {
+			current += " " + word
+		}
+	}
+
+	wrapped = append(wrapped, prefix+current)
+
+	return strings.Join(wrapped, "\n")
+}
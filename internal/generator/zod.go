@@ -0,0 +1,238 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+const (
+	// LanguageGo renders Go structs and enums (default).
+	LanguageGo = "go"
+	// LanguageZod renders TypeScript Zod schemas.
+	LanguageZod = "zod"
+)
+
+const zodSchemaTemplate = `// Code generated by dotprompt-gen-go {{.Version}}. DO NOT EDIT.
+{{with .Source}}// Source: {{.}}
+{{end}}{{with .InputHash}}// Input hash: {{.}}
+{{end}}
+import { z } from "zod";
+{{range .Enums}}
+// {{.Name}} represents {{.Comment}}
+export const {{.Name}}Schema = {{zodEnum .}};
+export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+{{end}}{{range .Structs}}{{$struct := .}}
+{{range .Comments}}// {{trim .}}
+{{end}}export const {{.Name}}Schema = z.object({
+{{range .Fields}}{{range .DocLines}}  //{{with .}} {{.}}{{end}}
+{{end}}  {{zodKey .JSONKey}}: {{zodField $struct .}},
+{{end}}});
+export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+{{end}}`
+
+// zodIdentifierPattern matches object keys that can be written without quotes.
+var zodIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// GenerateZodCode generates TypeScript Zod schemas from structs and enums.
+// Structs are emitted in reverse order so nested schemas are declared before their parents.
+func GenerateZodCode(structs []codegen.GoStruct, enums []codegen.GoEnum) ([]byte, error) {
+	return generateZodCode(structs, enums, fileHeader{})
+}
+
+// generateZodCode generates Zod schemas whose header names the prompt files they are generated from.
+func generateZodCode(structs []codegen.GoStruct, enums []codegen.GoEnum, header fileHeader) ([]byte, error) {
+	declared := make(map[string]bool)
+	for _, enum := range enums {
+		declared[enum.Name] = true
+	}
+
+	var ordered []codegen.GoStruct
+
+	for _, goStruct := range structs {
+		if len(goStruct.Fields) > 0 {
+			declared[goStruct.Name] = true
+			ordered = append(ordered, goStruct)
+		}
+	}
+
+	if name := recursiveZodStruct(ordered, declared); name != "" {
+		return nil, fmt.Errorf("zod output does not support recursive type %s", name)
+	}
+
+	slices.Reverse(ordered)
+	outputStructs := outputStructNames(ordered)
+
+	tmpl := template.Must(template.New("zod").Funcs(template.FuncMap{
+		"trim":    strings.TrimSpace,
+		"zodEnum": zodEnum,
+		"zodKey":  zodKey,
+		"zodField": func(goStruct codegen.GoStruct, field codegen.GoField) string {
+			return zodField(field, outputStructs[goStruct.Name], declared)
+		},
+	}).Parse(zodSchemaTemplate))
+
+	templateData := codegen.TemplateData{
+		Version:   Version,
+		Source:    sourceHeader(header.sources),
+		InputHash: header.inputHash,
+		Enums:     enums,
+		Structs:   ordered,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return nil, fmt.Errorf("failed to execute zod template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// recursiveZodStruct returns the name of the first struct that nests itself, or "" when there is
+// none. Zod schemas are declared in order, so they cannot reference themselves.
+func recursiveZodStruct(structs []codegen.GoStruct, declared map[string]bool) string {
+	nestedTypes := make(map[string][]string, len(structs))
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			if typeName := zodTypeName(field.GoType); declared[typeName] {
+				nestedTypes[goStruct.Name] = append(nestedTypes[goStruct.Name], typeName)
+			}
+		}
+	}
+
+	for _, goStruct := range structs {
+		for _, nested := range nestedTypes[goStruct.Name] {
+			if typeReaches(nestedTypes, nested, goStruct.Name) {
+				return goStruct.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+// zodEnum renders a Zod enum for string values or a union of literals for other types.
+func zodEnum(enum codegen.GoEnum) string {
+	var literals []string
+
+	for _, value := range enum.Values {
+		if enum.Type == "string" {
+			literals = append(literals, strconv.Quote(value.Value))
+		} else {
+			literals = append(literals, "z.literal("+value.Value+")")
+		}
+	}
+
+	if enum.Type == "string" {
+		return "z.enum([" + strings.Join(literals, ", ") + "])"
+	}
+
+	return "z.union([" + strings.Join(literals, ", ") + "])"
+}
+
+// zodKey renders an object key, quoting it when it is not a valid identifier.
+func zodKey(key string) string {
+	if zodIdentifierPattern.MatchString(key) {
+		return key
+	}
+
+	return strconv.Quote(key)
+}
+
+// zodTypeName returns the type a field type refers to, without slice, map and pointer wrappers.
+func zodTypeName(goType string) string {
+	typeName := strings.TrimPrefix(strings.TrimLeft(goType, "[]*"), "map[string]")
+
+	return strings.TrimLeft(typeName, "[]*")
+}
+
+// outputStructNames returns the names of the output structs and of the structs nested in them.
+func outputStructNames(structs []codegen.GoStruct) map[string]bool {
+	nestedTypes := make(map[string][]string, len(structs))
+
+	var pending []string
+
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			nestedTypes[goStruct.Name] = append(nestedTypes[goStruct.Name], zodTypeName(field.GoType))
+		}
+
+		if goStruct.IsOutput {
+			pending = append(pending, goStruct.Name)
+		}
+	}
+
+	output := make(map[string]bool)
+
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if !output[name] {
+			output[name] = true
+			pending = append(pending, nestedTypes[name]...)
+		}
+	}
+
+	return output
+}
+
+// zodField maps a field to a Zod expression. Fields of output structs are optional unless required,
+// whatever their Go type, and required pointers are nullable. Input structs only declare pointers
+// for optional fields.
+func zodField(field codegen.GoField, output bool, declared map[string]bool) string {
+	if !output {
+		return zodFieldType(field.GoType, declared)
+	}
+
+	valueType, isPointer := strings.CutPrefix(field.GoType, "*")
+	expr := zodFieldType(valueType, declared)
+
+	switch {
+	case !field.Required:
+		return expr + ".optional()"
+	case isPointer:
+		return expr + ".nullable()"
+	default:
+		return expr
+	}
+}
+
+// zodFieldType maps a Go field type to a Zod expression. Pointer types become optional.
+func zodFieldType(goType string, declared map[string]bool) string {
+	if inner, ok := strings.CutPrefix(goType, "*"); ok {
+		return zodFieldType(inner, declared) + ".optional()"
+	}
+
+	if inner, ok := strings.CutPrefix(goType, "[]"); ok {
+		return "z.array(" + zodFieldType(inner, declared) + ")"
+	}
+
+	if inner, ok := strings.CutPrefix(goType, "map[string]"); ok {
+		return "z.record(z.string(), " + zodFieldType(inner, declared) + ")"
+	}
+
+	switch goType {
+	case "string":
+		return "z.string()"
+	case "int":
+		return "z.number().int()"
+	case "float64":
+		return "z.number()"
+	case "bool":
+		return "z.boolean()"
+	}
+
+	if declared[goType] {
+		return goType + "Schema"
+	}
+
+	return "z.any()"
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites golden files instead of comparing against them: go test -run Zod -update
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// TestZodGolden tests Zod schema output for a prompt with enums, arrays, optional and nested fields
+func TestZodGolden(t *testing.T) {
+	gen, outputDir := createTempGenerator(t, "models")
+	gen.Language = LanguageZod
+
+	err := ProcessFile(gen, filepath.Join("testdata", "order_summary.prompt"))
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile(filepath.Join(outputDir, "order_summary.zod.ts"))
+	require.NoError(t, err)
+
+	goldenFile := filepath.Join("testdata", "order_summary.zod.ts")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(goldenFile, generated, 0o600))
+	}
+
+	golden, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(generated))
+}
+
+// TestZodFieldTypes tests the mapping from Go field types to Zod expressions
+func TestZodFieldTypes(t *testing.T) {
+	declared := map[string]bool{"Address": true}
+
+	tests := map[string]string{
+		"string":             "z.string()",
+		"*int":               "z.number().int().optional()",
+		"[]float64":          "z.array(z.number())",
+		"map[string]bool":    "z.record(z.string(), z.boolean())",
+		"[]Address":          "z.array(AddressSchema)",
+		"*Address":           "AddressSchema.optional()",
+		"map[string]Unknown": "z.record(z.string(), z.any())",
+	}
+
+	for goType, expected := range tests {
+		assert.Equal(t, expected, zodFieldType(goType, declared), "zodFieldType(%q)", goType)
+	}
+}
+
+// TestZodFieldOptionality tests that output fields are optional unless required, whatever their Go type
+func TestZodFieldOptionality(t *testing.T) {
+	declared := map[string]bool{"Address": true}
+
+	assert.Equal(t, "z.array(z.string()).optional()", zodField(codegen.GoField{GoType: "[]string"}, true, declared))
+	assert.Equal(t, "AddressSchema.optional()", zodField(codegen.GoField{GoType: "Address"}, true, declared))
+	assert.Equal(t, "z.string().optional()", zodField(codegen.GoField{GoType: "*string"}, true, declared))
+	assert.Equal(t, "z.string().nullable()", zodField(codegen.GoField{GoType: "*string", Required: true}, true, declared))
+	assert.Equal(t, "z.array(z.string())", zodField(codegen.GoField{GoType: "[]string"}, false, declared), "Input fields follow their Go type")
+}
+
+// TestZodRejectsRecursiveTypes tests that recursive structs fail instead of producing invalid schemas
+func TestZodRejectsRecursiveTypes(t *testing.T) {
+	structs := []codegen.GoStruct{
+		{Name: "TreeNode", Fields: []codegen.GoField{{Name: "Children", GoType: "[]*TreeNode", JSONTag: "children"}}},
+	}
+
+	_, err := GenerateZodCode(structs, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "zod output does not support recursive type TreeNode")
+}
@@ -0,0 +1,65 @@
+package integration_tests
+
+import (
+	"testing"
+
+	"github.com/oter/dotprompt-gen-go/internal/integration_tests/prompts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEnumHelpersRuntime tests the generated String() and Values() enum helpers
+func TestEnumHelpersRuntime(t *testing.T) {
+	assert.Equal(t, []prompts.PriorityEnum{
+		prompts.PriorityEnumLow, prompts.PriorityEnumMedium, prompts.PriorityEnumHigh,
+	}, prompts.PriorityEnumValues(), "Values keep the schema declaration order")
+	assert.Equal(t, prompts.AllPriorityEnum, prompts.PriorityEnumValues())
+
+	values := prompts.PriorityEnumValues()
+	values[0] = prompts.PriorityEnumHigh
+	assert.Equal(t, prompts.PriorityEnumLow, prompts.AllPriorityEnum[0], "Values returns a copy")
+
+	assert.Equal(t, "medium", prompts.PriorityEnumMedium.String())
+	assert.Equal(t, "3", prompts.ConfidenceLevelEnum3.String())
+
+	for _, level := range prompts.ConfidenceLevelEnumValues() {
+		assert.NoError(t, level.Validate())
+	}
+}
+
+// TestEnumParseRuntime tests the generated Parse<Enum>() functions
+func TestEnumParseRuntime(t *testing.T) {
+	priority, err := prompts.ParsePriorityEnum("high")
+	require.NoError(t, err)
+	assert.Equal(t, prompts.PriorityEnumHigh, priority)
+
+	_, err = prompts.ParsePriorityEnum("urgent")
+	require.EqualError(t, err, `invalid enum value "urgent" for PriorityEnum, must be one of: low, medium, high`)
+
+	level, err := prompts.ParseConfidenceLevelEnum("3")
+	require.NoError(t, err)
+	assert.Equal(t, prompts.ConfidenceLevelEnum3, level)
+
+	_, err = prompts.ParseConfidenceLevelEnum("9")
+	require.EqualError(t, err, "invalid enum value 9 for ConfidenceLevelEnum, must be one of: 1, 2, 3, 4, 5")
+
+	_, err = prompts.ParseConfidenceLevelEnum("three")
+	require.EqualError(t, err, `invalid enum value "three" for ConfidenceLevelEnum, must be one of: 1, 2, 3, 4, 5`)
+}
+
+// TestInvalidEnumErrorsWrapSentinel tests that enum errors match ErrInvalidEnum and MustParse panics
+func TestInvalidEnumErrorsWrapSentinel(t *testing.T) {
+	err := prompts.PriorityEnum("urgent").Validate()
+	require.ErrorIs(t, err, prompts.ErrInvalidEnum)
+
+	_, err = prompts.ParseConfidenceLevelEnum("three")
+	require.ErrorIs(t, err, prompts.ErrInvalidEnum)
+
+	_, err = prompts.ParseConfidenceLevelEnum("9")
+	require.ErrorIs(t, err, prompts.ErrInvalidEnum)
+
+	assert.Equal(t, prompts.PriorityEnumHigh, prompts.MustParsePriorityEnum("high"))
+	assert.PanicsWithError(t, `invalid enum value "urgent" for PriorityEnum, must be one of: low, medium, high`, func() {
+		prompts.MustParsePriorityEnum("urgent")
+	})
+}
@@ -61,8 +61,8 @@ func TestCodegenAllTypes(t *testing.T) {
 				{
 					Name: "SimpleTypesOutput",
 					Fields: []ExpectedField{
-						{"Success", "*bool", "success"},
-						{"Message", "*string", "message"},
+						{"Success", "*bool", "success,omitempty"},
+						{"Message", "*string", "message,omitempty"},
 					},
 				},
 			},
@@ -104,7 +104,7 @@ func TestCodegenAllTypes(t *testing.T) {
 				},
 				{
 					Name:   "ConfidenceLevelEnum",
-					Type:   "string",
+					Type:   "int",
 					Values: []string{"1", "2", "3", "4", "5"},
 				},
 			},
@@ -125,7 +125,7 @@ func TestCodegenAllTypes(t *testing.T) {
 					Name: "ArrayTypesOutput",
 					Fields: []ExpectedField{
 						{"Results", "[]string", "results"},
-						{"Counts", "[]int", "counts"},
+						{"Counts", "[]int", "counts,omitempty"},
 					},
 				},
 			},
@@ -135,7 +135,7 @@ func TestCodegenAllTypes(t *testing.T) {
 			PromptFile: "json_schema_basic.prompt",
 			ExpectedStructs: []ExpectedStruct{
 				{
-					Name: "JsonSchemaBasicInput",
+					Name: "JSONSchemaBasicInput",
 					Fields: []ExpectedField{
 						{"Habit", "string", "habit"},
 						{"HabitCategory", "HabitCategoryEnum", "habit_category"},
@@ -143,10 +143,10 @@ func TestCodegenAllTypes(t *testing.T) {
 					},
 				},
 				{
-					Name: "JsonSchemaBasicOutput",
+					Name: "JSONSchemaBasicOutput",
 					Fields: []ExpectedField{
 						{"Summary", "string", "summary"},
-						{"Confidence", "*float64", "confidence"},
+						{"Confidence", "*float64", "confidence,omitempty"},
 						{"Valid", "bool", "valid"},
 					},
 				},
@@ -164,17 +164,17 @@ func TestCodegenAllTypes(t *testing.T) {
 			PromptFile: "json_schema_arrays.prompt",
 			ExpectedStructs: []ExpectedStruct{
 				{
-					Name: "JsonSchemaArraysInput",
+					Name: "JSONSchemaArraysInput",
 					Fields: []ExpectedField{
 						{"Keywords", "[]string", "keywords"},
 						{"Ratings", "[]float64", "ratings"},
 					},
 				},
 				{
-					Name: "JsonSchemaArraysOutput",
+					Name: "JSONSchemaArraysOutput",
 					Fields: []ExpectedField{
-						{"MatchedKeywords", "[]string", "matched_keywords"},
-						{"AverageRating", "*float64", "average_rating"},
+						{"MatchedKeywords", "[]string", "matched_keywords,omitempty"},
+						{"AverageRating", "*float64", "average_rating,omitempty"},
 					},
 				},
 			},
@@ -228,7 +228,12 @@ func TestCodegenAllTypes(t *testing.T) {
 
 				// Verify enum values exist
 				for _, value := range expectedEnum.Values {
-					enumValue := expectedEnum.Name + " = \"" + value + "\""
+					literal := "\"" + value + "\""
+					if expectedEnum.Type != "string" {
+						literal = value
+					}
+
+					enumValue := expectedEnum.Name + " = " + literal
 					assert.Contains(t, codeStr, enumValue, "Expected enum value %s not found in enum %s",
 						value, expectedEnum.Name)
 				}
@@ -0,0 +1,154 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: category_tree.prompt
+// Input hash: 8c825d24bde5c3fe5d1dae4dd57ab58b
+
+package optin
+
+import "encoding/json"
+import "fmt"
+import "github.com/oter/dotprompt-gen-go/pkg/validator"
+
+// CategoryNode represents the output for category tree
+type CategoryNode struct {
+	Name     string          `json:"name"`
+	Kind     KindEnum        `json:"kind"`
+	Children []*CategoryNode `json:"children"`
+	Parent   *CategoryNode   `json:"parent,omitempty"`
+}
+
+// Reset zeroes all fields of CategoryNode so the instance can be reused, e.g. from a sync.Pool
+func (x *CategoryNode) Reset() {
+	*x = CategoryNode{}
+}
+
+// IsZero reports whether every field of CategoryNode holds its zero value; empty slices and maps count as zero
+func (x CategoryNode) IsZero() bool {
+	return x.Name == "" &&
+		x.Kind == "" &&
+		len(x.Children) == 0 &&
+		x.Parent == nil
+}
+
+// Validate checks the enum and nested struct fields of CategoryNode and joins their errors
+func (x CategoryNode) Validate() error {
+	var validators []validator.Validator
+
+	validators = append(validators, x.Kind)
+
+	for _, v := range x.Children {
+		if v != nil {
+			validators = append(validators, *v)
+		}
+	}
+
+	if x.Parent != nil {
+		validators = append(validators, *x.Parent)
+	}
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of CategoryNode and returns all failures
+func (x CategoryNode) ValidateAll() []error {
+	var errs []error
+
+	if err := x.Kind.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("kind: %w", err))
+	}
+
+	for i, v := range x.Children {
+		if v != nil {
+			for _, err := range v.ValidateAll() {
+				errs = append(errs, fmt.Errorf("children[%d].%w", i, err))
+			}
+		}
+	}
+
+	if x.Parent != nil {
+		for _, err := range x.Parent.ValidateAll() {
+			errs = append(errs, fmt.Errorf("parent.%w", err))
+		}
+	}
+
+	return errs
+}
+
+// ExampleCategoryNode returns a sample CategoryNode whose enum fields hold valid values
+func ExampleCategoryNode() CategoryNode {
+	return CategoryNode{
+		Kind: KindEnumFolder,
+	}
+}
+
+// KindEnum represents valid kind values
+type KindEnum string
+
+const (
+	KindEnumFolder KindEnum = "folder"
+	KindEnumLeaf   KindEnum = "leaf"
+)
+
+// AllKindEnum lists every KindEnum value in schema declaration order
+var AllKindEnum = []KindEnum{KindEnumFolder, KindEnumLeaf}
+
+// Validate checks if the KindEnum value is valid
+func (e KindEnum) Validate() error {
+	switch e {
+	case KindEnumFolder, KindEnumLeaf:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for KindEnum, must be one of: folder, leaf", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseKindEnum returns s as a KindEnum, or the Validate() error when it is not a valid value
+func ParseKindEnum(s string) (KindEnum, error) {
+	e := KindEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseKindEnum is like ParseKindEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseKindEnum(s string) KindEnum {
+	e, err := ParseKindEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the KindEnum
+func (e KindEnum) String() string {
+	return string(e)
+}
+
+// KindEnumValues returns a copy of AllKindEnum that callers may modify
+func KindEnumValues() []KindEnum {
+	return append([]KindEnum(nil), AllKindEnum...)
+}
+
+// MarshalJSON encodes the KindEnum value as its underlying string
+func (e KindEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a KindEnum value and rejects values that are not valid KindEnum constants
+func (e *KindEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode KindEnum: %w", err)
+	}
+
+	decoded := KindEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
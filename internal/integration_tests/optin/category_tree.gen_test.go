@@ -0,0 +1,31 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// sampleCategoryNode returns the CategoryNode round-tripped by TestCategoryNodeRoundTrip
+func sampleCategoryNode() CategoryNode {
+	return CategoryNode{
+		Kind: KindEnumFolder,
+	}
+}
+
+func TestCategoryNodeRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleCategoryNode())
+	if err != nil {
+		t.Fatalf("failed to marshal CategoryNode: %v", err)
+	}
+
+	var decoded CategoryNode
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal CategoryNode: %v", err)
+	}
+
+	if err := decoded.Kind.Validate(); err != nil {
+		t.Errorf("invalid Kind: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConstructorPopulatesRequiredFields tests that New<Name> sets every required input field
+func TestConstructorPopulatesRequiredFields(t *testing.T) {
+	input := NewOrderSummaryInput("order-1", []string{"book", "lamp"})
+
+	assert.Equal(t, OrderSummaryInput{
+		OrderID: "order-1",
+		Items:   []string{"book", "lamp"},
+	}, input)
+}
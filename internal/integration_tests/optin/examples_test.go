@@ -0,0 +1,18 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExampleUsesPreferredEnumValue tests that example structs hold the schema example enum value
+func TestExampleUsesPreferredEnumValue(t *testing.T) {
+	example := ExampleOrderSummaryOutput()
+
+	require.NotNil(t, example.Status)
+	assert.Equal(t, StatusEnumShipped, *example.Status)
+	assert.NoError(t, example.Status.Validate())
+	assert.Equal(t, ExampleShipping(), example.Shipping)
+}
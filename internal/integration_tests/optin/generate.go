@@ -0,0 +1,4 @@
+// Package optin contains prompts generated with opt-in generator features enabled.
+package optin
+
+//go:generate go run ../../../cmd/dotprompt-gen-go -dir . -out . -pkg optin -reset -example-structs -validate-all -strict-enums -constructors -experimental-unions -struct-validate -emit-render -emit-tests -emit-metadata -emit-iszero
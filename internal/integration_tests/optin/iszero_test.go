@@ -0,0 +1,21 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsZeroReportsUnsetStructs tests that IsZero checks scalars, pointers, slices and nested structs
+func TestIsZeroReportsUnsetStructs(t *testing.T) {
+	total := 0.0
+
+	assert.True(t, OrderSummaryOutput{}.IsZero())
+	assert.True(t, OrderSummaryOutput{Tags: []string{}}.IsZero(), "An empty non-nil slice counts as zero")
+	assert.False(t, OrderSummaryOutput{Total: &total}.IsZero(), "A pointer to a zero value is set")
+	assert.False(t, OrderSummaryOutput{Tags: []string{"express"}}.IsZero())
+	assert.False(t, OrderSummaryOutput{Shipping: Shipping{Carrier: "ups"}}.IsZero(), "Nested structs delegate to their IsZero")
+
+	assert.True(t, OrderSummaryInput{}.IsZero())
+	assert.False(t, OrderSummaryInput{OrderID: "A-1"}.IsZero())
+}
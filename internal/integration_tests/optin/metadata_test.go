@@ -0,0 +1,18 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPromptMetadata tests that the frontmatter model and config are generated as declarations
+func TestPromptMetadata(t *testing.T) {
+	assert.Equal(t, "openai/gpt-4", TicketReviewModel)
+	assert.Equal(t, map[string]any{
+		"maxOutputTokens": 512,
+		"safety":          map[string]any{"level": "strict"},
+		"stopSequences":   []any{"END"},
+		"temperature":     0.2,
+	}, TicketReviewConfig)
+}
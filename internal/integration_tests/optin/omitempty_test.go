@@ -0,0 +1,32 @@
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNilPointerFieldsOmitted tests that optional pointer fields are left out of JSON when nil
+func TestNilPointerFieldsOmitted(t *testing.T) {
+	output := OrderSummaryOutput{
+		Summary:  "Pending order",
+		Shipping: Shipping{Carrier: "ups"},
+	}
+
+	data, err := json.Marshal(output)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), `"total"`)
+	assert.NotContains(t, string(data), `"status"`)
+	assert.NotContains(t, string(data), `"tracking_number"`)
+	assert.Contains(t, string(data), `"summary":"Pending order"`)
+
+	total := 12.5
+	output.Total = &total
+
+	data, err = json.Marshal(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"total":12.5`)
+}
@@ -0,0 +1,257 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: order_summary.prompt
+// Input hash: 30b8cf742e54b7d4b187125fbae176c1
+
+package optin
+
+import "encoding/json"
+import "fmt"
+import "github.com/oter/dotprompt-gen-go/pkg/render"
+import "github.com/oter/dotprompt-gen-go/pkg/validator"
+
+// OrderSummaryInput represents the input for order summary
+type OrderSummaryInput struct {
+	// The order identifier
+	OrderID string `json:"order_id"`
+	// Ordered item names
+	Items []string `json:"items"`
+}
+
+// NewOrderSummaryInput returns a OrderSummaryInput populated with its required fields
+func NewOrderSummaryInput(orderID string, items []string) OrderSummaryInput {
+	return OrderSummaryInput{
+		OrderID: orderID,
+		Items:   items,
+	}
+}
+
+// OrderSummaryTemplate is the handlebars template of the prompt OrderSummaryInput renders
+const OrderSummaryTemplate = `Summarize order {{order_id}} with items {{#each items}}{{this}} {{/each}}.`
+
+// Render executes OrderSummaryTemplate with x, resolving template variables by json field name
+func (x OrderSummaryInput) Render() (string, error) {
+	return render.Execute(OrderSummaryTemplate, x)
+}
+
+// OrderSummaryModel is the model named in the frontmatter of the OrderSummary prompt
+const OrderSummaryModel = "openai/gpt-4"
+
+// Reset zeroes all fields of OrderSummaryInput so the instance can be reused, e.g. from a sync.Pool
+func (x *OrderSummaryInput) Reset() {
+	*x = OrderSummaryInput{}
+}
+
+// IsZero reports whether every field of OrderSummaryInput holds its zero value; empty slices and maps count as zero
+func (x OrderSummaryInput) IsZero() bool {
+	return x.OrderID == "" &&
+		len(x.Items) == 0
+}
+
+// Validate checks the enum and nested struct fields of OrderSummaryInput and joins their errors
+func (x OrderSummaryInput) Validate() error {
+	var validators []validator.Validator
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of OrderSummaryInput and returns all failures
+func (x OrderSummaryInput) ValidateAll() []error {
+	var errs []error
+
+	return errs
+}
+
+// String returns the OrderID value, the primary field of OrderSummaryInput
+func (x OrderSummaryInput) String() string {
+	return x.OrderID
+}
+
+// ExampleOrderSummaryInput returns a sample OrderSummaryInput whose enum fields hold valid values
+func ExampleOrderSummaryInput() OrderSummaryInput {
+	return OrderSummaryInput{}
+}
+
+// OrderSummaryOutput represents the output for order summary
+type OrderSummaryOutput struct {
+	// Short order summary
+	Summary string `json:"summary"`
+	// Order total
+	Total *float64 `json:"total,omitempty"`
+	// Current order status
+	Status *StatusEnum `json:"status,omitempty"`
+	// Classification tags
+	Tags []string `json:"tags,omitempty"`
+	// shipping details
+	Shipping Shipping `json:"shipping"`
+}
+
+// Reset zeroes all fields of OrderSummaryOutput so the instance can be reused, e.g. from a sync.Pool
+func (x *OrderSummaryOutput) Reset() {
+	*x = OrderSummaryOutput{}
+}
+
+// IsZero reports whether every field of OrderSummaryOutput holds its zero value; empty slices and maps count as zero
+func (x OrderSummaryOutput) IsZero() bool {
+	return x.Summary == "" &&
+		x.Total == nil &&
+		x.Status == nil &&
+		len(x.Tags) == 0 &&
+		x.Shipping.IsZero()
+}
+
+// Validate checks the enum and nested struct fields of OrderSummaryOutput and joins their errors
+func (x OrderSummaryOutput) Validate() error {
+	var validators []validator.Validator
+
+	if x.Status != nil {
+		validators = append(validators, *x.Status)
+	}
+
+	validators = append(validators, x.Shipping)
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of OrderSummaryOutput and returns all failures
+func (x OrderSummaryOutput) ValidateAll() []error {
+	var errs []error
+
+	if x.Status != nil {
+		if err := x.Status.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("status: %w", err))
+		}
+	}
+
+	for _, err := range x.Shipping.ValidateAll() {
+		errs = append(errs, fmt.Errorf("shipping.%w", err))
+	}
+
+	return errs
+}
+
+// String returns the Status value, the primary field of OrderSummaryOutput
+func (x OrderSummaryOutput) String() string {
+	if x.Status == nil {
+		return ""
+	}
+
+	return fmt.Sprint(*x.Status)
+}
+
+// ExampleOrderSummaryOutput returns a sample OrderSummaryOutput whose enum fields hold valid values
+func ExampleOrderSummaryOutput() OrderSummaryOutput {
+	return OrderSummaryOutput{
+		Status:   func() *StatusEnum { v := StatusEnumShipped; return &v }(),
+		Shipping: ExampleShipping(),
+	}
+}
+
+// Shipping represents shipping details
+type Shipping struct {
+	Carrier        string  `json:"carrier"`
+	TrackingNumber *string `json:"tracking_number,omitempty"`
+}
+
+// Reset zeroes all fields of Shipping so the instance can be reused, e.g. from a sync.Pool
+func (x *Shipping) Reset() {
+	*x = Shipping{}
+}
+
+// IsZero reports whether every field of Shipping holds its zero value; empty slices and maps count as zero
+func (x Shipping) IsZero() bool {
+	return x.Carrier == "" &&
+		x.TrackingNumber == nil
+}
+
+// Validate checks the enum and nested struct fields of Shipping and joins their errors
+func (x Shipping) Validate() error {
+	var validators []validator.Validator
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of Shipping and returns all failures
+func (x Shipping) ValidateAll() []error {
+	var errs []error
+
+	return errs
+}
+
+// ExampleShipping returns a sample Shipping whose enum fields hold valid values
+func ExampleShipping() Shipping {
+	return Shipping{}
+}
+
+// StatusEnum represents Current order status
+type StatusEnum string
+
+const (
+	StatusEnumPending   StatusEnum = "pending"
+	StatusEnumShipped   StatusEnum = "shipped"
+	StatusEnumDelivered StatusEnum = "delivered"
+)
+
+// AllStatusEnum lists every StatusEnum value in schema declaration order
+var AllStatusEnum = []StatusEnum{StatusEnumPending, StatusEnumShipped, StatusEnumDelivered}
+
+// Validate checks if the StatusEnum value is valid
+func (e StatusEnum) Validate() error {
+	switch e {
+	case StatusEnumPending, StatusEnumShipped, StatusEnumDelivered:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for StatusEnum, must be one of: pending, shipped, delivered", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseStatusEnum returns s as a StatusEnum, or the Validate() error when it is not a valid value
+func ParseStatusEnum(s string) (StatusEnum, error) {
+	e := StatusEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseStatusEnum is like ParseStatusEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseStatusEnum(s string) StatusEnum {
+	e, err := ParseStatusEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the StatusEnum
+func (e StatusEnum) String() string {
+	return string(e)
+}
+
+// StatusEnumValues returns a copy of AllStatusEnum that callers may modify
+func StatusEnumValues() []StatusEnum {
+	return append([]StatusEnum(nil), AllStatusEnum...)
+}
+
+// MarshalJSON encodes the StatusEnum value as its underlying string
+func (e StatusEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a StatusEnum value and rejects values that are not valid StatusEnum constants
+func (e *StatusEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode StatusEnum: %w", err)
+	}
+
+	decoded := StatusEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
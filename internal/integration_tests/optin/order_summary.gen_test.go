@@ -0,0 +1,61 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// sampleOrderSummaryInput returns the OrderSummaryInput round-tripped by TestOrderSummaryInputRoundTrip
+func sampleOrderSummaryInput() OrderSummaryInput {
+	return OrderSummaryInput{}
+}
+
+func TestOrderSummaryInputRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleOrderSummaryInput())
+	if err != nil {
+		t.Fatalf("failed to marshal OrderSummaryInput: %v", err)
+	}
+
+	var decoded OrderSummaryInput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal OrderSummaryInput: %v", err)
+	}
+}
+
+// sampleOrderSummaryOutput returns the OrderSummaryOutput round-tripped by TestOrderSummaryOutputRoundTrip
+func sampleOrderSummaryOutput() OrderSummaryOutput {
+	return OrderSummaryOutput{
+		Shipping: sampleShipping(),
+	}
+}
+
+func TestOrderSummaryOutputRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleOrderSummaryOutput())
+	if err != nil {
+		t.Fatalf("failed to marshal OrderSummaryOutput: %v", err)
+	}
+
+	var decoded OrderSummaryOutput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal OrderSummaryOutput: %v", err)
+	}
+}
+
+// sampleShipping returns the Shipping round-tripped by TestShippingRoundTrip
+func sampleShipping() Shipping {
+	return Shipping{}
+}
+
+func TestShippingRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleShipping())
+	if err != nil {
+		t.Fatalf("failed to marshal Shipping: %v", err)
+	}
+
+	var decoded Shipping
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal Shipping: %v", err)
+	}
+}
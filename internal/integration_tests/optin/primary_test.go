@@ -0,0 +1,19 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStringReturnsPrimaryField tests that String() returns the x-codegen-primary field value
+func TestStringReturnsPrimaryField(t *testing.T) {
+	input := OrderSummaryInput{OrderID: "A-1", Items: []string{"book"}}
+	assert.Equal(t, "A-1", input.String())
+
+	status := StatusEnumDelivered
+	output := OrderSummaryOutput{Summary: "Delivered", Status: &status}
+	assert.Equal(t, "delivered", output.String())
+
+	assert.Empty(t, OrderSummaryOutput{}.String(), "A nil primary pointer should yield an empty string")
+}
@@ -0,0 +1,17 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRenderExecutesPromptTemplate tests that Render fills the prompt template from the input fields
+func TestRenderExecutesPromptTemplate(t *testing.T) {
+	input := OrderSummaryInput{OrderID: "A-17", Items: []string{"lamp", "desk"}}
+
+	rendered, err := input.Render()
+	require.NoError(t, err)
+	assert.Equal(t, "Summarize order A-17 with items lamp desk .", rendered)
+}
@@ -0,0 +1,35 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResetZeroesAllFields tests that Reset clears values, pointers, slices and nested structs
+func TestResetZeroesAllFields(t *testing.T) {
+	total := 42.5
+	status := StatusEnumShipped
+	trackingNumber := "1Z999"
+
+	output := &OrderSummaryOutput{
+		Summary: "Two items shipped",
+		Total:   &total,
+		Status:  &status,
+		Tags:    []string{"express"},
+		Shipping: Shipping{
+			Carrier:        "ups",
+			TrackingNumber: &trackingNumber,
+		},
+	}
+
+	output.Reset()
+
+	assert.Zero(t, *output, "All fields should be zero after Reset")
+	assert.Nil(t, output.Total)
+	assert.Nil(t, output.Tags)
+
+	input := &OrderSummaryInput{OrderID: "A-1", Items: []string{"book"}}
+	input.Reset()
+	assert.Zero(t, *input)
+}
@@ -0,0 +1,233 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: shape_classification.prompt
+// Input hash: 00062bb0fe356345fa59593acdc53f60
+
+package optin
+
+import "encoding/json"
+import "fmt"
+import "github.com/oter/dotprompt-gen-go/pkg/validator"
+
+// ShapeClassificationOutput represents the output for shape classification
+type ShapeClassificationOutput struct {
+	// Human readable label
+	Label string `json:"label"`
+	// detected shape
+	Shape Shape `json:"shape"`
+}
+
+// ShapeClassificationModel is the model named in the frontmatter of the ShapeClassification prompt
+const ShapeClassificationModel = "openai/gpt-4"
+
+// UnmarshalJSON decodes ShapeClassificationOutput, choosing the variant of each union field from its discriminator
+func (x *ShapeClassificationOutput) UnmarshalJSON(data []byte) error {
+	type plain ShapeClassificationOutput
+
+	var raw struct {
+		*plain
+		Shape json.RawMessage `json:"shape"`
+	}
+
+	raw.plain = (*plain)(x)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to decode ShapeClassificationOutput: %w", err)
+	}
+
+	if len(raw.Shape) > 0 && string(raw.Shape) != "null" {
+		value, err := UnmarshalShape(raw.Shape)
+		if err != nil {
+			return err
+		}
+
+		x.Shape = value
+	}
+
+	return nil
+}
+
+// Reset zeroes all fields of ShapeClassificationOutput so the instance can be reused, e.g. from a sync.Pool
+func (x *ShapeClassificationOutput) Reset() {
+	*x = ShapeClassificationOutput{}
+}
+
+// IsZero reports whether every field of ShapeClassificationOutput holds its zero value; empty slices and maps count as
+// zero
+func (x ShapeClassificationOutput) IsZero() bool {
+	return x.Label == "" &&
+		x.Shape == nil
+}
+
+// Validate checks the enum and nested struct fields of ShapeClassificationOutput and joins their errors
+func (x ShapeClassificationOutput) Validate() error {
+	var validators []validator.Validator
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of ShapeClassificationOutput and returns all failures
+func (x ShapeClassificationOutput) ValidateAll() []error {
+	var errs []error
+
+	return errs
+}
+
+// ExampleShapeClassificationOutput returns a sample ShapeClassificationOutput whose enum fields hold valid values
+func ExampleShapeClassificationOutput() ShapeClassificationOutput {
+	return ShapeClassificationOutput{}
+}
+
+// Polygon represents Polygon
+type Polygon struct {
+	Kind  string `json:"kind"`
+	Sides int    `json:"sides"`
+}
+
+// Reset zeroes all fields of Polygon so the instance can be reused, e.g. from a sync.Pool
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+}
+
+// IsZero reports whether every field of Polygon holds its zero value; empty slices and maps count as zero
+func (x Polygon) IsZero() bool {
+	return x.Kind == "" &&
+		x.Sides == 0
+}
+
+// Validate checks the enum and nested struct fields of Polygon and joins their errors
+func (x Polygon) Validate() error {
+	var validators []validator.Validator
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of Polygon and returns all failures
+func (x Polygon) ValidateAll() []error {
+	var errs []error
+
+	return errs
+}
+
+// ExamplePolygon returns a sample Polygon whose enum fields hold valid values
+func ExamplePolygon() Polygon {
+	return Polygon{}
+}
+
+// ShapeCircle represents a round shape
+type ShapeCircle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+// Reset zeroes all fields of ShapeCircle so the instance can be reused, e.g. from a sync.Pool
+func (x *ShapeCircle) Reset() {
+	*x = ShapeCircle{}
+}
+
+// IsZero reports whether every field of ShapeCircle holds its zero value; empty slices and maps count as zero
+func (x ShapeCircle) IsZero() bool {
+	return x.Kind == "" &&
+		x.Radius == 0
+}
+
+// Validate checks the enum and nested struct fields of ShapeCircle and joins their errors
+func (x ShapeCircle) Validate() error {
+	var validators []validator.Validator
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of ShapeCircle and returns all failures
+func (x ShapeCircle) ValidateAll() []error {
+	var errs []error
+
+	return errs
+}
+
+// ExampleShapeCircle returns a sample ShapeCircle whose enum fields hold valid values
+func ExampleShapeCircle() ShapeCircle {
+	return ShapeCircle{}
+}
+
+// ShapeSquare represents the square variant of Shape
+type ShapeSquare struct {
+	Kind string  `json:"kind"`
+	Side float64 `json:"side"`
+}
+
+// Reset zeroes all fields of ShapeSquare so the instance can be reused, e.g. from a sync.Pool
+func (x *ShapeSquare) Reset() {
+	*x = ShapeSquare{}
+}
+
+// IsZero reports whether every field of ShapeSquare holds its zero value; empty slices and maps count as zero
+func (x ShapeSquare) IsZero() bool {
+	return x.Kind == "" &&
+		x.Side == 0
+}
+
+// Validate checks the enum and nested struct fields of ShapeSquare and joins their errors
+func (x ShapeSquare) Validate() error {
+	var validators []validator.Validator
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of ShapeSquare and returns all failures
+func (x ShapeSquare) ValidateAll() []error {
+	var errs []error
+
+	return errs
+}
+
+// ExampleShapeSquare returns a sample ShapeSquare whose enum fields hold valid values
+func ExampleShapeSquare() ShapeSquare {
+	return ShapeSquare{}
+}
+
+// Shape is detected shape, one of the variants selected by the "kind" property
+type Shape interface {
+	isShape()
+}
+
+func (ShapeCircle) isShape() {}
+
+func (ShapeSquare) isShape() {}
+
+func (Polygon) isShape() {}
+
+// UnmarshalShape decodes a Shape, choosing the variant from its "kind" property
+func UnmarshalShape(data []byte) (Shape, error) {
+	var probe struct {
+		Discriminator string `json:"kind"`
+	}
+
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to decode Shape: %w", err)
+	}
+
+	switch probe.Discriminator {
+	case "circle":
+		var variant ShapeCircle
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return nil, fmt.Errorf("failed to decode ShapeCircle: %w", err)
+		}
+
+		return variant, nil
+	case "square":
+		var variant ShapeSquare
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return nil, fmt.Errorf("failed to decode ShapeSquare: %w", err)
+		}
+
+		return variant, nil
+	case "polygon":
+		var variant Polygon
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return nil, fmt.Errorf("failed to decode Polygon: %w", err)
+		}
+
+		return variant, nil
+	default:
+		return nil, fmt.Errorf("unknown Shape kind %q", probe.Discriminator)
+	}
+}
@@ -0,0 +1,76 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// sampleShapeClassificationOutput returns the ShapeClassificationOutput round-tripped by TestShapeClassificationOutputRoundTrip
+func sampleShapeClassificationOutput() ShapeClassificationOutput {
+	return ShapeClassificationOutput{}
+}
+
+func TestShapeClassificationOutputRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleShapeClassificationOutput())
+	if err != nil {
+		t.Fatalf("failed to marshal ShapeClassificationOutput: %v", err)
+	}
+
+	var decoded ShapeClassificationOutput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal ShapeClassificationOutput: %v", err)
+	}
+}
+
+// samplePolygon returns the Polygon round-tripped by TestPolygonRoundTrip
+func samplePolygon() Polygon {
+	return Polygon{}
+}
+
+func TestPolygonRoundTrip(t *testing.T) {
+	data, err := json.Marshal(samplePolygon())
+	if err != nil {
+		t.Fatalf("failed to marshal Polygon: %v", err)
+	}
+
+	var decoded Polygon
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal Polygon: %v", err)
+	}
+}
+
+// sampleShapeCircle returns the ShapeCircle round-tripped by TestShapeCircleRoundTrip
+func sampleShapeCircle() ShapeCircle {
+	return ShapeCircle{}
+}
+
+func TestShapeCircleRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleShapeCircle())
+	if err != nil {
+		t.Fatalf("failed to marshal ShapeCircle: %v", err)
+	}
+
+	var decoded ShapeCircle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal ShapeCircle: %v", err)
+	}
+}
+
+// sampleShapeSquare returns the ShapeSquare round-tripped by TestShapeSquareRoundTrip
+func sampleShapeSquare() ShapeSquare {
+	return ShapeSquare{}
+}
+
+func TestShapeSquareRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleShapeSquare())
+	if err != nil {
+		t.Fatalf("failed to marshal ShapeSquare: %v", err)
+	}
+
+	var decoded ShapeSquare
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal ShapeSquare: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStrictEnumsRejectInvalidValues tests that decoding an unknown enum value fails
+func TestStrictEnumsRejectInvalidValues(t *testing.T) {
+	var review TicketReviewOutput
+
+	err := json.Unmarshal([]byte(`{"severity":"urgent","team":"billing"}`), &review)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid enum value "urgent" for SeverityEnum`)
+
+	err = json.Unmarshal([]byte(`{"severity":"high","team":"billing","labels":["bug","typo"]}`), &review)
+	require.Error(t, err, "Enum slice items are validated too")
+	assert.Contains(t, err.Error(), `invalid enum value "typo" for LabelsItemEnum`)
+}
+
+// TestStrictEnumsRoundTrip tests that valid enum values encode and decode unchanged
+func TestStrictEnumsRoundTrip(t *testing.T) {
+	escalation := EscalationEnumManager
+	review := TicketReviewOutput{
+		Severity:   SeverityEnumHigh,
+		Team:       TeamEnumMobile,
+		Escalation: &escalation,
+		Labels:     []LabelsItemEnum{LabelsItemEnumRegression},
+		Assignee:   Assignee{Name: "Ana", Role: RoleEnumLead},
+	}
+
+	data, err := json.Marshal(review)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"severity":"high"`)
+
+	var decoded TicketReviewOutput
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, review, decoded)
+}
+
+// TestStrictEnumsSetHelpers tests that enum slices are validated as sets and can be queried
+func TestStrictEnumsSetHelpers(t *testing.T) {
+	labels := []LabelsItemEnum{LabelsItemEnumBug, LabelsItemEnumRegression}
+
+	require.NoError(t, ValidateLabelsItemEnumSet(labels))
+	assert.True(t, HasLabelsItemEnum(labels, LabelsItemEnumBug))
+	assert.False(t, HasLabelsItemEnum(labels, LabelsItemEnumSecurity))
+
+	err := ValidateLabelsItemEnumSet([]LabelsItemEnum{LabelsItemEnumBug, "typo"})
+	require.ErrorIs(t, err, ErrInvalidEnum)
+	assert.Contains(t, err.Error(), "index 1: ")
+
+	err = ValidateLabelsItemEnumSet([]LabelsItemEnum{LabelsItemEnumBug, LabelsItemEnumRegression, LabelsItemEnumBug})
+	require.EqualError(t, err, `duplicate LabelsItemEnum value "bug" at index 2`)
+}
@@ -0,0 +1,41 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStructValidateRecursesIntoFields tests that Validate aggregates enum errors from nested fields
+func TestStructValidateRecursesIntoFields(t *testing.T) {
+	review := TicketReviewOutput{
+		Severity: SeverityEnumLow,
+		Team:     TeamEnumBilling,
+		Labels:   []LabelsItemEnum{LabelsItemEnumBug, "typo"},
+		Assignee: Assignee{Name: "Ana", Role: "intern"},
+	}
+
+	err := review.Validate()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `"typo" for LabelsItemEnum`)
+	assert.ErrorContains(t, err, `"intern" for RoleEnum`)
+
+	valid := TicketReviewOutput{Severity: SeverityEnumHigh, Team: TeamEnumPlatform, Assignee: ExampleAssignee()}
+	assert.NoError(t, valid.Validate())
+}
+
+// TestStructValidateRecursesIntoChildren tests that Validate checks the nodes of recursive []*T fields
+func TestStructValidateRecursesIntoChildren(t *testing.T) {
+	tree := CategoryNode{
+		Name:     "root",
+		Kind:     KindEnumFolder,
+		Children: []*CategoryNode{nil, {Name: "draft", Kind: "archived"}},
+	}
+
+	require.Error(t, tree.Validate())
+	assert.ErrorContains(t, tree.Validate(), `"archived" for KindEnum`)
+
+	tree.Children[1].Kind = KindEnumLeaf
+	assert.NoError(t, tree.Validate())
+}
@@ -0,0 +1,548 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: ticket_review.prompt
+// Input hash: 185d19cb7ac27b65a76f816fb56d53c5
+
+package optin
+
+import "encoding/json"
+import "fmt"
+import "slices"
+import "github.com/oter/dotprompt-gen-go/pkg/validator"
+
+// TicketReviewOutput represents the output for ticket review
+type TicketReviewOutput struct {
+	// Ticket severity
+	Severity SeverityEnum `json:"severity"`
+	// Owning team
+	Team TeamEnum `json:"team"`
+	// Escalation level
+	Escalation *EscalationEnum `json:"escalation,omitempty"`
+	// Ticket labels
+	Labels []LabelsItemEnum `json:"labels,omitempty"`
+	// assigned engineer
+	Assignee Assignee `json:"assignee"`
+}
+
+// TicketReviewModel is the model named in the frontmatter of the TicketReview prompt
+const TicketReviewModel = "openai/gpt-4"
+
+// TicketReviewConfig is the model config in the frontmatter of the TicketReview prompt
+var TicketReviewConfig = map[string]any{
+	"maxOutputTokens": 512,
+	"safety":          map[string]any{"level": "strict"},
+	"stopSequences":   []any{"END"},
+	"temperature":     0.2,
+}
+
+// Reset zeroes all fields of TicketReviewOutput so the instance can be reused, e.g. from a sync.Pool
+func (x *TicketReviewOutput) Reset() {
+	*x = TicketReviewOutput{}
+}
+
+// IsZero reports whether every field of TicketReviewOutput holds its zero value; empty slices and maps count as zero
+func (x TicketReviewOutput) IsZero() bool {
+	return x.Severity == "" &&
+		x.Team == "" &&
+		x.Escalation == nil &&
+		len(x.Labels) == 0 &&
+		x.Assignee.IsZero()
+}
+
+// Validate checks the enum and nested struct fields of TicketReviewOutput and joins their errors
+func (x TicketReviewOutput) Validate() error {
+	var validators []validator.Validator
+
+	validators = append(validators, x.Severity)
+
+	validators = append(validators, x.Team)
+
+	if x.Escalation != nil {
+		validators = append(validators, *x.Escalation)
+	}
+
+	for _, v := range x.Labels {
+		validators = append(validators, v)
+	}
+
+	validators = append(validators, x.Assignee)
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of TicketReviewOutput and returns all failures
+func (x TicketReviewOutput) ValidateAll() []error {
+	var errs []error
+
+	if err := x.Severity.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("severity: %w", err))
+	}
+
+	if err := x.Team.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("team: %w", err))
+	}
+
+	if x.Escalation != nil {
+		if err := x.Escalation.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("escalation: %w", err))
+		}
+	}
+
+	for i, v := range x.Labels {
+		if err := v.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("labels[%d]: %w", i, err))
+		}
+	}
+
+	for _, err := range x.Assignee.ValidateAll() {
+		errs = append(errs, fmt.Errorf("assignee.%w", err))
+	}
+
+	return errs
+}
+
+// ExampleTicketReviewOutput returns a sample TicketReviewOutput whose enum fields hold valid values
+func ExampleTicketReviewOutput() TicketReviewOutput {
+	return TicketReviewOutput{
+		Severity:   SeverityEnumLow,
+		Team:       TeamEnumBilling,
+		Escalation: func() *EscalationEnum { v := EscalationEnumNone; return &v }(),
+		Assignee:   ExampleAssignee(),
+	}
+}
+
+// Assignee represents assigned engineer
+type Assignee struct {
+	Name string   `json:"name"`
+	Role RoleEnum `json:"role"`
+}
+
+// Reset zeroes all fields of Assignee so the instance can be reused, e.g. from a sync.Pool
+func (x *Assignee) Reset() {
+	*x = Assignee{}
+}
+
+// IsZero reports whether every field of Assignee holds its zero value; empty slices and maps count as zero
+func (x Assignee) IsZero() bool {
+	return x.Name == "" &&
+		x.Role == ""
+}
+
+// Validate checks the enum and nested struct fields of Assignee and joins their errors
+func (x Assignee) Validate() error {
+	var validators []validator.Validator
+
+	validators = append(validators, x.Role)
+
+	return validator.ValidateAll(validators...)
+}
+
+// ValidateAll validates every enum and nested struct field of Assignee and returns all failures
+func (x Assignee) ValidateAll() []error {
+	var errs []error
+
+	if err := x.Role.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("role: %w", err))
+	}
+
+	return errs
+}
+
+// ExampleAssignee returns a sample Assignee whose enum fields hold valid values
+func ExampleAssignee() Assignee {
+	return Assignee{
+		Role: RoleEnumEngineer,
+	}
+}
+
+// SeverityEnum represents Ticket severity
+type SeverityEnum string
+
+const (
+	SeverityEnumLow    SeverityEnum = "low"
+	SeverityEnumMedium SeverityEnum = "medium"
+	SeverityEnumHigh   SeverityEnum = "high"
+)
+
+// AllSeverityEnum lists every SeverityEnum value in schema declaration order
+var AllSeverityEnum = []SeverityEnum{SeverityEnumLow, SeverityEnumMedium, SeverityEnumHigh}
+
+// Validate checks if the SeverityEnum value is valid
+func (e SeverityEnum) Validate() error {
+	switch e {
+	case SeverityEnumLow, SeverityEnumMedium, SeverityEnumHigh:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for SeverityEnum, must be one of: low, medium, high", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseSeverityEnum returns s as a SeverityEnum, or the Validate() error when it is not a valid value
+func ParseSeverityEnum(s string) (SeverityEnum, error) {
+	e := SeverityEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseSeverityEnum is like ParseSeverityEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseSeverityEnum(s string) SeverityEnum {
+	e, err := ParseSeverityEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the SeverityEnum
+func (e SeverityEnum) String() string {
+	return string(e)
+}
+
+// SeverityEnumValues returns a copy of AllSeverityEnum that callers may modify
+func SeverityEnumValues() []SeverityEnum {
+	return append([]SeverityEnum(nil), AllSeverityEnum...)
+}
+
+// MarshalJSON encodes the SeverityEnum value as its underlying string
+func (e SeverityEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a SeverityEnum value and rejects values that are not valid SeverityEnum constants
+func (e *SeverityEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode SeverityEnum: %w", err)
+	}
+
+	decoded := SeverityEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
+
+// TeamEnum represents Owning team
+type TeamEnum string
+
+const (
+	TeamEnumBilling  TeamEnum = "billing"
+	TeamEnumPlatform TeamEnum = "platform"
+	TeamEnumMobile   TeamEnum = "mobile"
+)
+
+// AllTeamEnum lists every TeamEnum value in schema declaration order
+var AllTeamEnum = []TeamEnum{TeamEnumBilling, TeamEnumPlatform, TeamEnumMobile}
+
+// Validate checks if the TeamEnum value is valid
+func (e TeamEnum) Validate() error {
+	switch e {
+	case TeamEnumBilling, TeamEnumPlatform, TeamEnumMobile:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for TeamEnum, must be one of: billing, platform, mobile", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseTeamEnum returns s as a TeamEnum, or the Validate() error when it is not a valid value
+func ParseTeamEnum(s string) (TeamEnum, error) {
+	e := TeamEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseTeamEnum is like ParseTeamEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseTeamEnum(s string) TeamEnum {
+	e, err := ParseTeamEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the TeamEnum
+func (e TeamEnum) String() string {
+	return string(e)
+}
+
+// TeamEnumValues returns a copy of AllTeamEnum that callers may modify
+func TeamEnumValues() []TeamEnum {
+	return append([]TeamEnum(nil), AllTeamEnum...)
+}
+
+// MarshalJSON encodes the TeamEnum value as its underlying string
+func (e TeamEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a TeamEnum value and rejects values that are not valid TeamEnum constants
+func (e *TeamEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode TeamEnum: %w", err)
+	}
+
+	decoded := TeamEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
+
+// EscalationEnum represents Escalation level
+type EscalationEnum string
+
+const (
+	EscalationEnumNone     EscalationEnum = "none"
+	EscalationEnumManager  EscalationEnum = "manager"
+	EscalationEnumDirector EscalationEnum = "director"
+)
+
+// AllEscalationEnum lists every EscalationEnum value in schema declaration order
+var AllEscalationEnum = []EscalationEnum{EscalationEnumNone, EscalationEnumManager, EscalationEnumDirector}
+
+// Validate checks if the EscalationEnum value is valid
+func (e EscalationEnum) Validate() error {
+	switch e {
+	case EscalationEnumNone, EscalationEnumManager, EscalationEnumDirector:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for EscalationEnum, must be one of: none, manager, director", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseEscalationEnum returns s as a EscalationEnum, or the Validate() error when it is not a valid value
+func ParseEscalationEnum(s string) (EscalationEnum, error) {
+	e := EscalationEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseEscalationEnum is like ParseEscalationEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseEscalationEnum(s string) EscalationEnum {
+	e, err := ParseEscalationEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the EscalationEnum
+func (e EscalationEnum) String() string {
+	return string(e)
+}
+
+// EscalationEnumValues returns a copy of AllEscalationEnum that callers may modify
+func EscalationEnumValues() []EscalationEnum {
+	return append([]EscalationEnum(nil), AllEscalationEnum...)
+}
+
+// MarshalJSON encodes the EscalationEnum value as its underlying string
+func (e EscalationEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a EscalationEnum value and rejects values that are not valid EscalationEnum constants
+func (e *EscalationEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode EscalationEnum: %w", err)
+	}
+
+	decoded := EscalationEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
+
+// LabelsItemEnum represents valid labels item values
+type LabelsItemEnum string
+
+const (
+	LabelsItemEnumBug        LabelsItemEnum = "bug"
+	LabelsItemEnumRegression LabelsItemEnum = "regression"
+	LabelsItemEnumSecurity   LabelsItemEnum = "security"
+)
+
+// AllLabelsItemEnum lists every LabelsItemEnum value in schema declaration order
+var AllLabelsItemEnum = []LabelsItemEnum{LabelsItemEnumBug, LabelsItemEnumRegression, LabelsItemEnumSecurity}
+
+// Validate checks if the LabelsItemEnum value is valid
+func (e LabelsItemEnum) Validate() error {
+	switch e {
+	case LabelsItemEnumBug, LabelsItemEnumRegression, LabelsItemEnumSecurity:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for LabelsItemEnum, must be one of: bug, regression, security", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseLabelsItemEnum returns s as a LabelsItemEnum, or the Validate() error when it is not a valid value
+func ParseLabelsItemEnum(s string) (LabelsItemEnum, error) {
+	e := LabelsItemEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseLabelsItemEnum is like ParseLabelsItemEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseLabelsItemEnum(s string) LabelsItemEnum {
+	e, err := ParseLabelsItemEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the LabelsItemEnum
+func (e LabelsItemEnum) String() string {
+	return string(e)
+}
+
+// LabelsItemEnumValues returns a copy of AllLabelsItemEnum that callers may modify
+func LabelsItemEnumValues() []LabelsItemEnum {
+	return append([]LabelsItemEnum(nil), AllLabelsItemEnum...)
+}
+
+// MarshalJSON encodes the LabelsItemEnum value as its underlying string
+func (e LabelsItemEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a LabelsItemEnum value and rejects values that are not valid LabelsItemEnum constants
+func (e *LabelsItemEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode LabelsItemEnum: %w", err)
+	}
+
+	decoded := LabelsItemEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
+
+// ValidateLabelsItemEnumSet checks that s holds valid LabelsItemEnum values, each at most once
+func ValidateLabelsItemEnumSet(s []LabelsItemEnum) error {
+	seen := make(map[LabelsItemEnum]bool, len(s))
+
+	for i, e := range s {
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+
+		if seen[e] {
+			return fmt.Errorf("duplicate LabelsItemEnum value %q at index %d", string(e), i)
+		}
+
+		seen[e] = true
+	}
+
+	return nil
+}
+
+// HasLabelsItemEnum reports whether s contains e
+func HasLabelsItemEnum(s []LabelsItemEnum, e LabelsItemEnum) bool {
+	return slices.Contains(s, e)
+}
+
+// RoleEnum represents valid role values
+type RoleEnum string
+
+const (
+	RoleEnumEngineer RoleEnum = "engineer"
+	RoleEnumLead     RoleEnum = "lead"
+)
+
+// AllRoleEnum lists every RoleEnum value in schema declaration order
+var AllRoleEnum = []RoleEnum{RoleEnumEngineer, RoleEnumLead}
+
+// Validate checks if the RoleEnum value is valid
+func (e RoleEnum) Validate() error {
+	switch e {
+	case RoleEnumEngineer, RoleEnumLead:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for RoleEnum, must be one of: engineer, lead", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseRoleEnum returns s as a RoleEnum, or the Validate() error when it is not a valid value
+func ParseRoleEnum(s string) (RoleEnum, error) {
+	e := RoleEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseRoleEnum is like ParseRoleEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseRoleEnum(s string) RoleEnum {
+	e, err := ParseRoleEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the RoleEnum
+func (e RoleEnum) String() string {
+	return string(e)
+}
+
+// RoleEnumValues returns a copy of AllRoleEnum that callers may modify
+func RoleEnumValues() []RoleEnum {
+	return append([]RoleEnum(nil), AllRoleEnum...)
+}
+
+// MarshalJSON encodes the RoleEnum value as its underlying string
+func (e RoleEnum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON decodes a RoleEnum value and rejects values that are not valid RoleEnum constants
+func (e *RoleEnum) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to decode RoleEnum: %w", err)
+	}
+
+	decoded := RoleEnum(value)
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+
+	*e = decoded
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// sampleTicketReviewOutput returns the TicketReviewOutput round-tripped by TestTicketReviewOutputRoundTrip
+func sampleTicketReviewOutput() TicketReviewOutput {
+	return TicketReviewOutput{
+		Severity: SeverityEnumLow,
+		Team:     TeamEnumBilling,
+		Labels:   []LabelsItemEnum{LabelsItemEnumBug},
+		Assignee: sampleAssignee(),
+	}
+}
+
+func TestTicketReviewOutputRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleTicketReviewOutput())
+	if err != nil {
+		t.Fatalf("failed to marshal TicketReviewOutput: %v", err)
+	}
+
+	var decoded TicketReviewOutput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal TicketReviewOutput: %v", err)
+	}
+
+	if err := decoded.Severity.Validate(); err != nil {
+		t.Errorf("invalid Severity: %v", err)
+	}
+
+	if err := decoded.Team.Validate(); err != nil {
+		t.Errorf("invalid Team: %v", err)
+	}
+
+	for _, value := range decoded.Labels {
+		if err := value.Validate(); err != nil {
+			t.Errorf("invalid Labels: %v", err)
+		}
+	}
+}
+
+// sampleAssignee returns the Assignee round-tripped by TestAssigneeRoundTrip
+func sampleAssignee() Assignee {
+	return Assignee{
+		Role: RoleEnumEngineer,
+	}
+}
+
+func TestAssigneeRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sampleAssignee())
+	if err != nil {
+		t.Fatalf("failed to marshal Assignee: %v", err)
+	}
+
+	var decoded Assignee
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal Assignee: %v", err)
+	}
+
+	if err := decoded.Role.Validate(); err != nil {
+		t.Errorf("invalid Role: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package optin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnionDecodesVariantByDiscriminator tests that union fields decode into the variant named by the discriminator
+func TestUnionDecodesVariantByDiscriminator(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Shape
+	}{
+		{
+			name: "inline const variant",
+			data: `{"label": "ball", "shape": {"kind": "circle", "radius": 2.5}}`,
+			want: ShapeCircle{Kind: "circle", Radius: 2.5},
+		},
+		{
+			name: "inline enum variant",
+			data: `{"label": "tile", "shape": {"kind": "square", "side": 3}}`,
+			want: ShapeSquare{Kind: "square", Side: 3},
+		},
+		{
+			name: "referenced variant",
+			data: `{"label": "sign", "shape": {"kind": "polygon", "sides": 8}}`,
+			want: Polygon{Kind: "polygon", Sides: 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output ShapeClassificationOutput
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &output))
+
+			assert.Equal(t, tt.want, output.Shape)
+			assert.NotEmpty(t, output.Label, "Fields next to the union should still be decoded")
+
+			data, err := json.Marshal(output)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.data, string(data), "Marshaling should round-trip the variant")
+		})
+	}
+}
+
+// TestUnionRejectsUnknownDiscriminator tests that an unknown discriminator value fails decoding
+func TestUnionRejectsUnknownDiscriminator(t *testing.T) {
+	var output ShapeClassificationOutput
+
+	err := json.Unmarshal([]byte(`{"label": "blob", "shape": {"kind": "blob"}}`), &output)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown Shape kind "blob"`)
+}
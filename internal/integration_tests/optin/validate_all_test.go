@@ -0,0 +1,45 @@
+package optin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateAllReturnsEveryFieldError tests that ValidateAll reports one error per invalid field
+func TestValidateAllReturnsEveryFieldError(t *testing.T) {
+	escalation := EscalationEnum("ceo")
+
+	review := TicketReviewOutput{
+		Severity:   "urgent",
+		Team:       "sales",
+		Escalation: &escalation,
+		Labels:     []LabelsItemEnum{LabelsItemEnumBug, "typo"},
+		Assignee:   Assignee{Name: "Ana", Role: "intern"},
+	}
+
+	errs := review.ValidateAll()
+	require.Len(t, errs, 5)
+	assert.ErrorContains(t, errs[0], "severity: invalid enum value")
+	assert.ErrorContains(t, errs[1], "team: invalid enum value")
+	assert.ErrorContains(t, errs[2], "escalation: invalid enum value")
+	assert.ErrorContains(t, errs[3], "labels[1]: invalid enum value")
+	assert.ErrorContains(t, errs[4], "assignee.role: invalid enum value")
+
+	valid := TicketReviewOutput{Severity: SeverityEnumHigh, Team: TeamEnumPlatform, Assignee: ExampleAssignee()}
+	assert.Empty(t, valid.ValidateAll())
+}
+
+// TestValidateAllRecursesIntoChildren tests that ValidateAll reports invalid nodes of recursive []*T fields
+func TestValidateAllRecursesIntoChildren(t *testing.T) {
+	tree := CategoryNode{
+		Name:     "root",
+		Kind:     KindEnumFolder,
+		Children: []*CategoryNode{nil, {Name: "draft", Kind: "archived"}},
+	}
+
+	errs := tree.ValidateAll()
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "children[1].kind: invalid enum value")
+}
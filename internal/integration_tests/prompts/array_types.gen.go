@@ -1,4 +1,6 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: array_types.prompt
+// Input hash: 9d22856eb2ad954a4916cd3dd01296c7
 
 package prompts
 
@@ -17,5 +19,5 @@ type ArrayTypesOutput struct {
 	// Processed results
 	Results []string `json:"results"`
 	// Result counts
-	Counts []int `json:"counts"`
+	Counts []int `json:"counts,omitempty"`
 }
@@ -1,4 +1,6 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: classify_habits.prompt
+// Input hash: a6edad6385fd9cf876cec043468d80c8
 
 package prompts
 
@@ -20,7 +22,7 @@ type ClassifyHabitsOutput struct {
 	ImpactLevel ImpactLevelEnum `json:"impact_level"`
 }
 
-// TransformationCategoryEnum represents valid transformation_category values
+// TransformationCategoryEnum represents The transformative category of the habit
 type TransformationCategoryEnum string
 
 const (
@@ -37,17 +39,52 @@ const (
 	TransformationCategoryEnumMindfulPresence        TransformationCategoryEnum = "mindful_presence"
 )
 
+// AllTransformationCategoryEnum lists every TransformationCategoryEnum value in schema declaration order
+var AllTransformationCategoryEnum = []TransformationCategoryEnum{TransformationCategoryEnumPhysicalVitality, TransformationCategoryEnumMentalMastery, TransformationCategoryEnumCreativeExpression, TransformationCategoryEnumSocialConnection, TransformationCategoryEnumFinancialWisdom, TransformationCategoryEnumEnvironmentalHarmony, TransformationCategoryEnumSpiritualGrowth, TransformationCategoryEnumProfessionalExcellence, TransformationCategoryEnumLearningAdventure, TransformationCategoryEnumSelfCareRitual, TransformationCategoryEnumMindfulPresence}
+
 // Validate checks if the TransformationCategoryEnum value is valid
 func (e TransformationCategoryEnum) Validate() error {
 	switch e {
 	case TransformationCategoryEnumPhysicalVitality, TransformationCategoryEnumMentalMastery, TransformationCategoryEnumCreativeExpression, TransformationCategoryEnumSocialConnection, TransformationCategoryEnumFinancialWisdom, TransformationCategoryEnumEnvironmentalHarmony, TransformationCategoryEnumSpiritualGrowth, TransformationCategoryEnumProfessionalExcellence, TransformationCategoryEnumLearningAdventure, TransformationCategoryEnumSelfCareRitual, TransformationCategoryEnumMindfulPresence:
 		return nil
 	default:
-		return fmt.Errorf("invalid TransformationCategoryEnum value: %q, must be one of: physical_vitality, mental_mastery, creative_expression, social_connection, financial_wisdom, environmental_harmony, spiritual_growth, professional_excellence, learning_adventure, self_care_ritual, mindful_presence", string(e))
+		return fmt.Errorf("%w %q for TransformationCategoryEnum, must be one of: physical_vitality, mental_mastery, creative_expression, social_connection, financial_wisdom, environmental_harmony, spiritual_growth, professional_excellence, learning_adventure, self_care_ritual, mindful_presence", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseTransformationCategoryEnum returns s as a TransformationCategoryEnum, or the Validate() error when it is not a
+// valid value
+func ParseTransformationCategoryEnum(s string) (TransformationCategoryEnum, error) {
+	e := TransformationCategoryEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseTransformationCategoryEnum is like ParseTransformationCategoryEnum but panics when s is not a valid value,
+// e.g. in test fixtures
+func MustParseTransformationCategoryEnum(s string) TransformationCategoryEnum {
+	e, err := ParseTransformationCategoryEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the TransformationCategoryEnum
+func (e TransformationCategoryEnum) String() string {
+	return string(e)
+}
+
+// TransformationCategoryEnumValues returns a copy of AllTransformationCategoryEnum that callers may modify
+func TransformationCategoryEnumValues() []TransformationCategoryEnum {
+	return append([]TransformationCategoryEnum(nil), AllTransformationCategoryEnum...)
 }
 
-// ImpactLevelEnum represents valid impact_level values
+// ImpactLevelEnum represents The developmental stage this habit represents
 type ImpactLevelEnum string
 
 const (
@@ -56,12 +93,45 @@ const (
 	ImpactLevelEnumMastery      ImpactLevelEnum = "mastery"
 )
 
+// AllImpactLevelEnum lists every ImpactLevelEnum value in schema declaration order
+var AllImpactLevelEnum = []ImpactLevelEnum{ImpactLevelEnumFoundational, ImpactLevelEnumGrowth, ImpactLevelEnumMastery}
+
 // Validate checks if the ImpactLevelEnum value is valid
 func (e ImpactLevelEnum) Validate() error {
 	switch e {
 	case ImpactLevelEnumFoundational, ImpactLevelEnumGrowth, ImpactLevelEnumMastery:
 		return nil
 	default:
-		return fmt.Errorf("invalid ImpactLevelEnum value: %q, must be one of: foundational, growth, mastery", string(e))
+		return fmt.Errorf("%w %q for ImpactLevelEnum, must be one of: foundational, growth, mastery", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseImpactLevelEnum returns s as a ImpactLevelEnum, or the Validate() error when it is not a valid value
+func ParseImpactLevelEnum(s string) (ImpactLevelEnum, error) {
+	e := ImpactLevelEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseImpactLevelEnum is like ParseImpactLevelEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseImpactLevelEnum(s string) ImpactLevelEnum {
+	e, err := ParseImpactLevelEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the ImpactLevelEnum
+func (e ImpactLevelEnum) String() string {
+	return string(e)
+}
+
+// ImpactLevelEnumValues returns a copy of AllImpactLevelEnum that callers may modify
+func ImpactLevelEnumValues() []ImpactLevelEnum {
+	return append([]ImpactLevelEnum(nil), AllImpactLevelEnum...)
 }
@@ -1,4 +1,6 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: comprehensive_arrays.prompt
+// Input hash: 0d769b46f61911b08f804658efa59e55
 
 package prompts
 
@@ -26,9 +28,9 @@ type ComprehensiveArraysInput struct {
 
 // UserListItem represents item in user_list array
 type UserListItem struct {
-	Active bool   `json:"active"`
-	Age    int    `json:"age"`
 	Name   string `json:"name"`
+	Age    int    `json:"age"`
+	Active bool   `json:"active"`
 }
 
 // ComprehensiveArraysOutput represents the output for comprehensive arrays
@@ -36,31 +38,31 @@ type ComprehensiveArraysOutput struct {
 	// Processed string results
 	ProcessedStrings []string `json:"processed_strings"`
 	// Calculated numeric results
-	CalculatedNumbers []float64 `json:"calculated_numbers"`
+	CalculatedNumbers []float64 `json:"calculated_numbers,omitempty"`
 	// Status boolean flags
 	StatusFlags []bool `json:"status_flags"`
 	// Selected category enums
-	SelectedCategories []SelectedCategoriesItemEnum `json:"selected_categories"`
+	SelectedCategories []SelectedCategoriesItemEnum `json:"selected_categories,omitempty"`
 	// Processed user objects
 	ProcessedUsers []ProcessedUsersItem `json:"processed_users"`
 	// Enum array in object
 	EnumArrayInObject EnumArrayInObject `json:"enum_array_in_object"`
 	// Summary integer counts
-	SummaryCounts []int `json:"summary_counts"`
+	SummaryCounts []int `json:"summary_counts,omitempty"`
 }
 
 // ProcessedUsersItem represents item in processed_users array
 type ProcessedUsersItem struct {
-	Id         *string         `json:"id"`
-	UserStatus *UserStatusEnum `json:"user_status"`
+	ID         *string         `json:"id,omitempty"`
+	UserStatus *UserStatusEnum `json:"user_status,omitempty"`
 }
 
 // EnumArrayInObject represents Enum array in object
 type EnumArrayInObject struct {
 	// Enum array in object
-	EnumArray []EnumArrayItemEnum `json:"enum_array"`
+	EnumArray []EnumArrayItemEnum `json:"enum_array,omitempty"`
 	// String field
-	StringField *string `json:"string_field"`
+	StringField *string `json:"string_field,omitempty"`
 }
 
 // CategoryListItemEnum represents valid category_list item values
@@ -73,14 +75,48 @@ const (
 	CategoryListItemEnumEducation CategoryListItemEnum = "education"
 )
 
+// AllCategoryListItemEnum lists every CategoryListItemEnum value in schema declaration order
+var AllCategoryListItemEnum = []CategoryListItemEnum{CategoryListItemEnumTech, CategoryListItemEnumFinance, CategoryListItemEnumHealth, CategoryListItemEnumEducation}
+
 // Validate checks if the CategoryListItemEnum value is valid
 func (e CategoryListItemEnum) Validate() error {
 	switch e {
 	case CategoryListItemEnumTech, CategoryListItemEnumFinance, CategoryListItemEnumHealth, CategoryListItemEnumEducation:
 		return nil
 	default:
-		return fmt.Errorf("invalid CategoryListItemEnum value: %q, must be one of: tech, finance, health, education", string(e))
+		return fmt.Errorf("%w %q for CategoryListItemEnum, must be one of: tech, finance, health, education", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseCategoryListItemEnum returns s as a CategoryListItemEnum, or the Validate() error when it is not a valid value
+func ParseCategoryListItemEnum(s string) (CategoryListItemEnum, error) {
+	e := CategoryListItemEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseCategoryListItemEnum is like ParseCategoryListItemEnum but panics when s is not a valid value, e.g. in test
+// fixtures
+func MustParseCategoryListItemEnum(s string) CategoryListItemEnum {
+	e, err := ParseCategoryListItemEnum(s)
+	if err != nil {
+		panic(err)
 	}
+
+	return e
+}
+
+// String returns the underlying string value of the CategoryListItemEnum
+func (e CategoryListItemEnum) String() string {
+	return string(e)
+}
+
+// CategoryListItemEnumValues returns a copy of AllCategoryListItemEnum that callers may modify
+func CategoryListItemEnumValues() []CategoryListItemEnum {
+	return append([]CategoryListItemEnum(nil), AllCategoryListItemEnum...)
 }
 
 // PriorityListItemEnum represents valid priority_list item values
@@ -93,16 +129,50 @@ const (
 	PriorityListItemEnumUrgent PriorityListItemEnum = "urgent"
 )
 
+// AllPriorityListItemEnum lists every PriorityListItemEnum value in schema declaration order
+var AllPriorityListItemEnum = []PriorityListItemEnum{PriorityListItemEnumLow, PriorityListItemEnumMedium, PriorityListItemEnumHigh, PriorityListItemEnumUrgent}
+
 // Validate checks if the PriorityListItemEnum value is valid
 func (e PriorityListItemEnum) Validate() error {
 	switch e {
 	case PriorityListItemEnumLow, PriorityListItemEnumMedium, PriorityListItemEnumHigh, PriorityListItemEnumUrgent:
 		return nil
 	default:
-		return fmt.Errorf("invalid PriorityListItemEnum value: %q, must be one of: low, medium, high, urgent", string(e))
+		return fmt.Errorf("%w %q for PriorityListItemEnum, must be one of: low, medium, high, urgent", ErrInvalidEnum, string(e))
 	}
 }
 
+// ParsePriorityListItemEnum returns s as a PriorityListItemEnum, or the Validate() error when it is not a valid value
+func ParsePriorityListItemEnum(s string) (PriorityListItemEnum, error) {
+	e := PriorityListItemEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParsePriorityListItemEnum is like ParsePriorityListItemEnum but panics when s is not a valid value, e.g. in test
+// fixtures
+func MustParsePriorityListItemEnum(s string) PriorityListItemEnum {
+	e, err := ParsePriorityListItemEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the PriorityListItemEnum
+func (e PriorityListItemEnum) String() string {
+	return string(e)
+}
+
+// PriorityListItemEnumValues returns a copy of AllPriorityListItemEnum that callers may modify
+func PriorityListItemEnumValues() []PriorityListItemEnum {
+	return append([]PriorityListItemEnum(nil), AllPriorityListItemEnum...)
+}
+
 // SelectedCategoriesItemEnum represents valid selected_categories item values
 type SelectedCategoriesItemEnum string
 
@@ -113,16 +183,51 @@ const (
 	SelectedCategoriesItemEnumEducation SelectedCategoriesItemEnum = "education"
 )
 
+// AllSelectedCategoriesItemEnum lists every SelectedCategoriesItemEnum value in schema declaration order
+var AllSelectedCategoriesItemEnum = []SelectedCategoriesItemEnum{SelectedCategoriesItemEnumTech, SelectedCategoriesItemEnumFinance, SelectedCategoriesItemEnumHealth, SelectedCategoriesItemEnumEducation}
+
 // Validate checks if the SelectedCategoriesItemEnum value is valid
 func (e SelectedCategoriesItemEnum) Validate() error {
 	switch e {
 	case SelectedCategoriesItemEnumTech, SelectedCategoriesItemEnumFinance, SelectedCategoriesItemEnumHealth, SelectedCategoriesItemEnumEducation:
 		return nil
 	default:
-		return fmt.Errorf("invalid SelectedCategoriesItemEnum value: %q, must be one of: tech, finance, health, education", string(e))
+		return fmt.Errorf("%w %q for SelectedCategoriesItemEnum, must be one of: tech, finance, health, education", ErrInvalidEnum, string(e))
 	}
 }
 
+// ParseSelectedCategoriesItemEnum returns s as a SelectedCategoriesItemEnum, or the Validate() error when it is not a
+// valid value
+func ParseSelectedCategoriesItemEnum(s string) (SelectedCategoriesItemEnum, error) {
+	e := SelectedCategoriesItemEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseSelectedCategoriesItemEnum is like ParseSelectedCategoriesItemEnum but panics when s is not a valid value,
+// e.g. in test fixtures
+func MustParseSelectedCategoriesItemEnum(s string) SelectedCategoriesItemEnum {
+	e, err := ParseSelectedCategoriesItemEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the SelectedCategoriesItemEnum
+func (e SelectedCategoriesItemEnum) String() string {
+	return string(e)
+}
+
+// SelectedCategoriesItemEnumValues returns a copy of AllSelectedCategoriesItemEnum that callers may modify
+func SelectedCategoriesItemEnumValues() []SelectedCategoriesItemEnum {
+	return append([]SelectedCategoriesItemEnum(nil), AllSelectedCategoriesItemEnum...)
+}
+
 // UserStatusEnum represents valid user_status values
 type UserStatusEnum string
 
@@ -132,16 +237,49 @@ const (
 	UserStatusEnumSuspended UserStatusEnum = "suspended"
 )
 
+// AllUserStatusEnum lists every UserStatusEnum value in schema declaration order
+var AllUserStatusEnum = []UserStatusEnum{UserStatusEnumActive, UserStatusEnumInactive, UserStatusEnumSuspended}
+
 // Validate checks if the UserStatusEnum value is valid
 func (e UserStatusEnum) Validate() error {
 	switch e {
 	case UserStatusEnumActive, UserStatusEnumInactive, UserStatusEnumSuspended:
 		return nil
 	default:
-		return fmt.Errorf("invalid UserStatusEnum value: %q, must be one of: active, inactive, suspended", string(e))
+		return fmt.Errorf("%w %q for UserStatusEnum, must be one of: active, inactive, suspended", ErrInvalidEnum, string(e))
 	}
 }
 
+// ParseUserStatusEnum returns s as a UserStatusEnum, or the Validate() error when it is not a valid value
+func ParseUserStatusEnum(s string) (UserStatusEnum, error) {
+	e := UserStatusEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseUserStatusEnum is like ParseUserStatusEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseUserStatusEnum(s string) UserStatusEnum {
+	e, err := ParseUserStatusEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the UserStatusEnum
+func (e UserStatusEnum) String() string {
+	return string(e)
+}
+
+// UserStatusEnumValues returns a copy of AllUserStatusEnum that callers may modify
+func UserStatusEnumValues() []UserStatusEnum {
+	return append([]UserStatusEnum(nil), AllUserStatusEnum...)
+}
+
 // EnumArrayItemEnum represents valid enum_array item values
 type EnumArrayItemEnum string
 
@@ -151,12 +289,46 @@ const (
 	EnumArrayItemEnumSuspended EnumArrayItemEnum = "suspended"
 )
 
+// AllEnumArrayItemEnum lists every EnumArrayItemEnum value in schema declaration order
+var AllEnumArrayItemEnum = []EnumArrayItemEnum{EnumArrayItemEnumActive, EnumArrayItemEnumInactive, EnumArrayItemEnumSuspended}
+
 // Validate checks if the EnumArrayItemEnum value is valid
 func (e EnumArrayItemEnum) Validate() error {
 	switch e {
 	case EnumArrayItemEnumActive, EnumArrayItemEnumInactive, EnumArrayItemEnumSuspended:
 		return nil
 	default:
-		return fmt.Errorf("invalid EnumArrayItemEnum value: %q, must be one of: active, inactive, suspended", string(e))
+		return fmt.Errorf("%w %q for EnumArrayItemEnum, must be one of: active, inactive, suspended", ErrInvalidEnum, string(e))
 	}
 }
+
+// ParseEnumArrayItemEnum returns s as a EnumArrayItemEnum, or the Validate() error when it is not a valid value
+func ParseEnumArrayItemEnum(s string) (EnumArrayItemEnum, error) {
+	e := EnumArrayItemEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseEnumArrayItemEnum is like ParseEnumArrayItemEnum but panics when s is not a valid value, e.g. in test
+// fixtures
+func MustParseEnumArrayItemEnum(s string) EnumArrayItemEnum {
+	e, err := ParseEnumArrayItemEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the EnumArrayItemEnum
+func (e EnumArrayItemEnum) String() string {
+	return string(e)
+}
+
+// EnumArrayItemEnumValues returns a copy of AllEnumArrayItemEnum that callers may modify
+func EnumArrayItemEnumValues() []EnumArrayItemEnum {
+	return append([]EnumArrayItemEnum(nil), AllEnumArrayItemEnum...)
+}
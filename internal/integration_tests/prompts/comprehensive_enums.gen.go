@@ -1,8 +1,11 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: comprehensive_enums.prompt
+// Input hash: 2e841721c5c14f479d9edeff0edab5c0
 
 package prompts
 
 import "fmt"
+import "strconv"
 
 // ComprehensiveEnumsInput represents the input for comprehensive enums
 type ComprehensiveEnumsInput struct {
@@ -27,14 +30,14 @@ type ComprehensiveEnumsOutput struct {
 	// Detailed processing status
 	ProcessingStatus ProcessingStatusEnum `json:"processing_status"`
 	// Error code if processing fails
-	ErrorCode *ErrorCodeEnum `json:"error_code"`
+	ErrorCode *ErrorCodeEnum `json:"error_code,omitempty"`
 	// Output quality score
 	QualityScore QualityScoreEnum `json:"quality_score"`
 	// Result urgency level
-	Urgency *UrgencyEnum `json:"urgency"`
+	Urgency *UrgencyEnum `json:"urgency,omitempty"`
 }
 
-// PriorityEnum represents valid priority values
+// PriorityEnum represents Task priority level
 type PriorityEnum string
 
 const (
@@ -43,17 +46,50 @@ const (
 	PriorityEnumHigh   PriorityEnum = "high"
 )
 
+// AllPriorityEnum lists every PriorityEnum value in schema declaration order
+var AllPriorityEnum = []PriorityEnum{PriorityEnumLow, PriorityEnumMedium, PriorityEnumHigh}
+
 // Validate checks if the PriorityEnum value is valid
 func (e PriorityEnum) Validate() error {
 	switch e {
 	case PriorityEnumLow, PriorityEnumMedium, PriorityEnumHigh:
 		return nil
 	default:
-		return fmt.Errorf("invalid PriorityEnum value: %q, must be one of: low, medium, high", string(e))
+		return fmt.Errorf("%w %q for PriorityEnum, must be one of: low, medium, high", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParsePriorityEnum returns s as a PriorityEnum, or the Validate() error when it is not a valid value
+func ParsePriorityEnum(s string) (PriorityEnum, error) {
+	e := PriorityEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParsePriorityEnum is like ParsePriorityEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParsePriorityEnum(s string) PriorityEnum {
+	e, err := ParsePriorityEnum(s)
+	if err != nil {
+		panic(err)
 	}
+
+	return e
 }
 
-// StatusEnum represents valid status values
+// String returns the underlying string value of the PriorityEnum
+func (e PriorityEnum) String() string {
+	return string(e)
+}
+
+// PriorityEnumValues returns a copy of AllPriorityEnum that callers may modify
+func PriorityEnumValues() []PriorityEnum {
+	return append([]PriorityEnum(nil), AllPriorityEnum...)
+}
+
+// StatusEnum represents Approval status
 type StatusEnum string
 
 const (
@@ -62,17 +98,50 @@ const (
 	StatusEnumRejected StatusEnum = "rejected"
 )
 
+// AllStatusEnum lists every StatusEnum value in schema declaration order
+var AllStatusEnum = []StatusEnum{StatusEnumPending, StatusEnumApproved, StatusEnumRejected}
+
 // Validate checks if the StatusEnum value is valid
 func (e StatusEnum) Validate() error {
 	switch e {
 	case StatusEnumPending, StatusEnumApproved, StatusEnumRejected:
 		return nil
 	default:
-		return fmt.Errorf("invalid StatusEnum value: %q, must be one of: pending, approved, rejected", string(e))
+		return fmt.Errorf("%w %q for StatusEnum, must be one of: pending, approved, rejected", ErrInvalidEnum, string(e))
 	}
 }
 
-// DifficultyEnum represents valid difficulty values
+// ParseStatusEnum returns s as a StatusEnum, or the Validate() error when it is not a valid value
+func ParseStatusEnum(s string) (StatusEnum, error) {
+	e := StatusEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseStatusEnum is like ParseStatusEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseStatusEnum(s string) StatusEnum {
+	e, err := ParseStatusEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the StatusEnum
+func (e StatusEnum) String() string {
+	return string(e)
+}
+
+// StatusEnumValues returns a copy of AllStatusEnum that callers may modify
+func StatusEnumValues() []StatusEnum {
+	return append([]StatusEnum(nil), AllStatusEnum...)
+}
+
+// DifficultyEnum represents Complexity level with hyphens
 type DifficultyEnum string
 
 const (
@@ -83,17 +152,50 @@ const (
 	DifficultyEnumVeryHard DifficultyEnum = "very-hard"
 )
 
+// AllDifficultyEnum lists every DifficultyEnum value in schema declaration order
+var AllDifficultyEnum = []DifficultyEnum{DifficultyEnumVeryEasy, DifficultyEnumEasy, DifficultyEnumMedium, DifficultyEnumHard, DifficultyEnumVeryHard}
+
 // Validate checks if the DifficultyEnum value is valid
 func (e DifficultyEnum) Validate() error {
 	switch e {
 	case DifficultyEnumVeryEasy, DifficultyEnumEasy, DifficultyEnumMedium, DifficultyEnumHard, DifficultyEnumVeryHard:
 		return nil
 	default:
-		return fmt.Errorf("invalid DifficultyEnum value: %q, must be one of: very-easy, easy, medium, hard, very-hard", string(e))
+		return fmt.Errorf("%w %q for DifficultyEnum, must be one of: very-easy, easy, medium, hard, very-hard", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseDifficultyEnum returns s as a DifficultyEnum, or the Validate() error when it is not a valid value
+func ParseDifficultyEnum(s string) (DifficultyEnum, error) {
+	e := DifficultyEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseDifficultyEnum is like ParseDifficultyEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseDifficultyEnum(s string) DifficultyEnum {
+	e, err := ParseDifficultyEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the DifficultyEnum
+func (e DifficultyEnum) String() string {
+	return string(e)
 }
 
-// LanguageEnum represents valid language values
+// DifficultyEnumValues returns a copy of AllDifficultyEnum that callers may modify
+func DifficultyEnumValues() []DifficultyEnum {
+	return append([]DifficultyEnum(nil), AllDifficultyEnum...)
+}
+
+// LanguageEnum represents Target language code
 type LanguageEnum string
 
 const (
@@ -105,58 +207,163 @@ const (
 	LanguageEnumZhCn LanguageEnum = "zh-cn"
 )
 
+// AllLanguageEnum lists every LanguageEnum value in schema declaration order
+var AllLanguageEnum = []LanguageEnum{LanguageEnumEn, LanguageEnumEs, LanguageEnumFr, LanguageEnumDe, LanguageEnumJa, LanguageEnumZhCn}
+
 // Validate checks if the LanguageEnum value is valid
 func (e LanguageEnum) Validate() error {
 	switch e {
 	case LanguageEnumEn, LanguageEnumEs, LanguageEnumFr, LanguageEnumDe, LanguageEnumJa, LanguageEnumZhCn:
 		return nil
 	default:
-		return fmt.Errorf("invalid LanguageEnum value: %q, must be one of: en, es, fr, de, ja, zh-cn", string(e))
+		return fmt.Errorf("%w %q for LanguageEnum, must be one of: en, es, fr, de, ja, zh-cn", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseLanguageEnum returns s as a LanguageEnum, or the Validate() error when it is not a valid value
+func ParseLanguageEnum(s string) (LanguageEnum, error) {
+	e := LanguageEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
 }
 
-// FormatEnum represents valid format values
+// MustParseLanguageEnum is like ParseLanguageEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseLanguageEnum(s string) LanguageEnum {
+	e, err := ParseLanguageEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the LanguageEnum
+func (e LanguageEnum) String() string {
+	return string(e)
+}
+
+// LanguageEnumValues returns a copy of AllLanguageEnum that callers may modify
+func LanguageEnumValues() []LanguageEnum {
+	return append([]LanguageEnum(nil), AllLanguageEnum...)
+}
+
+// FormatEnum represents Output format preference
 type FormatEnum string
 
 const (
-	FormatEnumJson FormatEnum = "json"
+	FormatEnumJSON FormatEnum = "json"
 	FormatEnumXml  FormatEnum = "xml"
 	FormatEnumYaml FormatEnum = "yaml"
 	FormatEnumCsv  FormatEnum = "csv"
 )
 
+// AllFormatEnum lists every FormatEnum value in schema declaration order
+var AllFormatEnum = []FormatEnum{FormatEnumJSON, FormatEnumXml, FormatEnumYaml, FormatEnumCsv}
+
 // Validate checks if the FormatEnum value is valid
 func (e FormatEnum) Validate() error {
 	switch e {
-	case FormatEnumJson, FormatEnumXml, FormatEnumYaml, FormatEnumCsv:
+	case FormatEnumJSON, FormatEnumXml, FormatEnumYaml, FormatEnumCsv:
 		return nil
 	default:
-		return fmt.Errorf("invalid FormatEnum value: %q, must be one of: json, xml, yaml, csv", string(e))
+		return fmt.Errorf("%w %q for FormatEnum, must be one of: json, xml, yaml, csv", ErrInvalidEnum, string(e))
 	}
 }
 
-// ConfidenceLevelEnum represents valid confidence_level values
-type ConfidenceLevelEnum string
+// ParseFormatEnum returns s as a FormatEnum, or the Validate() error when it is not a valid value
+func ParseFormatEnum(s string) (FormatEnum, error) {
+	e := FormatEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseFormatEnum is like ParseFormatEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseFormatEnum(s string) FormatEnum {
+	e, err := ParseFormatEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the FormatEnum
+func (e FormatEnum) String() string {
+	return string(e)
+}
+
+// FormatEnumValues returns a copy of AllFormatEnum that callers may modify
+func FormatEnumValues() []FormatEnum {
+	return append([]FormatEnum(nil), AllFormatEnum...)
+}
+
+// ConfidenceLevelEnum represents Confidence level as integer
+type ConfidenceLevelEnum int
 
 const (
-	ConfidenceLevelEnum1 ConfidenceLevelEnum = "1"
-	ConfidenceLevelEnum2 ConfidenceLevelEnum = "2"
-	ConfidenceLevelEnum3 ConfidenceLevelEnum = "3"
-	ConfidenceLevelEnum4 ConfidenceLevelEnum = "4"
-	ConfidenceLevelEnum5 ConfidenceLevelEnum = "5"
+	ConfidenceLevelEnum1 ConfidenceLevelEnum = 1
+	ConfidenceLevelEnum2 ConfidenceLevelEnum = 2
+	ConfidenceLevelEnum3 ConfidenceLevelEnum = 3
+	ConfidenceLevelEnum4 ConfidenceLevelEnum = 4
+	ConfidenceLevelEnum5 ConfidenceLevelEnum = 5
 )
 
+// AllConfidenceLevelEnum lists every ConfidenceLevelEnum value in schema declaration order
+var AllConfidenceLevelEnum = []ConfidenceLevelEnum{ConfidenceLevelEnum1, ConfidenceLevelEnum2, ConfidenceLevelEnum3, ConfidenceLevelEnum4, ConfidenceLevelEnum5}
+
 // Validate checks if the ConfidenceLevelEnum value is valid
 func (e ConfidenceLevelEnum) Validate() error {
 	switch e {
 	case ConfidenceLevelEnum1, ConfidenceLevelEnum2, ConfidenceLevelEnum3, ConfidenceLevelEnum4, ConfidenceLevelEnum5:
 		return nil
 	default:
-		return fmt.Errorf("invalid ConfidenceLevelEnum value: %q, must be one of: 1, 2, 3, 4, 5", string(e))
+		return fmt.Errorf("%w %v for ConfidenceLevelEnum, must be one of: 1, 2, 3, 4, 5", ErrInvalidEnum, int(e))
 	}
 }
 
-// ResultEnum represents valid result values
+// ParseConfidenceLevelEnum returns s as a ConfidenceLevelEnum, or the Validate() error when it is not a valid value
+func ParseConfidenceLevelEnum(s string) (ConfidenceLevelEnum, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q for ConfidenceLevelEnum, must be one of: 1, 2, 3, 4, 5", ErrInvalidEnum, s)
+	}
+
+	e := ConfidenceLevelEnum(value)
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+
+	return e, nil
+}
+
+// MustParseConfidenceLevelEnum is like ParseConfidenceLevelEnum but panics when s is not a valid value, e.g. in test
+// fixtures
+func MustParseConfidenceLevelEnum(s string) ConfidenceLevelEnum {
+	e, err := ParseConfidenceLevelEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying int value of the ConfidenceLevelEnum
+func (e ConfidenceLevelEnum) String() string {
+	return fmt.Sprint(int(e))
+}
+
+// ConfidenceLevelEnumValues returns a copy of AllConfidenceLevelEnum that callers may modify
+func ConfidenceLevelEnumValues() []ConfidenceLevelEnum {
+	return append([]ConfidenceLevelEnum(nil), AllConfidenceLevelEnum...)
+}
+
+// ResultEnum represents Processing result
 type ResultEnum string
 
 const (
@@ -165,17 +372,50 @@ const (
 	ResultEnumRetry   ResultEnum = "retry"
 )
 
+// AllResultEnum lists every ResultEnum value in schema declaration order
+var AllResultEnum = []ResultEnum{ResultEnumSuccess, ResultEnumFailure, ResultEnumRetry}
+
 // Validate checks if the ResultEnum value is valid
 func (e ResultEnum) Validate() error {
 	switch e {
 	case ResultEnumSuccess, ResultEnumFailure, ResultEnumRetry:
 		return nil
 	default:
-		return fmt.Errorf("invalid ResultEnum value: %q, must be one of: success, failure, retry", string(e))
+		return fmt.Errorf("%w %q for ResultEnum, must be one of: success, failure, retry", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseResultEnum returns s as a ResultEnum, or the Validate() error when it is not a valid value
+func ParseResultEnum(s string) (ResultEnum, error) {
+	e := ResultEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseResultEnum is like ParseResultEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseResultEnum(s string) ResultEnum {
+	e, err := ParseResultEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the ResultEnum
+func (e ResultEnum) String() string {
+	return string(e)
+}
+
+// ResultEnumValues returns a copy of AllResultEnum that callers may modify
+func ResultEnumValues() []ResultEnum {
+	return append([]ResultEnum(nil), AllResultEnum...)
 }
 
-// ProcessingStatusEnum represents valid processing_status values
+// ProcessingStatusEnum represents Detailed processing status
 type ProcessingStatusEnum string
 
 const (
@@ -186,17 +426,51 @@ const (
 	ProcessingStatusEnumCancelled  ProcessingStatusEnum = "cancelled"
 )
 
+// AllProcessingStatusEnum lists every ProcessingStatusEnum value in schema declaration order
+var AllProcessingStatusEnum = []ProcessingStatusEnum{ProcessingStatusEnumQueued, ProcessingStatusEnumProcessing, ProcessingStatusEnumCompleted, ProcessingStatusEnumFailed, ProcessingStatusEnumCancelled}
+
 // Validate checks if the ProcessingStatusEnum value is valid
 func (e ProcessingStatusEnum) Validate() error {
 	switch e {
 	case ProcessingStatusEnumQueued, ProcessingStatusEnumProcessing, ProcessingStatusEnumCompleted, ProcessingStatusEnumFailed, ProcessingStatusEnumCancelled:
 		return nil
 	default:
-		return fmt.Errorf("invalid ProcessingStatusEnum value: %q, must be one of: queued, processing, completed, failed, cancelled", string(e))
+		return fmt.Errorf("%w %q for ProcessingStatusEnum, must be one of: queued, processing, completed, failed, cancelled", ErrInvalidEnum, string(e))
 	}
 }
 
-// ErrorCodeEnum represents valid error_code values
+// ParseProcessingStatusEnum returns s as a ProcessingStatusEnum, or the Validate() error when it is not a valid value
+func ParseProcessingStatusEnum(s string) (ProcessingStatusEnum, error) {
+	e := ProcessingStatusEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseProcessingStatusEnum is like ParseProcessingStatusEnum but panics when s is not a valid value, e.g. in test
+// fixtures
+func MustParseProcessingStatusEnum(s string) ProcessingStatusEnum {
+	e, err := ParseProcessingStatusEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the ProcessingStatusEnum
+func (e ProcessingStatusEnum) String() string {
+	return string(e)
+}
+
+// ProcessingStatusEnumValues returns a copy of AllProcessingStatusEnum that callers may modify
+func ProcessingStatusEnumValues() []ProcessingStatusEnum {
+	return append([]ProcessingStatusEnum(nil), AllProcessingStatusEnum...)
+}
+
+// ErrorCodeEnum represents Error code if processing fails
 type ErrorCodeEnum string
 
 const (
@@ -206,38 +480,109 @@ const (
 	ErrorCodeEnumRateLimit    ErrorCodeEnum = "rate_limit"
 )
 
+// AllErrorCodeEnum lists every ErrorCodeEnum value in schema declaration order
+var AllErrorCodeEnum = []ErrorCodeEnum{ErrorCodeEnumTimeout, ErrorCodeEnumInvalidInput, ErrorCodeEnumServerError, ErrorCodeEnumRateLimit}
+
 // Validate checks if the ErrorCodeEnum value is valid
 func (e ErrorCodeEnum) Validate() error {
 	switch e {
 	case ErrorCodeEnumTimeout, ErrorCodeEnumInvalidInput, ErrorCodeEnumServerError, ErrorCodeEnumRateLimit:
 		return nil
 	default:
-		return fmt.Errorf("invalid ErrorCodeEnum value: %q, must be one of: timeout, invalid_input, server_error, rate_limit", string(e))
+		return fmt.Errorf("%w %q for ErrorCodeEnum, must be one of: timeout, invalid_input, server_error, rate_limit", ErrInvalidEnum, string(e))
 	}
 }
 
-// QualityScoreEnum represents valid quality_score values
-type QualityScoreEnum string
+// ParseErrorCodeEnum returns s as a ErrorCodeEnum, or the Validate() error when it is not a valid value
+func ParseErrorCodeEnum(s string) (ErrorCodeEnum, error) {
+	e := ErrorCodeEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseErrorCodeEnum is like ParseErrorCodeEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseErrorCodeEnum(s string) ErrorCodeEnum {
+	e, err := ParseErrorCodeEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the ErrorCodeEnum
+func (e ErrorCodeEnum) String() string {
+	return string(e)
+}
+
+// ErrorCodeEnumValues returns a copy of AllErrorCodeEnum that callers may modify
+func ErrorCodeEnumValues() []ErrorCodeEnum {
+	return append([]ErrorCodeEnum(nil), AllErrorCodeEnum...)
+}
+
+// QualityScoreEnum represents Output quality score
+type QualityScoreEnum int
 
 const (
-	QualityScoreEnum1 QualityScoreEnum = "1"
-	QualityScoreEnum2 QualityScoreEnum = "2"
-	QualityScoreEnum3 QualityScoreEnum = "3"
-	QualityScoreEnum4 QualityScoreEnum = "4"
-	QualityScoreEnum5 QualityScoreEnum = "5"
+	QualityScoreEnum1 QualityScoreEnum = 1
+	QualityScoreEnum2 QualityScoreEnum = 2
+	QualityScoreEnum3 QualityScoreEnum = 3
+	QualityScoreEnum4 QualityScoreEnum = 4
+	QualityScoreEnum5 QualityScoreEnum = 5
 )
 
+// AllQualityScoreEnum lists every QualityScoreEnum value in schema declaration order
+var AllQualityScoreEnum = []QualityScoreEnum{QualityScoreEnum1, QualityScoreEnum2, QualityScoreEnum3, QualityScoreEnum4, QualityScoreEnum5}
+
 // Validate checks if the QualityScoreEnum value is valid
 func (e QualityScoreEnum) Validate() error {
 	switch e {
 	case QualityScoreEnum1, QualityScoreEnum2, QualityScoreEnum3, QualityScoreEnum4, QualityScoreEnum5:
 		return nil
 	default:
-		return fmt.Errorf("invalid QualityScoreEnum value: %q, must be one of: 1, 2, 3, 4, 5", string(e))
+		return fmt.Errorf("%w %v for QualityScoreEnum, must be one of: 1, 2, 3, 4, 5", ErrInvalidEnum, int(e))
+	}
+}
+
+// ParseQualityScoreEnum returns s as a QualityScoreEnum, or the Validate() error when it is not a valid value
+func ParseQualityScoreEnum(s string) (QualityScoreEnum, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q for QualityScoreEnum, must be one of: 1, 2, 3, 4, 5", ErrInvalidEnum, s)
+	}
+
+	e := QualityScoreEnum(value)
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+
+	return e, nil
+}
+
+// MustParseQualityScoreEnum is like ParseQualityScoreEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseQualityScoreEnum(s string) QualityScoreEnum {
+	e, err := ParseQualityScoreEnum(s)
+	if err != nil {
+		panic(err)
 	}
+
+	return e
+}
+
+// String returns the underlying int value of the QualityScoreEnum
+func (e QualityScoreEnum) String() string {
+	return fmt.Sprint(int(e))
+}
+
+// QualityScoreEnumValues returns a copy of AllQualityScoreEnum that callers may modify
+func QualityScoreEnumValues() []QualityScoreEnum {
+	return append([]QualityScoreEnum(nil), AllQualityScoreEnum...)
 }
 
-// UrgencyEnum represents valid urgency values
+// UrgencyEnum represents Result urgency level
 type UrgencyEnum string
 
 const (
@@ -247,12 +592,45 @@ const (
 	UrgencyEnumCritical UrgencyEnum = "critical"
 )
 
+// AllUrgencyEnum lists every UrgencyEnum value in schema declaration order
+var AllUrgencyEnum = []UrgencyEnum{UrgencyEnumLow, UrgencyEnumNormal, UrgencyEnumHigh, UrgencyEnumCritical}
+
 // Validate checks if the UrgencyEnum value is valid
 func (e UrgencyEnum) Validate() error {
 	switch e {
 	case UrgencyEnumLow, UrgencyEnumNormal, UrgencyEnumHigh, UrgencyEnumCritical:
 		return nil
 	default:
-		return fmt.Errorf("invalid UrgencyEnum value: %q, must be one of: low, normal, high, critical", string(e))
+		return fmt.Errorf("%w %q for UrgencyEnum, must be one of: low, normal, high, critical", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseUrgencyEnum returns s as a UrgencyEnum, or the Validate() error when it is not a valid value
+func ParseUrgencyEnum(s string) (UrgencyEnum, error) {
+	e := UrgencyEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseUrgencyEnum is like ParseUrgencyEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseUrgencyEnum(s string) UrgencyEnum {
+	e, err := ParseUrgencyEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the UrgencyEnum
+func (e UrgencyEnum) String() string {
+	return string(e)
+}
+
+// UrgencyEnumValues returns a copy of AllUrgencyEnum that callers may modify
+func UrgencyEnumValues() []UrgencyEnum {
+	return append([]UrgencyEnum(nil), AllUrgencyEnum...)
 }
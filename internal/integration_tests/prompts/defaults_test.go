@@ -0,0 +1,27 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchRequestInputApplyDefaults(t *testing.T) {
+	input := SearchRequestInput{Query: "dotprompt"}
+	input.ApplyDefaults()
+
+	assert.Equal(t, "en", input.Language)
+	assert.Equal(t, 10, input.Limit)
+	assert.InDelta(t, 0.2, input.Temperature, 1e-9)
+	assert.True(t, input.SafeSearch)
+	assert.Equal(t, SortEnumRelevance, input.Sort)
+}
+
+func TestSearchRequestInputApplyDefaultsKeepsSetFields(t *testing.T) {
+	input := SearchRequestInput{Query: "dotprompt", Language: "de", Limit: 3, Sort: SortEnumRecency}
+	input.ApplyDefaults()
+
+	assert.Equal(t, "de", input.Language)
+	assert.Equal(t, 3, input.Limit)
+	assert.Equal(t, SortEnumRecency, input.Sort)
+}
@@ -0,0 +1,33 @@
+package prompts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleOutputDecodesDateTime(t *testing.T) {
+	var output ScheduleOutput
+	require.NoError(t, json.Unmarshal([]byte(`{"created_at": "2024-01-02T15:04:05Z"}`), &output))
+
+	assert.Equal(t, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), output.CreatedAt)
+}
+
+func TestScheduleOutputDecodesDate(t *testing.T) {
+	var output ScheduleOutput
+	require.NoError(t, json.Unmarshal([]byte(`{"day": "2024-01-02"}`), &output))
+
+	assert.Equal(t, "2024-01-02", output.Day)
+}
+
+func TestScheduleOutputDecodesDuration(t *testing.T) {
+	for _, length := range []string{"PT5M", "5m"} {
+		var output ScheduleOutput
+		require.NoError(t, json.Unmarshal([]byte(`{"length": "`+length+`"}`), &output))
+
+		assert.Equal(t, length, output.Length)
+	}
+}
@@ -1,3 +1,3 @@
 package prompts
 
-//go:generate go run ../../../cmd/dotprompt-gen-go/main.go -dir . -out . -pkg prompts
+//go:generate go run ../../../cmd/dotprompt-gen-go -dir . -out . -pkg prompts
@@ -0,0 +1,57 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: geo_points.prompt
+// Input hash: 7917ce199fe8fdc738e46cc85e4e73f7
+
+package prompts
+
+import "encoding/json"
+import "fmt"
+
+// GeoPointsInput represents the input for geo points
+type GeoPointsInput struct {
+	// Region to sample
+	Region string `json:"region"`
+}
+
+// GeoPointsOutput represents the output for geo points
+type GeoPointsOutput struct {
+	// Labels of the sampled points
+	Labels []string `json:"labels,omitempty"`
+	// Label and weight of the region center
+	Center CenterTuple `json:"center"`
+}
+
+// CenterTuple is the 2-element center tuple, encoded as a JSON array
+type CenterTuple struct {
+	// Center label
+	Elem0 string `json:"elem_0"`
+	// Center weight
+	Elem1 float64 `json:"elem_1"`
+}
+
+// MarshalJSON encodes CenterTuple as a JSON array of its elements
+func (x CenterTuple) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{x.Elem0, x.Elem1})
+}
+
+// UnmarshalJSON decodes CenterTuple from a JSON array of exactly 2 elements
+func (x *CenterTuple) UnmarshalJSON(data []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return fmt.Errorf("failed to decode CenterTuple: %w", err)
+	}
+
+	if len(elems) != 2 {
+		return fmt.Errorf("failed to decode CenterTuple: expected 2 elements, got %d", len(elems))
+	}
+
+	if err := json.Unmarshal(elems[0], &x.Elem0); err != nil {
+		return fmt.Errorf("failed to decode CenterTuple element 0: %w", err)
+	}
+
+	if err := json.Unmarshal(elems[1], &x.Elem1); err != nil {
+		return fmt.Errorf("failed to decode CenterTuple element 1: %w", err)
+	}
+
+	return nil
+}
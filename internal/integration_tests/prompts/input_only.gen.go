@@ -1,4 +1,6 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: input_only.prompt
+// Input hash: 56f6e29cb78013ebde9736e6588cbc64
 
 package prompts
 
@@ -1,19 +1,21 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: json_schema_arrays.prompt
+// Input hash: 8e026a2e3f9d113249abd778cdf15cab
 
 package prompts
 
-// JsonSchemaArraysInput represents the input for json schema arrays
-type JsonSchemaArraysInput struct {
+// JSONSchemaArraysInput represents the input for json schema arrays
+type JSONSchemaArraysInput struct {
 	// List of keywords
 	Keywords []string `json:"keywords"`
 	// List of ratings
 	Ratings []float64 `json:"ratings"`
 }
 
-// JsonSchemaArraysOutput represents the output for json schema arrays
-type JsonSchemaArraysOutput struct {
+// JSONSchemaArraysOutput represents the output for json schema arrays
+type JSONSchemaArraysOutput struct {
 	// Keywords that matched
-	MatchedKeywords []string `json:"matched_keywords"`
+	MatchedKeywords []string `json:"matched_keywords,omitempty"`
 	// Average of all ratings
-	AverageRating *float64 `json:"average_rating"`
+	AverageRating *float64 `json:"average_rating,omitempty"`
 }
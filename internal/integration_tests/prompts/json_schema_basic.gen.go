@@ -1,11 +1,13 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: json_schema_basic.prompt
+// Input hash: ac681a41ad500987f0e393de3639ad8f
 
 package prompts
 
 import "fmt"
 
-// JsonSchemaBasicInput represents the input for json schema basic
-type JsonSchemaBasicInput struct {
+// JSONSchemaBasicInput represents the input for json schema basic
+type JSONSchemaBasicInput struct {
 	// The habit to analyze
 	Habit string `json:"habit"`
 	// Habit category
@@ -14,17 +16,17 @@ type JsonSchemaBasicInput struct {
 	WordCount int `json:"word_count"`
 }
 
-// JsonSchemaBasicOutput represents the output for json schema basic
-type JsonSchemaBasicOutput struct {
+// JSONSchemaBasicOutput represents the output for json schema basic
+type JSONSchemaBasicOutput struct {
 	// Generated summary
 	Summary string `json:"summary"`
 	// Confidence score
-	Confidence *float64 `json:"confidence"`
+	Confidence *float64 `json:"confidence,omitempty"`
 	// Whether the input is valid
 	Valid bool `json:"valid"`
 }
 
-// HabitCategoryEnum represents valid habit_category values
+// HabitCategoryEnum represents Habit category
 type HabitCategoryEnum string
 
 const (
@@ -33,12 +35,46 @@ const (
 	HabitCategoryEnumSocial   HabitCategoryEnum = "social"
 )
 
+// AllHabitCategoryEnum lists every HabitCategoryEnum value in schema declaration order
+var AllHabitCategoryEnum = []HabitCategoryEnum{HabitCategoryEnumPhysical, HabitCategoryEnumMental, HabitCategoryEnumSocial}
+
 // Validate checks if the HabitCategoryEnum value is valid
 func (e HabitCategoryEnum) Validate() error {
 	switch e {
 	case HabitCategoryEnumPhysical, HabitCategoryEnumMental, HabitCategoryEnumSocial:
 		return nil
 	default:
-		return fmt.Errorf("invalid HabitCategoryEnum value: %q, must be one of: physical, mental, social", string(e))
+		return fmt.Errorf("%w %q for HabitCategoryEnum, must be one of: physical, mental, social", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseHabitCategoryEnum returns s as a HabitCategoryEnum, or the Validate() error when it is not a valid value
+func ParseHabitCategoryEnum(s string) (HabitCategoryEnum, error) {
+	e := HabitCategoryEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseHabitCategoryEnum is like ParseHabitCategoryEnum but panics when s is not a valid value, e.g. in test
+// fixtures
+func MustParseHabitCategoryEnum(s string) HabitCategoryEnum {
+	e, err := ParseHabitCategoryEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the HabitCategoryEnum
+func (e HabitCategoryEnum) String() string {
+	return string(e)
+}
+
+// HabitCategoryEnumValues returns a copy of AllHabitCategoryEnum that callers may modify
+func HabitCategoryEnumValues() []HabitCategoryEnum {
+	return append([]HabitCategoryEnum(nil), AllHabitCategoryEnum...)
 }
@@ -1,4 +1,6 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: mixed_formats.prompt
+// Input hash: 93c2ed96e6e272018788dd466a76a742
 
 package prompts
 
@@ -6,7 +8,7 @@ import "fmt"
 
 // MixedFormatsInput represents the input for mixed formats
 type MixedFormatsInput struct {
-	UserId      string   `json:"user_id"`
+	UserID      string   `json:"user_id"`
 	Preferences any      `json:"preferences"`
 	Role        RoleEnum `json:"role"`
 	Settings    any      `json:"settings"`
@@ -15,14 +17,14 @@ type MixedFormatsInput struct {
 // MixedFormatsOutput represents the output for mixed formats
 type MixedFormatsOutput struct {
 	UserProfile     UserProfile `json:"user_profile"`
-	Recommendations []string    `json:"recommendations"`
+	Recommendations []string    `json:"recommendations,omitempty"`
 	Success         bool        `json:"success"`
 }
 
 // UserProfile represents
 type UserProfile struct {
-	Id       *string       `json:"id"`
-	UserRole *UserRoleEnum `json:"user_role"`
+	ID       *string       `json:"id,omitempty"`
+	UserRole *UserRoleEnum `json:"user_role,omitempty"`
 }
 
 // RoleEnum represents valid role values
@@ -34,14 +36,47 @@ const (
 	RoleEnumGuest RoleEnum = "guest"
 )
 
+// AllRoleEnum lists every RoleEnum value in schema declaration order
+var AllRoleEnum = []RoleEnum{RoleEnumAdmin, RoleEnumUser, RoleEnumGuest}
+
 // Validate checks if the RoleEnum value is valid
 func (e RoleEnum) Validate() error {
 	switch e {
 	case RoleEnumAdmin, RoleEnumUser, RoleEnumGuest:
 		return nil
 	default:
-		return fmt.Errorf("invalid RoleEnum value: %q, must be one of: admin, user, guest", string(e))
+		return fmt.Errorf("%w %q for RoleEnum, must be one of: admin, user, guest", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseRoleEnum returns s as a RoleEnum, or the Validate() error when it is not a valid value
+func ParseRoleEnum(s string) (RoleEnum, error) {
+	e := RoleEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseRoleEnum is like ParseRoleEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseRoleEnum(s string) RoleEnum {
+	e, err := ParseRoleEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the RoleEnum
+func (e RoleEnum) String() string {
+	return string(e)
+}
+
+// RoleEnumValues returns a copy of AllRoleEnum that callers may modify
+func RoleEnumValues() []RoleEnum {
+	return append([]RoleEnum(nil), AllRoleEnum...)
 }
 
 // UserRoleEnum represents valid user_role values
@@ -53,12 +88,45 @@ const (
 	UserRoleEnumGuest UserRoleEnum = "guest"
 )
 
+// AllUserRoleEnum lists every UserRoleEnum value in schema declaration order
+var AllUserRoleEnum = []UserRoleEnum{UserRoleEnumAdmin, UserRoleEnumUser, UserRoleEnumGuest}
+
 // Validate checks if the UserRoleEnum value is valid
 func (e UserRoleEnum) Validate() error {
 	switch e {
 	case UserRoleEnumAdmin, UserRoleEnumUser, UserRoleEnumGuest:
 		return nil
 	default:
-		return fmt.Errorf("invalid UserRoleEnum value: %q, must be one of: admin, user, guest", string(e))
+		return fmt.Errorf("%w %q for UserRoleEnum, must be one of: admin, user, guest", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseUserRoleEnum returns s as a UserRoleEnum, or the Validate() error when it is not a valid value
+func ParseUserRoleEnum(s string) (UserRoleEnum, error) {
+	e := UserRoleEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
 	}
+
+	return e, nil
+}
+
+// MustParseUserRoleEnum is like ParseUserRoleEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseUserRoleEnum(s string) UserRoleEnum {
+	e, err := ParseUserRoleEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the UserRoleEnum
+func (e UserRoleEnum) String() string {
+	return string(e)
+}
+
+// UserRoleEnumValues returns a copy of AllUserRoleEnum that callers may modify
+func UserRoleEnumValues() []UserRoleEnum {
+	return append([]UserRoleEnum(nil), AllUserRoleEnum...)
 }
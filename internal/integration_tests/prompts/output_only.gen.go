@@ -1,13 +1,15 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: output_only.prompt
+// Input hash: dba8a668d5ce5116b41b0ea512c97e4f
 
 package prompts
 
 // OutputOnlyOutput represents the output for output only
 type OutputOnlyOutput struct {
 	// the generated response
-	GeneratedText *string `json:"generated_text"`
+	GeneratedText *string `json:"generated_text,omitempty"`
 	// number of words generated
-	WordCount *int `json:"word_count"`
+	WordCount *int `json:"word_count,omitempty"`
 	// optional quality assessment
-	QualityScore *float64 `json:"quality_score"`
+	QualityScore *float64 `json:"quality_score,omitempty"`
 }
@@ -0,0 +1,17 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: schedule.prompt
+// Input hash: 6452ebe22668eb42bddba4c4ef374f8f
+
+package prompts
+
+import "time"
+
+// ScheduleOutput represents the output for schedule
+type ScheduleOutput struct {
+	// When the meeting was scheduled
+	CreatedAt time.Time `json:"created_at"`
+	// Calendar day of the meeting
+	Day string `json:"day"`
+	// ISO 8601 meeting length
+	Length string `json:"length"`
+}
@@ -0,0 +1,102 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: search_request.prompt
+// Input hash: 1055b7cf1f4b049f4937a8b81d6a3201
+
+package prompts
+
+import "fmt"
+
+// SearchRequestInput represents the input for search request
+type SearchRequestInput struct {
+	// Search query
+	Query string `json:"query"`
+	// Result language
+	Language string `json:"language"`
+	// Maximum number of results
+	Limit int `json:"limit"`
+	// Sampling temperature
+	Temperature float64 `json:"temperature"`
+	// Filter explicit results
+	SafeSearch bool `json:"safe_search"`
+	// Result order
+	Sort SortEnum `json:"sort"`
+}
+
+// ApplyDefaults sets the zero-valued fields of SearchRequestInput to their schema defaults
+func (x *SearchRequestInput) ApplyDefaults() {
+	if x.Language == "" {
+		x.Language = "en"
+	}
+
+	if x.Limit == 0 {
+		x.Limit = 10
+	}
+
+	if x.Temperature == 0 {
+		x.Temperature = 0.2
+	}
+
+	if !x.SafeSearch {
+		x.SafeSearch = true
+	}
+
+	if x.Sort == "" {
+		x.Sort = SortEnumRelevance
+	}
+}
+
+// SearchRequestOutput represents the output for search request
+type SearchRequestOutput struct {
+	Results []string `json:"results"`
+}
+
+// SortEnum represents Result order
+type SortEnum string
+
+const (
+	SortEnumRelevance SortEnum = "relevance"
+	SortEnumRecency   SortEnum = "recency"
+)
+
+// AllSortEnum lists every SortEnum value in schema declaration order
+var AllSortEnum = []SortEnum{SortEnumRelevance, SortEnumRecency}
+
+// Validate checks if the SortEnum value is valid
+func (e SortEnum) Validate() error {
+	switch e {
+	case SortEnumRelevance, SortEnumRecency:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for SortEnum, must be one of: relevance, recency", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseSortEnum returns s as a SortEnum, or the Validate() error when it is not a valid value
+func ParseSortEnum(s string) (SortEnum, error) {
+	e := SortEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseSortEnum is like ParseSortEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseSortEnum(s string) SortEnum {
+	e, err := ParseSortEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the SortEnum
+func (e SortEnum) String() string {
+	return string(e)
+}
+
+// SortEnumValues returns a copy of AllSortEnum that callers may modify
+func SortEnumValues() []SortEnum {
+	return append([]SortEnum(nil), AllSortEnum...)
+}
@@ -1,4 +1,6 @@
 // Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: simple_types.prompt
+// Input hash: b0670a9ed72b64ae79837c979b4ed10d
 
 package prompts
 
@@ -21,7 +23,7 @@ type SimpleTypesInput struct {
 // SimpleTypesOutput represents the output for simple types
 type SimpleTypesOutput struct {
 	// operation success
-	Success *bool `json:"success"`
+	Success *bool `json:"success,omitempty"`
 	// result message
-	Message *string `json:"message"`
+	Message *string `json:"message,omitempty"`
 }
@@ -0,0 +1,32 @@
+package prompts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCenterTupleRoundTrip(t *testing.T) {
+	var output GeoPointsOutput
+	require.NoError(t, json.Unmarshal([]byte(`{"center":["origin",0.5]}`), &output))
+
+	assert.Equal(t, CenterTuple{Elem0: "origin", Elem1: 0.5}, output.Center)
+
+	data, err := json.Marshal(output)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"center":["origin",0.5]}`, string(data))
+}
+
+func TestCenterTupleRejectsWrongLength(t *testing.T) {
+	var center CenterTuple
+
+	err := json.Unmarshal([]byte(`["origin"]`), &center)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected 2 elements, got 1")
+
+	err = json.Unmarshal([]byte(`["origin","heavy"]`), &center)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode CenterTuple element 1")
+}
@@ -1,6 +1,7 @@
 package integration_tests
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/oter/dotprompt-gen-go/internal/integration_tests/prompts"
@@ -68,18 +69,18 @@ func TestEnumValidationRuntime(t *testing.T) {
 	// Test ConfidenceLevelEnum (numeric values)
 	validLevels := []prompts.ConfidenceLevelEnum{prompts.ConfidenceLevelEnum1, prompts.ConfidenceLevelEnum2, prompts.ConfidenceLevelEnum3, prompts.ConfidenceLevelEnum4, prompts.ConfidenceLevelEnum5}
 	for _, l := range validLevels {
-		assert.NoError(t, l.Validate(), "Valid level %q failed validation", l)
+		assert.NoError(t, l.Validate(), "Valid level %d failed validation", l)
 	}
 
 	// Test ConfidenceLevelEnum with invalid values
-	invalidLevels := []prompts.ConfidenceLevelEnum{"", "0", "6", "ten", "1.0"}
+	invalidLevels := []prompts.ConfidenceLevelEnum{0, 6, -1, 10}
 	for _, l := range invalidLevels {
 		err := l.Validate()
-		assert.Error(t, err, "Invalid level %q passed validation", l)
+		assert.Error(t, err, "Invalid level %d passed validation", l)
 		if err != nil {
 			// Check error message format
 			errStr := err.Error()
-			assert.Contains(t, errStr, string(l), "Error message doesn't contain invalid value %q", l)
+			assert.Contains(t, errStr, fmt.Sprintf("value %d for", l), "Error message doesn't contain invalid value %d", l)
 			assert.Contains(t, errStr, "1, 2, 3, 4, 5", "Error message doesn't list valid values")
 		}
 	}
@@ -0,0 +1,4 @@
+// Package wrapper contains prompts generated with -optional-style=wrapper.
+package wrapper
+
+//go:generate go run ../../../cmd/dotprompt-gen-go -dir . -out . -pkg wrapper -optional-style wrapper
@@ -0,0 +1,69 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+
+package wrapper
+
+import "encoding/json"
+
+// Optional holds an optional field value, telling a field that is absent apart from one set to null
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Some returns an Optional holding value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Null returns an Optional explicitly set to null
+func Null[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// IsSet reports whether the value is present, holding a value or null
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull reports whether the value is present and null
+func (o Optional[T]) IsNull() bool {
+	return o.set && o.null
+}
+
+// Get returns the value and whether there is one, false for absent and null values
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set && !o.null
+}
+
+// IsZero reports whether the value is absent, so omitzero leaves it out of JSON
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// MarshalJSON encodes the value, or null when there is none
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set || o.null {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a present value, which is null or a T
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Null[T]()
+
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*o = Some(value)
+
+	return nil
+}
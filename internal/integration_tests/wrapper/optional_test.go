@@ -0,0 +1,49 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOptionalTellsAbsentFromNull tests that decoding keeps absent, null and set values apart
+func TestOptionalTellsAbsentFromNull(t *testing.T) {
+	var update ProfileUpdateOutput
+	require.NoError(t, json.Unmarshal([]byte(`{"display_name":"Ana","bio":null,"age":41}`), &update))
+
+	assert.False(t, update.Visibility.IsSet(), "Absent fields are unset")
+
+	assert.True(t, update.Bio.IsSet())
+	assert.True(t, update.Bio.IsNull())
+	_, ok := update.Bio.Get()
+	assert.False(t, ok, "Null fields hold no value")
+
+	age, ok := update.Age.Get()
+	require.True(t, ok)
+	assert.Equal(t, 41, age)
+}
+
+// TestOptionalRoundTrip tests that unset fields are left out of JSON and null ones are kept
+func TestOptionalRoundTrip(t *testing.T) {
+	update := ProfileUpdateOutput{
+		DisplayName: "Ana",
+		Bio:         Null[string](),
+		Visibility:  Some(VisibilityEnumPrivate),
+	}
+
+	data, err := json.Marshal(update)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"display_name":"Ana","bio":null,"visibility":"private"}`, string(data))
+
+	var decoded ProfileUpdateOutput
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, update, decoded)
+}
+
+// TestOptionalRejectsInvalidValues tests that a value of the wrong type fails decoding
+func TestOptionalRejectsInvalidValues(t *testing.T) {
+	var update ProfileUpdateOutput
+	require.Error(t, json.Unmarshal([]byte(`{"display_name":"Ana","age":"old"}`), &update))
+}
@@ -0,0 +1,76 @@
+// Code generated by dotprompt-gen-go dev. DO NOT EDIT.
+// Source: profile_update.prompt
+// Input hash: a9fc1db820937a62112fd862da2224d8
+
+package wrapper
+
+import "fmt"
+
+// ProfileUpdateInput represents the input for profile update
+type ProfileUpdateInput struct {
+	// The user whose profile is updated
+	UserID string `json:"user_id"`
+}
+
+// ProfileUpdateOutput represents the output for profile update
+type ProfileUpdateOutput struct {
+	// New display name
+	DisplayName string `json:"display_name"`
+	// New biography, null clears it
+	Bio Optional[string] `json:"bio,omitzero"`
+	// Age in years
+	Age Optional[int] `json:"age,omitzero"`
+	// Profile visibility
+	Visibility Optional[VisibilityEnum] `json:"visibility,omitzero"`
+}
+
+// VisibilityEnum represents Profile visibility
+type VisibilityEnum string
+
+const (
+	VisibilityEnumPublic  VisibilityEnum = "public"
+	VisibilityEnumPrivate VisibilityEnum = "private"
+)
+
+// AllVisibilityEnum lists every VisibilityEnum value in schema declaration order
+var AllVisibilityEnum = []VisibilityEnum{VisibilityEnumPublic, VisibilityEnumPrivate}
+
+// Validate checks if the VisibilityEnum value is valid
+func (e VisibilityEnum) Validate() error {
+	switch e {
+	case VisibilityEnumPublic, VisibilityEnumPrivate:
+		return nil
+	default:
+		return fmt.Errorf("%w %q for VisibilityEnum, must be one of: public, private", ErrInvalidEnum, string(e))
+	}
+}
+
+// ParseVisibilityEnum returns s as a VisibilityEnum, or the Validate() error when it is not a valid value
+func ParseVisibilityEnum(s string) (VisibilityEnum, error) {
+	e := VisibilityEnum(s)
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return e, nil
+}
+
+// MustParseVisibilityEnum is like ParseVisibilityEnum but panics when s is not a valid value, e.g. in test fixtures
+func MustParseVisibilityEnum(s string) VisibilityEnum {
+	e, err := ParseVisibilityEnum(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// String returns the underlying string value of the VisibilityEnum
+func (e VisibilityEnum) String() string {
+	return string(e)
+}
+
+// VisibilityEnumValues returns a copy of AllVisibilityEnum that callers may modify
+func VisibilityEnumValues() []VisibilityEnum {
+	return append([]VisibilityEnum(nil), AllVisibilityEnum...)
+}
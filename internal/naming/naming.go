@@ -1,44 +1,286 @@
 package naming
 
 import (
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// SnakeToPascalCase converts snake_case to PascalCase
-// This is the canonical implementation used throughout the codebase.
+// defaultInitialisms are the words written in upper case by default, e.g. user_id becomes UserID.
+var defaultInitialisms = []string{"API", "HTTP", "ID", "JSON", "URL", "UUID"} //nolint:gochecknoglobals // read-only default set
+
+// defaultSet is the initialism set of the package-level naming functions.
+var defaultSet = NewInitialisms(defaultInitialisms) //nolint:gochecknoglobals // read-only default set
+
+// Initialisms maps the lower-case form of each word generated names write in upper case to its
+// spelling. An empty set disables initialism handling so user_id becomes UserId.
+type Initialisms map[string]string
+
+// NewInitialisms returns the set of initialisms made of words.
+func NewInitialisms(words []string) Initialisms {
+	set := make(Initialisms, len(words))
+	for _, word := range words {
+		set[strings.ToLower(word)] = strings.ToUpper(word)
+	}
+
+	return set
+}
+
+// DefaultInitialisms returns the initialisms that are upper-cased unless configured otherwise.
+func DefaultInitialisms() []string {
+	return slices.Clone(defaultInitialisms)
+}
+
+// DefaultSet returns the set of the default initialisms.
+func DefaultSet() Initialisms {
+	return maps.Clone(defaultSet)
+}
+
+// SnakeToPascalCase converts a name to PascalCase with the default initialisms.
 func SnakeToPascalCase(s string) string {
+	return defaultSet.SnakeToPascalCase(s)
+}
+
+// TitleToPascalCase converts a title to PascalCase with the default initialisms.
+func TitleToPascalCase(title string) string {
+	return defaultSet.TitleToPascalCase(title)
+}
+
+// EnumValueToConstName converts an enum value to a Go constant name with the default initialisms.
+func EnumValueToConstName(enumTypeName, enumValue string) string {
+	return defaultSet.EnumValueToConstName(enumTypeName, enumValue)
+}
+
+// SchemaFieldToGoField converts a schema field name to a Go field name with the default initialisms.
+func SchemaFieldToGoField(fieldName string) string {
+	return defaultSet.SchemaFieldToGoField(fieldName)
+}
+
+// SnakeToPascalCase converts snake_case, kebab-case and camelCase to PascalCase
+// This is the canonical implementation used throughout the codebase.
+// Parts that are initialisms are upper-cased, e.g. api_url and apiUrl become APIURL.
+func (in Initialisms) SnakeToPascalCase(s string) string {
 	if s == "" {
 		return s
 	}
 
-	parts := strings.Split(s, "_")
+	parts := splitWords(s)
 
 	var result strings.Builder
 
 	for _, part := range parts {
+		if word, ok := in[strings.ToLower(part)]; ok {
+			result.WriteString(word)
+
+			continue
+		}
+
 		if len(part) > 0 {
-			result.WriteString(strings.ToUpper(part[:1]))
+			first, size := utf8.DecodeRuneInString(part)
+			result.WriteRune(unicode.ToUpper(first))
+			result.WriteString(part[size:])
+		}
+	}
+
+	return result.String()
+}
+
+// splitWords splits a name into words at underscores, hyphens and camelCase boundaries, e.g.
+// "user_name", "user-name" and "userName" all yield [user name] and "HTTPServer" yields [HTTP Server].
+func splitWords(s string) []string {
+	var words []string
 
-			if len(part) > 1 {
-				result.WriteString(part[1:])
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' }) {
+		runes := []rune(part)
+		start := 0
+
+		for i := 1; i < len(runes); i++ {
+			if isWordBoundary(runes, i) {
+				words = append(words, string(runes[start:i]))
+				start = i
 			}
 		}
+
+		words = append(words, string(runes[start:]))
 	}
 
-	return result.String()
+	return words
+}
+
+// isWordBoundary reports whether a new camelCase word starts at runes[i]: an upper-case letter
+// after a lower-case letter or digit, or the last upper-case letter of an acronym followed by a
+// lower-case letter.
+func isWordBoundary(runes []rune, i int) bool {
+	if !unicode.IsUpper(runes[i]) {
+		return false
+	}
+
+	previous := runes[i-1]
+	if unicode.IsLower(previous) || unicode.IsDigit(previous) {
+		return true
+	}
+
+	return unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
+
+// TitleToPascalCase converts a human readable title like "Priority Level" to PascalCase.
+func (in Initialisms) TitleToPascalCase(title string) string {
+	replacer := strings.NewReplacer(" ", "_", "-", "_", ".", "_")
+
+	return in.SnakeToPascalCase(replacer.Replace(strings.TrimSpace(title)))
 }
 
 // EnumValueToConstName converts an enum value to a Go constant name
 // Handles special characters and ensures valid Go identifier.
-func EnumValueToConstName(enumTypeName, enumValue string) string {
+func (in Initialisms) EnumValueToConstName(enumTypeName, enumValue string) string {
 	// Convert enum value to PascalCase and prefix with type name
-	cleanValue := strings.ReplaceAll(enumValue, "-", "_")
-	pascalValue := SnakeToPascalCase(cleanValue)
+	pascalValue := in.SnakeToPascalCase(separateWords(enumValue))
+
+	// The minus sign is not an identifier character, so -1 would otherwise collide with 1
+	if magnitude, negative := strings.CutPrefix(enumValue, "-"); negative && isNumber(magnitude) {
+		pascalValue = negativeEnumValuePrefix + pascalValue
+	}
 
-	return enumTypeName + pascalValue
+	// A value without identifier characters, e.g. "" or "?", would otherwise redeclare the type name
+	if pascalValue == "" {
+		pascalValue = emptyEnumValueName
+	}
+
+	return SanitizeIdentifier(enumTypeName+pascalValue, fallbackFieldPrefix)
 }
 
 // SchemaFieldToGoField converts a schema field name to a Go field name.
-func SchemaFieldToGoField(fieldName string) string {
-	return SnakeToPascalCase(fieldName)
+func (in Initialisms) SchemaFieldToGoField(fieldName string) string {
+	return SanitizeIdentifier(in.SnakeToPascalCase(separateWords(fieldName)), fallbackFieldPrefix)
+}
+
+const (
+	// fallbackFieldPrefix prefixes identifiers that are empty or would start with a digit.
+	fallbackFieldPrefix = "Field"
+	// emptyEnumValueName names the constant of an enum value without identifier characters.
+	emptyEnumValueName = "Empty"
+	// negativeEnumValuePrefix prefixes the constant name of a negative number, e.g. Minus1 for -1.
+	negativeEnumValuePrefix = "Minus"
+)
+
+// isNumber reports whether s is a JSON number without a sign, e.g. "1" or "2.5".
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+
+	return err == nil && s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// SanitizeIdentifier turns name into a valid Go identifier that is not a keyword. Characters
+// that cannot appear in identifiers are dropped, names that end up empty or start with a digit
+// get the prefix, e.g. "2fa" becomes "Field2fa", and keywords get a trailing underscore.
+func SanitizeIdentifier(name, prefix string) string {
+	identifier := stripNonIdentifierRunes(name)
+
+	if first, _ := utf8.DecodeRuneInString(identifier); identifier == "" || unicode.IsDigit(first) {
+		identifier = prefix + identifier
+	}
+
+	if token.IsKeyword(identifier) {
+		identifier += "_"
+	}
+
+	return identifier
+}
+
+// separateWords replaces every rune that cannot appear in an identifier with an underscore, so
+// "first-name", "user.email" and "$schema" split into words like snake_case names.
+func separateWords(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isIdentifierRune(r) {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
+
+// stripNonIdentifierRunes removes every rune that is not a letter, digit or underscore.
+func stripNonIdentifierRunes(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isIdentifierRune(r) {
+			return r
+		}
+
+		return -1
+	}, name)
+}
+
+// isIdentifierRune reports whether r may appear in a Go identifier.
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// GoFieldToParamName converts a Go field name to a function parameter name with the default
+// initialisms.
+func GoFieldToParamName(goName string) string {
+	return defaultSet.GoFieldToParamName(goName)
+}
+
+// GoFieldToParamName converts a Go field name to a function parameter name, e.g. UserRole to userRole.
+// A leading initialism is lower-cased as a whole, e.g. ID to id and APIURL to apiURL, as is any other
+// leading run of capitals, e.g. HTMLBody to htmlBody. Names that would be Go keywords or shadow
+// predeclared identifiers get a Value suffix.
+func (in Initialisms) GoFieldToParamName(goName string) string {
+	if goName == "" {
+		return goName
+	}
+
+	prefix := in.leadingInitialism(goName)
+	if prefix == "" {
+		prefix = leadingCapitals(goName)
+	}
+
+	param := strings.ToLower(prefix) + goName[len(prefix):]
+	if token.IsKeyword(param) || types.Universe.Lookup(param) != nil {
+		return param + "Value"
+	}
+
+	return param
+}
+
+// leadingInitialism returns the longest initialism name starts with, or "" when it starts with none.
+func (in Initialisms) leadingInitialism(name string) string {
+	var longest string
+
+	for _, word := range in {
+		if len(word) > len(longest) && strings.HasPrefix(name, word) {
+			longest = word
+		}
+	}
+
+	return longest
+}
+
+// leadingCapitals returns the upper-case runes name starts with, leaving out the last one when
+// it begins the next word, e.g. "HTML" for HTMLBody and "U" for UserRole.
+func leadingCapitals(name string) string {
+	var ends []int
+
+	for i, r := range name {
+		if !unicode.IsUpper(r) {
+			if len(ends) > 1 && unicode.IsLower(r) {
+				ends = ends[:len(ends)-1]
+			}
+
+			break
+		}
+
+		ends = append(ends, i+utf8.RuneLen(r))
+	}
+
+	if len(ends) == 0 {
+		return ""
+	}
+
+	return name[:ends[len(ends)-1]]
 }
@@ -0,0 +1,137 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSanitizeIdentifier tests that keywords, leading digits and empty names become valid identifiers
+func TestSanitizeIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Status", "Status"},
+		{"type", "type_"},
+		{"func", "func_"},
+		{"2fa", "Field2fa"},
+		{"", "Field"},
+		{"$ref", "ref"},
+		{"@@", "Field"},
+		{"Größe", "Größe"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SanitizeIdentifier(tt.name, "Field"), "SanitizeIdentifier(%q)", tt.name)
+	}
+}
+
+// TestSchemaFieldToGoField tests that schema field names always become exported Go identifiers
+func TestSchemaFieldToGoField(t *testing.T) {
+	tests := map[string]string{
+		"user_name":  "UserName",
+		"type":       "Type",
+		"func":       "Func",
+		"2fa_code":   "Field2faCode",
+		"first-name": "FirstName",
+		"user.email": "UserEmail",
+		"_":          "Field",
+		"a__b":       "AB",
+		"$schema":    "Schema",
+		"émoji":      "Émoji",
+	}
+
+	for fieldName, want := range tests {
+		assert.Equal(t, want, SchemaFieldToGoField(fieldName), "SchemaFieldToGoField(%q)", fieldName)
+	}
+}
+
+// TestEnumValueToConstName tests that enum constants stay valid and distinct from the enum type name
+func TestEnumValueToConstName(t *testing.T) {
+	tests := map[string]string{
+		"very-easy": "LevelEnumVeryEasy",
+		"2fa":       "LevelEnum2fa",
+		"type":      "LevelEnumType",
+		"v1.2":      "LevelEnumV12",
+		"":          "LevelEnumEmpty",
+		"?":         "LevelEnumEmpty",
+		"in review": "LevelEnumInReview",
+		"-1":        "LevelEnumMinus1",
+		"1":         "LevelEnum1",
+		"-0.5":      "LevelEnumMinus05",
+		"-beta":     "LevelEnumBeta",
+	}
+
+	for value, want := range tests {
+		assert.Equal(t, want, EnumValueToConstName("LevelEnum", value), "EnumValueToConstName(%q)", value)
+	}
+}
+
+// TestInitialisms tests that initialism parts are upper-cased and that the set can be replaced
+func TestInitialisms(t *testing.T) {
+	assert.Equal(t, "UserID", SchemaFieldToGoField("user_id"))
+	assert.Equal(t, "APIURL", SchemaFieldToGoField("api_url"))
+	assert.Equal(t, "StatusEnumHTTPError", EnumValueToConstName("StatusEnum", "http-error"))
+	assert.Equal(t, "Ids", SchemaFieldToGoField("ids"))
+
+	extended := NewInitialisms(append(DefaultInitialisms(), "SKU"))
+	assert.Equal(t, "ProductSKU", extended.SchemaFieldToGoField("product_sku"))
+	assert.Equal(t, "ProductSku", SchemaFieldToGoField("product_sku"))
+
+	var none Initialisms
+	assert.Equal(t, "UserId", none.SchemaFieldToGoField("user_id"))
+	assert.Equal(t, "ApiUrl", none.SchemaFieldToGoField("api_url"))
+}
+
+// TestGoFieldToParamName tests that leading initialisms and capitals are lower-cased as a whole
+func TestGoFieldToParamName(t *testing.T) {
+	tests := []struct {
+		goName string
+		want   string
+	}{
+		{"UserRole", "userRole"},
+		{"ID", "id"},
+		{"IDs", "ids"},
+		{"UserID", "userID"},
+		{"URL", "url"},
+		{"URLPath", "urlPath"},
+		{"API", "api"},
+		{"APIURL", "apiURL"},
+		{"APIKey", "apiKey"},
+		{"HTTPServerPort", "httpServerPort"},
+		{"HTMLBody", "htmlBody"},
+		{"X", "x"},
+		{"Type", "typeValue"},
+		{"Len", "lenValue"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, GoFieldToParamName(tt.goName), "GoFieldToParamName(%q)", tt.goName)
+	}
+
+	var none Initialisms
+	assert.Equal(t, "apiurl", none.GoFieldToParamName("APIURL"), "Without initialisms the capitals form one word")
+	assert.Equal(t, "apiUrl", none.GoFieldToParamName("ApiUrl"))
+}
+
+// TestCasingStyles tests that snake_case, kebab-case and camelCase keys produce the same Go name
+func TestCasingStyles(t *testing.T) {
+	tests := []struct {
+		snake, kebab, camel string
+		want                string
+	}{
+		{"user_name", "user-name", "userName", "UserName"},
+		{"user_id", "user-id", "userId", "UserID"},
+		{"api_url", "api-url", "apiURL", "APIURL"},
+		{"http_server_port", "http-server-port", "HTTPServerPort", "HTTPServerPort"},
+		{"v2_beta", "v2-beta", "v2Beta", "V2Beta"},
+	}
+
+	for _, tt := range tests {
+		for _, key := range []string{tt.snake, tt.kebab, tt.camel} {
+			assert.Equal(t, tt.want, SchemaFieldToGoField(key), "SchemaFieldToGoField(%q)", key)
+			assert.Equal(t, tt.want, SnakeToPascalCase(key), "SnakeToPascalCase(%q)", key)
+		}
+	}
+}
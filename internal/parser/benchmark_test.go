@@ -2,6 +2,8 @@ package parser
 
 import (
 	"testing"
+
+	"github.com/oter/dotprompt-gen-go/internal/naming"
 )
 
 // BenchmarkParsePicoschema benchmarks Picoschema parsing performance
@@ -22,7 +24,7 @@ func BenchmarkParsePicoschema(b *testing.B) {
 	requiredFields := []string{"name", "email", "active"}
 
 	for b.Loop() {
-		_, _, err := parsePicoschemaWithFieldOrder(picoSchema, requiredFields, SchemaTypeOutput, nil)
+		_, _, _, err := parsePicoschemaWithFieldOrder(picoSchema, requiredFields, SchemaTypeOutput, naming.DefaultSet(), nil, nil)
 		if err != nil {
 			b.Fatalf("Failed to parse schema: %v", err)
 		}
@@ -69,7 +71,7 @@ func BenchmarkParseJSONSchema(b *testing.B) {
 	requiredFields := []string{"name", "email", "active"}
 
 	for b.Loop() {
-		_, _, _, err := parseJSONSchemaWithStructsAndFieldOrder(jsonSchema, requiredFields, SchemaTypeOutput, nil)
+		_, _, _, err := parseJSONSchemaWithStructsAndFieldOrder(jsonSchema, requiredFields, SchemaTypeOutput, naming.DefaultSet(), nil)
 		if err != nil {
 			b.Fatalf("Failed to parse schema: %v", err)
 		}
@@ -119,7 +121,7 @@ func BenchmarkDeeplyNestedJSONSchema(b *testing.B) {
 	requiredFields := []string{"level1"}
 
 	for b.Loop() {
-		_, _, _, err := parseJSONSchemaWithStructsAndFieldOrder(deepSchema, requiredFields, SchemaTypeOutput, nil)
+		_, _, _, err := parseJSONSchemaWithStructsAndFieldOrder(deepSchema, requiredFields, SchemaTypeOutput, naming.DefaultSet(), nil)
 		if err != nil {
 			b.Fatalf("Failed to parse deeply nested schema: %v", err)
 		}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+const (
+	// validateTagName is the struct tag used by go-playground/validator.
+	validateTagName = "validate"
+	// requiredRule is the validator rule marking a field as required.
+	requiredRule = "required"
+	// omitEmptyRule is the validator rule skipping the other rules for zero values.
+	omitEmptyRule = "omitempty"
+)
+
+// constraintKeywords maps JSON Schema constraint keywords to go-playground/validator rule names.
+// String length and array size both use min/max since validator applies them to len().
+var constraintKeywords = []struct { //nolint:gochecknoglobals // read-only lookup table
+	keyword string
+	rule    string
+}{
+	{"minItems", "min"},
+	{"maxItems", "max"},
+	{"minLength", "min"},
+	{"maxLength", "max"},
+	{"minimum", "gte"},
+	{"exclusiveMinimum", "gt"},
+	{"maximum", "lte"},
+	{"exclusiveMaximum", "lt"},
+}
+
+// constraintRules translates the constraint keywords of a field definition into validator
+// rules, e.g. maxLength: 280 into max=280. A pattern becomes a regexp rule unless it contains
+// characters validator uses as rule separators; regexp is not built into validator and must be
+// registered as a custom validation.
+func constraintRules(fieldDefMap map[string]any) []string {
+	var rules []string
+
+	for _, constraint := range constraintKeywords {
+		if value, ok := schemaNumber(fieldDefMap[constraint.keyword]); ok {
+			rules = append(rules, constraint.rule+"="+value)
+		}
+	}
+
+	if pattern, ok := fieldDefMap["pattern"].(string); ok && validatorPattern(pattern) {
+		rules = append(rules, "regexp="+pattern)
+	}
+
+	return rules
+}
+
+// validatorPattern reports whether a pattern can be written into a validate tag, which is not the
+// case for empty patterns and patterns containing the rule separators of validator.
+func validatorPattern(pattern string) bool {
+	return pattern != "" && !strings.ContainsAny(pattern, ",|\"` \t")
+}
+
+// propertyNamesPattern returns the pattern of a map's propertyNames keyword, e.g. "^[a-z]+$" for
+// propertyNames: {pattern: "^[a-z]+$"}, or "" when the keys are not constrained.
+func propertyNamesPattern(fieldDefMap map[string]any) string {
+	propertyNames, _ := fieldDefMap["propertyNames"].(map[string]any)
+	pattern, _ := propertyNames["pattern"].(string)
+
+	return pattern
+}
+
+// mapKeyRules returns the validator rules checking every map key against pattern. They dive into
+// the map, so they have to come after the rules of the map itself.
+func mapKeyRules(pattern string) []string {
+	if !validatorPattern(pattern) {
+		return nil
+	}
+
+	return []string{"dive", "keys", "regexp=" + pattern, "endkeys"}
+}
+
+// applyStringLengthRules translates minLength on required string fields into validator rules.
+// A required string with minLength produces validate:"required,min=N" so that the non-empty
+// constraint is not lost next to the required rule. Optional strings get no rule here, without
+// omitempty an absent value would fail min=N, and -constraint-tags covers them with omitempty.
+// listed reports whether the schema's required list names the field, the pointer decision does
+// not tell since input schemas and -all-required make optional fields non-pointers too.
+func applyStringLengthRules(field codegen.GoField, fieldDef any, listed bool) codegen.GoField {
+	fieldDefMap, ok := fieldDef.(map[string]any)
+	if !ok || isNullableType(fieldDefMap) || hasEnum(fieldDefMap) || getFieldTypeFromSchema(fieldDefMap) != "string" {
+		return field
+	}
+
+	if required, ok := fieldDefMap["x-codegen-required"].(bool); ok {
+		listed = required
+	}
+
+	minLength, ok := schemaInt(fieldDefMap["minLength"])
+	if !ok || !listed {
+		return field
+	}
+
+	return AddValidateRules(field, requiredRule, "min="+strconv.Itoa(minLength))
+}
+
+// AddValidateRules merges validator rules into the field's validate tag.
+// Rules already present (compared by rule name) are kept as provided by the user,
+// and "required" or "omitempty" is always placed first.
+func AddValidateRules(field codegen.GoField, rules ...string) codegen.GoField {
+	var existing []string
+	if current := field.ExtraTags[validateTagName]; current != "" {
+		existing = strings.Split(current, ",")
+	}
+
+	merged := existing
+
+	for _, rule := range rules {
+		if hasValidateRule(existing, rule) {
+			continue
+		}
+
+		if rule == requiredRule || rule == omitEmptyRule {
+			merged = append([]string{rule}, merged...)
+		} else {
+			merged = append(merged, rule)
+		}
+	}
+
+	if len(merged) == 0 {
+		return field
+	}
+
+	if field.ExtraTags == nil {
+		field.ExtraTags = make(map[string]string)
+	}
+
+	field.ExtraTags[validateTagName] = strings.Join(merged, ",")
+
+	return field
+}
+
+// hasValidateRule checks if a rule with the same name is already present.
+func hasValidateRule(rules []string, rule string) bool {
+	ruleName, _, _ := strings.Cut(rule, "=")
+
+	for _, existing := range rules {
+		existingName, _, _ := strings.Cut(existing, "=")
+		if existingName == ruleName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaNumber formats a numeric schema keyword value for a validator rule.
+func schemaNumber(value any) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// schemaInt converts a numeric schema keyword value to an int.
+// YAML decodes integers as int while JSON decodes them as float64.
+func schemaInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
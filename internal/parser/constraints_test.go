@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestRequiredMinLengthString tests that a required non-empty string keeps both validator rules
+func TestRequiredMinLengthString(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"title": map[string]any{"type": "string", "minLength": 1},
+			"note":  map[string]any{"type": "string", "minLength": 3},
+			"slug": map[string]any{
+				"type":                 "string",
+				"minLength":            1,
+				"x-codegen-extra-tags": map[string]any{"validate": "alphanum"},
+			},
+		},
+		"required": []any{"title", "slug"},
+	}
+
+	fields, _, _, err := ParseSchemaWithStructs(schema, []string{"title", "slug"}, SchemaTypeOutput)
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+
+	byName := make(map[string]codegen.GoField)
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	assert.Equal(t, `json:"title" validate:"required,min=1"`, byName["Title"].StructTags())
+	assert.Empty(t, byName["Note"].ExtraTags["validate"], "Optional string gets no rule without omitempty")
+	assert.Equal(t, `json:"slug" validate:"required,alphanum,min=1"`, byName["Slug"].StructTags(), "User rules are preserved")
+}
+
+// TestMinLengthStringFollowsRequiredList tests that the required list, not the pointer decision, decides the required rule
+func TestMinLengthStringFollowsRequiredList(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"title":   map[string]any{"type": "string", "minLength": 1},
+			"note":    map[string]any{"type": "string", "minLength": 3},
+			"summary": map[string]any{"type": "string", "minLength": 5, "x-codegen-required": true},
+			"reply": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"body": map[string]any{"type": "string", "minLength": 2}},
+			},
+		},
+		"required": []any{"title"},
+	}
+
+	for _, schemaType := range []SchemaType{SchemaTypeInput, SchemaTypeRequiredOutput} {
+		t.Run(string(schemaType), func(t *testing.T) {
+			fields, _, structs, err := ParseSchemaWithStructs(schema, []string{"title"}, schemaType)
+			require.NoError(t, err)
+
+			byName := make(map[string]codegen.GoField)
+			for _, field := range fields {
+				byName[field.Name] = field
+			}
+
+			assert.Equal(t, "required,min=1", byName["Title"].ExtraTags["validate"])
+			assert.Empty(t, byName["Note"].ExtraTags["validate"], "Fields missing from required are not validator-required")
+			assert.Equal(t, "required,min=5", byName["Summary"].ExtraTags["validate"], "x-codegen-required overrides the list")
+
+			require.Len(t, structs, 1)
+			require.Len(t, structs[0].Fields, 1)
+			assert.Empty(t, structs[0].Fields[0].ExtraTags["validate"], "Nested fields follow their own required list")
+		})
+	}
+}
+
+// TestConstraintRules tests that schema constraints translate into validator rules
+func TestConstraintRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldDef  map[string]any
+		wantRules []string
+	}{
+		{"array size", map[string]any{"type": "array", "minItems": 1, "maxItems": 10}, []string{"min=1", "max=10"}},
+		{"string length", map[string]any{"type": "string", "maxLength": 280}, []string{"max=280"}},
+		{"number range", map[string]any{"type": "number", "minimum": 0.5, "maximum": float64(100)}, []string{"gte=0.5", "lte=100"}},
+		{"exclusive range", map[string]any{"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 5}, []string{"gt=0", "lt=5"}},
+		{"pattern", map[string]any{"type": "string", "pattern": "^[a-z]+$"}, []string{"regexp=^[a-z]+$"}},
+		{"pattern with separators", map[string]any{"type": "string", "pattern": "^(a|b){1,2}$"}, nil},
+		{"no constraints", map[string]any{"type": "string"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRules, constraintRules(tt.fieldDef))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// disambiguateEnumConstNames numbers the constants of enum values that collapse to the same Go
+// name, so "very-easy" and "very_easy" become <Enum>VeryEasy and <Enum>VeryEasy2 instead of
+// duplicate declarations. The first value keeps the plain name, and names ending in a digit are
+// numbered after an underscore.
+func disambiguateEnumConstNames(values []codegen.EnumValue) {
+	taken := make(map[string]bool, len(values))
+	for _, value := range values {
+		taken[value.ConstName] = true
+	}
+
+	seen := make(map[string]bool, len(values))
+
+	for i := range values {
+		name := values[i].ConstName
+		if !seen[name] {
+			seen[name] = true
+
+			continue
+		}
+
+		// Names ending in a digit get a separator, so the second 1 becomes <Enum>1_2 rather than <Enum>12
+		format := "%s%d"
+		if last := name[len(name)-1]; last >= '0' && last <= '9' {
+			format = "%s_%d"
+		}
+
+		suffix := 2
+		for taken[fmt.Sprintf(format, name, suffix)] {
+			suffix++
+		}
+
+		values[i].ConstName = fmt.Sprintf(format, name, suffix)
+		taken[values[i].ConstName] = true
+		seen[values[i].ConstName] = true
+	}
+}
@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestNumericEnumsKeepDeclaredType tests that integer and number enums are backed by numeric Go types
+func TestNumericEnumsKeepDeclaredType(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"level":  map[string]any{"type": "integer", "enum": []any{1, 2, 3}},
+			"ratio":  map[string]any{"type": "number", "enum": []any{0.5, 1.5}},
+			"status": map[string]any{"type": "string", "enum": []any{"on", "off"}},
+			"steps": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "integer", "enum": []any{10, 20}},
+			},
+		},
+	}
+
+	_, enums, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeInput)
+	require.NoError(t, err)
+
+	enumTypes := make(map[string]string)
+	for _, enum := range enums {
+		enumTypes[enum.Name] = enum.Type
+	}
+
+	assert.Equal(t, map[string]string{
+		"LevelEnum":     "int",
+		"RatioEnum":     "float64",
+		"StatusEnum":    "string",
+		"StepsItemEnum": "int",
+	}, enumTypes)
+
+	for _, enum := range enums {
+		if enum.Name == "RatioEnum" {
+			assert.Equal(t, "RatioEnum05", enum.Values[0].ConstName)
+			assert.Equal(t, "0.5", enum.Literal(enum.Values[0].Value))
+		}
+	}
+}
+
+// TestEnumConstNameCollisionsAreNumbered tests that values mapping to the same constant name get numbered constants
+func TestEnumConstNameCollisionsAreNumbered(t *testing.T) {
+	jsonSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"difficulty": map[string]any{
+				"type": "string",
+				"enum": []any{"very-easy", "very_easy", "Very-Easy", "hard", "Hard", "HARD"},
+			},
+		},
+	}
+
+	picoschema := map[string]any{
+		"difficulty": "string(enum): [very-easy, very_easy, Very-Easy, hard, Hard, HARD], how hard it is",
+	}
+
+	expected := map[string]string{
+		"very-easy": "DifficultyEnumVeryEasy",
+		"very_easy": "DifficultyEnumVeryEasy2",
+		"Very-Easy": "DifficultyEnumVeryEasy3",
+		"hard":      "DifficultyEnumHard",
+		"Hard":      "DifficultyEnumHard2",
+		"HARD":      "DifficultyEnumHARD",
+	}
+
+	for name, schema := range map[string]map[string]any{"JSON Schema": jsonSchema, "Picoschema": picoschema} {
+		t.Run(name, func(t *testing.T) {
+			_, enums, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeInput)
+			require.NoError(t, err)
+			require.Len(t, enums, 1)
+
+			constNames := make(map[string]string)
+			for _, value := range enums[0].Values {
+				constNames[value.Value] = value.ConstName
+			}
+
+			assert.Equal(t, expected, constNames)
+		})
+	}
+}
+
+// TestMixedSignIntegerEnumConstNames tests that negative values keep their sign in constant names and
+// that colliding names ending in a digit are numbered after an underscore
+func TestMixedSignIntegerEnumConstNames(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"delta":   map[string]any{"type": "integer", "enum": []any{-1, 1, 0}},
+			"version": map[string]any{"type": "string", "enum": []any{"v1", "v_1"}},
+		},
+	}
+
+	_, enums, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.NoError(t, err)
+	require.Len(t, enums, 2)
+
+	constNames := make(map[string]string)
+	for _, enum := range enums {
+		for _, value := range enum.Values {
+			constNames[value.Value] = value.ConstName
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"-1":  "DeltaEnumMinus1",
+		"1":   "DeltaEnum1",
+		"0":   "DeltaEnum0",
+		"v1":  "VersionEnumV1",
+		"v_1": "VersionEnumV1_2",
+	}, constNames)
+}
+
+// TestArrayEnumConstNameCollisionsAreNumbered tests that array item enums number colliding constants too
+func TestArrayEnumConstNameCollisionsAreNumbered(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"levels": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string", "enum": []any{"very-easy", "very_easy", "hard"}},
+			},
+		},
+	}
+
+	_, enums, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.NoError(t, err)
+	require.Len(t, enums, 1)
+
+	constNames := make(map[string]string)
+	for _, value := range enums[0].Values {
+		constNames[value.Value] = value.ConstName
+	}
+
+	assert.Equal(t, map[string]string{
+		"very-easy": "LevelsItemEnumVeryEasy",
+		"very_easy": "LevelsItemEnumVeryEasy2",
+		"hard":      "LevelsItemEnumHard",
+	}, constNames)
+}
+
+// TestConstBecomesSingleValueEnum tests that string and integer consts are parsed as one-value enums
+func TestConstBecomesSingleValueEnum(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"schema_version": map[string]any{"type": "string", "const": "v2"},
+			"revision":       map[string]any{"const": 3},
+		},
+		"required": []any{"schema_version", "revision"},
+	}
+
+	fields, enums, _, err := ParseSchemaWithStructs(schema, []string{"schema_version", "revision"}, SchemaTypeOutput)
+	require.NoError(t, err)
+	require.Len(t, enums, 2)
+
+	enumsByName := make(map[string]codegen.GoEnum)
+	for _, enum := range enums {
+		enumsByName[enum.Name] = enum
+	}
+
+	version := enumsByName["SchemaVersionEnum"]
+	assert.Equal(t, "string", version.Type)
+	assert.Equal(t, []codegen.EnumValue{{ConstName: "SchemaVersionEnumV2", Value: "v2"}}, version.Values)
+
+	revision := enumsByName["RevisionEnum"]
+	assert.Equal(t, "int", revision.Type, "Untyped integer consts are integer enums")
+	require.Len(t, revision.Values, 1)
+	assert.Equal(t, "3", revision.Literal(revision.Values[0].Value))
+
+	for _, field := range fields {
+		assert.True(t, field.IsEnum, "%s is an enum field", field.Name)
+	}
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+)
+
+// collectNamespacedKeys moves dotted top-level frontmatter keys such as "codegen.package" into
+// the ext metadata, as the dotprompt spec does. The part after the last dot is the key.
+func collectNamespacedKeys(frontmatterContent string, frontmatter *ast.FrontmatterData) error {
+	var raw map[string]any
+	if err := yaml.Unmarshal([]byte(frontmatterContent), &raw); err != nil {
+		return fmt.Errorf("failed to parse namespaced metadata: %w", err)
+	}
+
+	for name, value := range raw {
+		dot := strings.LastIndex(name, ".")
+		if dot <= 0 || dot == len(name)-1 {
+			continue
+		}
+
+		namespace, key := name[:dot], name[dot+1:]
+
+		if frontmatter.Ext == nil {
+			frontmatter.Ext = make(map[string]map[string]any)
+		}
+
+		if frontmatter.Ext[namespace] == nil {
+			frontmatter.Ext[namespace] = make(map[string]any)
+		}
+
+		frontmatter.Ext[namespace][key] = value
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExtMetadata tests that ext blocks and dotted top-level keys are both collected as namespaced metadata
+func TestExtMetadata(t *testing.T) {
+	promptFile, err := ParsePromptContent(`---
+model: test
+ext:
+  codegen:
+    package: billing
+tracing.enabled: true
+---
+Hello.`, "invoice.prompt")
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing", promptFile.ExtValue("codegen", "package"))
+	assert.Equal(t, true, promptFile.ExtValue("tracing", "enabled"))
+	assert.Nil(t, promptFile.ExtValue("codegen", "missing"))
+	assert.Nil(t, promptFile.ExtValue("missing", "package"))
+
+	promptFile, err = ParsePromptContent("---\ncodegen.package: billing\n---\nHello.", "invoice.prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "billing", promptFile.ExtValue("codegen", "package"))
+}
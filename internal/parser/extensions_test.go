@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCodegenSkipExtension tests that fields marked with x-codegen-skip are omitted
+func TestCodegenSkipExtension(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+			"internal_trace_id": map[string]any{
+				"type":           "string",
+				"x-codegen-skip": true,
+			},
+			"details": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"summary": map[string]any{"type": "string"},
+					"debug_info": map[string]any{
+						"type":           "string",
+						"x-codegen-skip": true,
+					},
+				},
+				"required": []any{"summary", "debug_info"},
+			},
+		},
+		"required": []any{"name", "internal_trace_id"},
+	}
+
+	fields, _, structs, err := ParseSchemaWithStructs(schema, []string{"name", "internal_trace_id"}, SchemaTypeOutput)
+	require.NoError(t, err)
+
+	var fieldNames []string
+	for _, field := range fields {
+		fieldNames = append(fieldNames, field.Name)
+	}
+	assert.Equal(t, []string{"Details", "Name"}, fieldNames, "Skipped root field should be absent")
+
+	require.Len(t, structs, 1)
+	require.Len(t, structs[0].Fields, 1, "Skipped nested field should be absent")
+	assert.Equal(t, "Summary", structs[0].Fields[0].Name)
+}
+
+// TestCodegenJSONNameExtension tests the json key precedence: x-codegen-extra-tags.json, then x-codegen-json-name, then the schema key
+func TestCodegenJSONNameExtension(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"user_id": map[string]any{
+				"type":                 "string",
+				"x-codegen-json-name":  "userId",
+				"x-codegen-extra-tags": map[string]any{"db": "user_id"},
+			},
+			"display_name": map[string]any{
+				"type":                "string",
+				"x-codegen-json-name": "displayName",
+			},
+			"raw_value": map[string]any{
+				"type":                 "string",
+				"x-codegen-json-name":  "rawValue",
+				"x-codegen-extra-tags": map[string]any{"json": "raw"},
+			},
+			"invalid_name": map[string]any{
+				"type":                "string",
+				"x-codegen-json-name": "bad,name",
+			},
+			"plain": map[string]any{"type": "string"},
+		},
+		"required": []any{"user_id"},
+	}
+
+	fields, _, _, err := ParseSchemaWithStructs(schema, []string{"user_id"}, SchemaTypeOutput)
+	require.NoError(t, err)
+
+	tags := make(map[string]string)
+	for _, field := range fields {
+		if field.Name != "UserID" {
+			field.OmitEmpty = true
+		}
+
+		tags[field.Name] = field.StructTags()
+	}
+
+	assert.Equal(t, `json:"userId" db:"user_id"`, tags["UserID"], "Other extra tags are kept")
+	assert.Equal(t, `json:"displayName,omitempty"`, tags["DisplayName"], "omitempty still applies to the renamed key")
+	assert.Equal(t, `json:"raw"`, tags["RawValue"], "An explicit json extra tag wins verbatim")
+	assert.Equal(t, `json:"invalid_name,omitempty"`, tags["InvalidName"], "Names breaking the tag are ignored")
+	assert.Equal(t, `json:"plain,omitempty"`, tags["Plain"])
+}
+
+// TestEnumDescriptionsExtension tests that x-enum-descriptions are applied by position and mismatches are recorded
+func TestEnumDescriptionsExtension(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"priority": map[string]any{
+				"type":                "string",
+				"enum":                []any{"low", nil, "high"},
+				"x-enum-descriptions": []any{"Whenever", "No priority", "Right now", "Extra"},
+			},
+		},
+	}
+
+	_, enums, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.NoError(t, err)
+	require.Len(t, enums, 1)
+
+	require.Len(t, enums[0].Values, 2)
+	assert.Equal(t, "Whenever", enums[0].Values[0].Comment)
+	assert.Equal(t, "Right now", enums[0].Values[1].Comment, "The null member keeps its description position")
+	assert.Equal(t, "4 enum descriptions for 3 enum values, only matching positions are used", enums[0].DescriptionWarning)
+}
+
+// TestCodegenRequiredExtension tests that x-codegen-required overrides the required list in both directions
+func TestCodegenRequiredExtension(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"count": map[string]any{"type": "integer", "x-codegen-required": true},
+			"status": map[string]any{
+				"type":               "string",
+				"enum":               []any{"open", "closed"},
+				"x-codegen-required": true,
+			},
+			"note":     map[string]any{"type": "string", "x-codegen-required": false},
+			"priority": map[string]any{"type": "string", "enum": []any{"low", "high"}, "x-codegen-required": false},
+			"title":    map[string]any{"type": "string"},
+		},
+		"required": []any{"note", "priority", "title"},
+	}
+
+	for _, schemaType := range []SchemaType{SchemaTypeOutput, SchemaTypeRequiredOutput} {
+		fields, _, _, err := ParseSchemaWithStructs(schema, []string{"note", "priority", "title"}, schemaType)
+		require.NoError(t, err)
+
+		types := make(map[string]string)
+		for _, field := range fields {
+			types[field.Name] = field.GoType
+		}
+
+		assert.Equal(t, "int", types["Count"], schemaType)
+		assert.Equal(t, "StatusEnum", types["Status"], schemaType)
+		assert.Equal(t, "*string", types["Note"], schemaType)
+		assert.Equal(t, "*PriorityEnum", types["Priority"], schemaType)
+		assert.Equal(t, "string", types["Title"], schemaType)
+	}
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oter/dotprompt-gen-go/internal/ast"
+)
+
+// externalSchemaExtension is the file extension required for schemas referenced from frontmatter.
+const externalSchemaExtension = ".json"
+
+// errSchemaOutsidePromptDir is returned when an external schema path escapes the prompt file's directory.
+var errSchemaOutsidePromptDir = errors.New("schema file must be inside the prompt file's directory")
+
+// resolveExternalSchemas replaces input and output schemas of the form {$ref: "./file.schema.json"}
+// with the contents of the referenced file, resolved relative to the prompt file, and records the
+// field orders declared in that file.
+func resolveExternalSchemas(promptFile *ast.PromptFile) error {
+	promptDir := filepath.Dir(promptFile.Filename)
+
+	inputSchema, inputNode, err := loadExternalSchema(promptFile.Frontmatter.Input.Schema, promptDir, promptFile.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to resolve input schema: %w", err)
+	}
+
+	if inputNode != nil {
+		promptFile.Frontmatter.Input.Schema = inputSchema
+		promptFile.InputFieldOrder = extractFieldNamesFromNode(inputNode)
+		promptFile.InputNestedFieldOrder = make(map[string][]string)
+		extractNestedFieldOrdersRecursive(inputNode, "", promptFile.InputNestedFieldOrder)
+	}
+
+	outputSchema, outputNode, err := loadExternalSchema(promptFile.Frontmatter.Output.Schema, promptDir, promptFile.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output schema: %w", err)
+	}
+
+	if outputNode != nil {
+		promptFile.Frontmatter.Output.Schema = outputSchema
+		promptFile.OutputFieldOrder = extractFieldNamesFromNode(outputNode)
+		promptFile.OutputNestedFieldOrder = make(map[string][]string)
+		extractNestedFieldOrdersRecursive(outputNode, "", promptFile.OutputNestedFieldOrder)
+	}
+
+	return nil
+}
+
+// externalSchemaRef returns the file path of a schema that only holds a $ref to another file.
+// Local JSON pointers such as "#/definitions/Item" are not external references.
+func externalSchemaRef(schema any) (string, bool) {
+	schemaMap, ok := schema.(map[string]any)
+	if !ok || len(schemaMap) != 1 {
+		return "", false
+	}
+
+	ref, ok := schemaMap["$ref"].(string)
+	if !ok || ref == "" || strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+
+	return ref, true
+}
+
+// loadExternalSchema loads the schema file referenced by schema. It returns a nil node when schema
+// is not an external reference.
+func loadExternalSchema(schema any, promptDir, promptPath string) (map[string]any, *yaml.Node, error) {
+	ref, ok := externalSchemaRef(schema)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	schemaPath, err := externalSchemaPath(ref, promptDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schema reference %q in %s: %w", ref, promptPath, err)
+	}
+
+	// #nosec G304 - Path has been validated above
+	content, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read schema file %s referenced by %s: %w", schemaPath, promptPath, err)
+	}
+
+	schemaMap, node, err := decodeSchemaFile(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse schema file %s referenced by %s: %w", schemaPath, promptPath, err)
+	}
+
+	return schemaMap, node, nil
+}
+
+// ParseSchemaFile reads a bare JSON Schema file that is not wrapped in a prompt file. The schema
+// becomes the output schema of the returned prompt file, which has no template, and the property
+// order declared in the file is kept.
+func ParseSchemaFile(path string) (*ast.PromptFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	schemaMap, node, err := decodeSchemaFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema file: %w", err)
+	}
+
+	promptFile := &ast.PromptFile{
+		Filename:               path,
+		OutputFieldOrder:       extractFieldNamesFromNode(node),
+		OutputNestedFieldOrder: make(map[string][]string),
+	}
+	promptFile.Frontmatter.Output.Schema = schemaMap
+	extractNestedFieldOrdersRecursive(node, "", promptFile.OutputNestedFieldOrder)
+
+	return promptFile, nil
+}
+
+// decodeSchemaFile decodes the content of a schema file and returns its root node.
+func decodeSchemaFile(content []byte) (map[string]any, *yaml.Node, error) {
+	// JSON is a subset of YAML, so decoding it as YAML keeps the declared key order for field ordering
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, nil, err
+	}
+
+	var schemaMap map[string]any
+	if err := document.Decode(&schemaMap); err != nil {
+		return nil, nil, err
+	}
+
+	if schemaMap == nil || len(document.Content) == 0 {
+		return nil, nil, errors.New("schema is empty")
+	}
+
+	return schemaMap, document.Content[0], nil
+}
+
+// externalSchemaPath validates a schema file reference and resolves it against the prompt
+// file's directory. References must be relative .json paths that stay inside that directory.
+func externalSchemaPath(ref, promptDir string) (string, error) {
+	if filepath.IsAbs(ref) {
+		return "", errors.New("schema file path must be relative to the prompt file")
+	}
+
+	if !strings.HasSuffix(ref, externalSchemaExtension) {
+		return "", fmt.Errorf("invalid file extension: expected %s file", externalSchemaExtension)
+	}
+
+	schemaPath := filepath.Join(promptDir, filepath.Clean(ref))
+
+	rel, err := filepath.Rel(promptDir, schemaPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errSchemaOutsidePromptDir
+	}
+
+	info, err := os.Stat(schemaPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to access schema file %s: %w", schemaPath, err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("schema path is not a regular file: %s", schemaPath)
+	}
+
+	return schemaPath, nil
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExternalSchemaFileRef tests that a frontmatter $ref to a .json file is loaded relative to the prompt
+func TestExternalSchemaFileRef(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0o750))
+
+	schema := `{
+  "type": "object",
+  "properties": {
+    "summary": {"type": "string"},
+    "address": {"type": "object", "properties": {"street": {"type": "string"}, "city": {"type": "string"}}},
+    "score": {"type": "number"}
+  },
+  "required": ["summary"]
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "response.schema.json"), []byte(schema), 0o600))
+
+	prompt := "---\noutput:\n  schema:\n    $ref: ./schemas/response.schema.json\n---\nSummarize.\n"
+	promptPath := filepath.Join(dir, "summary.prompt")
+	require.NoError(t, os.WriteFile(promptPath, []byte(prompt), 0o600))
+
+	promptFile, err := ParsePromptFile(promptPath)
+	require.NoError(t, err)
+
+	outputSchema, ok := promptFile.GetOutputSchema().(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "object", outputSchema["type"])
+	assert.Equal(t, []string{"summary", "address", "score"}, promptFile.OutputFieldOrder)
+	assert.Equal(t, []string{"street", "city"}, promptFile.OutputNestedFieldOrder["address"])
+	assert.Nil(t, promptFile.GetInputSchema())
+}
+
+// TestExternalSchemaFileRefErrors tests that unreadable, malformed and escaping references are rejected
+func TestExternalSchemaFileRefErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"type": "object",`), 0o600))
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr string
+	}{
+		{"missing file", "./missing.json", "missing.json"},
+		{"malformed file", "./broken.json", "failed to parse schema file"},
+		{"outside prompt directory", "../outside.json", "inside the prompt file's directory"},
+		{"not json", "./schema.yaml", "expected .json file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promptPath := filepath.Join(dir, "broken.prompt")
+			prompt := "---\noutput:\n  schema:\n    $ref: " + tt.ref + "\n---\nHi.\n"
+			require.NoError(t, os.WriteFile(promptPath, []byte(prompt), 0o600))
+
+			_, err := ParsePromptFile(promptPath)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.ErrorContains(t, err, promptPath)
+		})
+	}
+}
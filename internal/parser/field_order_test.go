@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -95,11 +96,13 @@ func TestSchemaParsingWithFieldOrder(t *testing.T) {
 
 	fieldOrder := []string{"name", "age", "height", "active", "metadata"}
 
-	fields, enums, err := parsePicoschemaWithFieldOrder(
+	fields, enums, _, err := parsePicoschemaWithFieldOrder(
 		schema,
 		[]string{},
 		SchemaTypeInput,
+		naming.DefaultSet(),
 		fieldOrder,
+		nil,
 	)
 	require.NoError(t, err)
 	require.Len(t, fields, 5)
@@ -112,11 +115,13 @@ func TestSchemaParsingWithFieldOrder(t *testing.T) {
 	}
 
 	// Test fallback to alphabetical order when no field order provided
-	fieldsNoOrder, _, err := parsePicoschemaWithFieldOrder(
+	fieldsNoOrder, _, _, err := parsePicoschemaWithFieldOrder(
 		schema,
 		[]string{},
 		SchemaTypeInput,
+		naming.DefaultSet(),
 		nil, // No field order
+		nil,
 	)
 	require.NoError(t, err)
 	require.Len(t, fieldsNoOrder, 5)
@@ -169,6 +174,7 @@ output:
 		promptFile.GetOutputSchema(),
 		promptFile.GetRequiredOutputFields(),
 		SchemaTypeOutput,
+		naming.DefaultSet(),
 		promptFile.OutputFieldOrder,
 		promptFile.OutputNestedFieldOrder,
 	)
@@ -187,6 +193,201 @@ output:
 
 	// Verify field order in the nested struct
 	require.Len(t, userProfileStruct.Fields, 2)
-	assert.Equal(t, "Id", userProfileStruct.Fields[0].Name, "First field should be Id")
+	assert.Equal(t, "ID", userProfileStruct.Fields[0].Name, "First field should be ID")
 	assert.Equal(t, "UserRole", userProfileStruct.Fields[1].Name, "Second field should be UserRole")
 }
+
+// TestPicoschemaNestedObjectFieldOrder tests that nested Picoschema objects keep their declared field order
+func TestPicoschemaNestedObjectFieldOrder(t *testing.T) {
+	promptFile, err := ParsePromptContent(`---
+output:
+  schema:
+    name: string, customer name
+    address(object, mailing address):
+      street: string
+      city: string
+      geo(object):
+        lon: number
+        lat: number
+      country: string
+---
+Extract the customer.`, "customer.prompt")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"street", "city", "geo(object)", "country"}, promptFile.OutputNestedFieldOrder["address"])
+	assert.Equal(t, []string{"lon", "lat"}, promptFile.OutputNestedFieldOrder["address.geo"])
+
+	fields, _, structs, err := ParsePicoschemaWithNestedFieldOrder(
+		promptFile.GetOutputSchema(),
+		promptFile.GetRequiredOutputFields(),
+		SchemaTypeOutput,
+		naming.DefaultSet(),
+		promptFile.OutputFieldOrder,
+		promptFile.OutputNestedFieldOrder,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, fields, 2)
+	assert.Equal(t, "Address", fields[1].GoType)
+	assert.Equal(t, "address", fields[1].JSONTag)
+	assert.Equal(t, "mailing address", fields[1].Comment)
+
+	require.Len(t, structs, 2)
+	assert.Equal(t, "Address", structs[0].Name)
+	assert.Equal(t, []string{"Street", "City", "Geo", "Country"}, fieldNames(structs[0].Fields))
+	assert.Equal(t, "AddressGeo", structs[1].Name)
+	assert.Equal(t, []string{"Lon", "Lat"}, fieldNames(structs[1].Fields))
+}
+
+// TestAliasedSchemaFieldOrder tests that YAML aliases and merge keys keep the anchored field order
+func TestAliasedSchemaFieldOrder(t *testing.T) {
+	promptFile, err := ParsePromptContent(`---
+input:
+  schema:
+    type: object
+    properties:
+      shipping: &address
+        type: object
+        properties: &address_fields
+          street: {type: string}
+          city: {type: string}
+          country: {type: string}
+      billing: *address
+output:
+  schema:
+    type: object
+    properties:
+      address: *address
+      contact:
+        type: object
+        properties:
+          phone: {type: string}
+          <<: *address_fields
+---
+Check the {{shipping.city}}.`, "addresses.prompt")
+	require.NoError(t, err)
+
+	addressOrder := []string{"street", "city", "country"}
+	assert.Equal(t, addressOrder, promptFile.InputNestedFieldOrder["shipping"])
+	assert.Equal(t, addressOrder, promptFile.InputNestedFieldOrder["billing"])
+	assert.Equal(t, addressOrder, promptFile.OutputNestedFieldOrder["address"])
+	assert.Equal(t, []string{"phone", "street", "city", "country"}, promptFile.OutputNestedFieldOrder["contact"])
+	assert.Equal(t, []string{"address", "contact"}, promptFile.OutputFieldOrder)
+}
+
+// TestArrayItemFieldOrderPreservation tests that object array items keep their declared field order
+func TestArrayItemFieldOrderPreservation(t *testing.T) {
+	promptFile, err := ParsePromptContent(`---
+output:
+  schema:
+    type: object
+    properties:
+      results:
+        type: array
+        items:
+          type: object
+          properties:
+            score:
+              type: number
+            name:
+              type: string
+            meta:
+              type: object
+              properties:
+                zeta:
+                  type: string
+                alpha:
+                  type: string
+---
+Rank the results.`, "rank.prompt")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"results[]":      {"score", "name", "meta"},
+		"results[].meta": {"zeta", "alpha"},
+	}, promptFile.OutputNestedFieldOrder)
+
+	_, _, structs, err := ParseJSONSchemaWithNestedFieldOrder(
+		promptFile.GetOutputSchema(),
+		promptFile.GetRequiredOutputFields(),
+		SchemaTypeOutput,
+		naming.DefaultSet(),
+		promptFile.OutputFieldOrder,
+		promptFile.OutputNestedFieldOrder,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, structs, 2)
+	assert.Equal(t, "ResultsItem", structs[0].Name)
+	assert.Equal(t, []string{"Score", "Name", "ResultsItemMeta"}, fieldNames(structs[0].Fields))
+	assert.Equal(t, "ResultsItemMeta", structs[1].Name)
+	assert.Equal(t, []string{"Zeta", "Alpha"}, fieldNames(structs[1].Fields))
+}
+
+// fieldNames returns the Go names of the given fields in order
+func fieldNames(fields []codegen.GoField) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+
+	return names
+}
+
+// TestAppendUnorderedNames tests that fields missing from the preserved order are appended alphabetically
+func TestAppendUnorderedNames(t *testing.T) {
+	fields := map[string]any{"title": nil, "zeta": nil, "alpha": nil, "mid": nil}
+
+	for range 10 {
+		assert.Equal(t, []string{"title", "alpha", "mid", "zeta"}, appendUnorderedNames([]string{"title"}, fields))
+	}
+}
+
+// TestExplicitPropertyOrder tests that x-order and x-field-order override the declaration order
+func TestExplicitPropertyOrder(t *testing.T) {
+	promptFile, err := ParsePromptContent(`---
+output:
+  schema:
+    type: object
+    properties:
+      summary:
+        type: string
+        x-order: 3
+      notes:
+        type: string
+      title:
+        type: string
+        x-order: 1
+      body:
+        type: string
+        x-order: 3
+      author:
+        type: object
+        x-field-order: [name, email, name]
+        properties:
+          id:
+            type: string
+          email:
+            type: string
+          name:
+            type: string
+---
+Write it.`, "article.prompt")
+	require.NoError(t, err)
+
+	fields, _, structs, err := ParseJSONSchemaWithNestedFieldOrder(
+		promptFile.GetOutputSchema(),
+		promptFile.GetRequiredOutputFields(),
+		SchemaTypeOutput,
+		naming.DefaultSet(),
+		promptFile.OutputFieldOrder,
+		promptFile.OutputNestedFieldOrder,
+	)
+	require.NoError(t, err)
+
+	// Ties break alphabetically and properties without x-order follow alphabetically
+	assert.Equal(t, []string{"Title", "Body", "Summary", "Author", "Notes"}, fieldNames(fields))
+
+	require.Len(t, structs, 1)
+	assert.Equal(t, []string{"Name", "Email", "ID"}, fieldNames(structs[0].Fields))
+}
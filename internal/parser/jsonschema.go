@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
@@ -41,10 +44,11 @@ func ParseJSONSchemaWithNestedFieldOrder(
 	schema any,
 	requiredFields []string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
 	nestedFieldOrder map[string][]string,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
-	return parseJSONSchemaWithStructsAndFieldOrderAndNested(schema, requiredFields, schemaType, fieldOrder, nestedFieldOrder)
+	return parseJSONSchemaWithStructsAndFieldOrderAndNested(schema, requiredFields, schemaType, names, fieldOrder, nestedFieldOrder)
 }
 
 // parseJSONSchemaWithStructsAndFieldOrder parses JSON Schema format with preserved field order.
@@ -52,9 +56,10 @@ func parseJSONSchemaWithStructsAndFieldOrder(
 	schema any,
 	requiredFields []string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
-	return parseJSONSchemaWithStructsAndFieldOrderAndNested(schema, requiredFields, schemaType, fieldOrder, nil)
+	return parseJSONSchemaWithStructsAndFieldOrderAndNested(schema, requiredFields, schemaType, names, fieldOrder, nil)
 }
 
 // parseJSONSchemaWithStructsAndFieldOrderAndNested parses JSON Schema format with preserved field order and nested field order.
@@ -62,6 +67,7 @@ func parseJSONSchemaWithStructsAndFieldOrderAndNested(
 	schema any,
 	requiredFields []string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
 	nestedFieldOrder map[string][]string,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
@@ -70,6 +76,11 @@ func parseJSONSchemaWithStructsAndFieldOrderAndNested(
 		return nil, nil, nil, errors.New("schema must be an object")
 	}
 
+	schemaMap, refs, err := resolveLocalRefs(schemaMap, names)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	var (
 		fields     []codegen.GoField
 		enums      []codegen.GoEnum
@@ -82,9 +93,14 @@ func parseJSONSchemaWithStructsAndFieldOrderAndNested(
 		return nil, nil, nil, errors.New("JSON schema must have properties")
 	}
 
+	properties = withoutSkippedProperties(properties, schemaType)
+
 	// Build required fields set and ordered field names using shared functions
 	requiredSet := buildRequiredFieldsSet(properties, requiredFields, schemaType)
-	fieldNames := buildOrderedFieldNames(properties, fieldOrder)
+	fieldNames := explicitPropertyOrder(schemaMap, properties)
+	if fieldNames == nil {
+		fieldNames = buildOrderedFieldNames(properties, fieldOrder)
+	}
 
 	// Process fields in sorted order
 	for _, fieldName := range fieldNames {
@@ -96,12 +112,14 @@ func parseJSONSchemaWithStructsAndFieldOrderAndNested(
 			requiredSet[fieldName],
 			"",
 			schemaType,
+			names,
 			nestedFieldOrder,
 		)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to parse field %s: %w", fieldName, err)
 		}
 
+		field = applyStringLengthRules(field, fieldDef, slices.Contains(requiredFields, fieldName))
 		fields = append(fields, field)
 		// Collect all enums from this field (including deeply nested ones)
 		if len(allFieldEnums) > 0 {
@@ -117,6 +135,15 @@ func parseJSONSchemaWithStructsAndFieldOrderAndNested(
 		}
 	}
 
+	// Referenced definitions are generated once, after the structs that use them
+	sharedEnums, sharedStructs, err := refs.parseSharedDefinitions(schemaMap, schemaType, names)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	enums = append(enums, sharedEnums...)
+	allStructs = append(allStructs, sharedStructs...)
+
 	return fields, enums, allStructs, nil
 }
 
@@ -129,6 +156,7 @@ func parseJSONSchemaFieldWithNestedRecursive(
 	isRequired bool,
 	parentStructName string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	nestedFieldOrder map[string][]string,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
 	fieldDefMap, ok := fieldDef.(map[string]any)
@@ -136,35 +164,119 @@ func parseJSONSchemaFieldWithNestedRecursive(
 		return codegen.GoField{}, nil, nil, nil, errors.New("JSON schema field must be an object")
 	}
 
-	field := createBaseField(fieldName, isRequired, fieldDefMap)
+	// Nullable fields are optional whether or not they are listed as required
+	if isNullableType(fieldDefMap) {
+		field, enums, directStruct, nestedStructs, err := parseJSONSchemaFieldDef(
+			fieldName, fieldDefMap, false, parentStructName, schemaType, names, nestedFieldOrder,
+		)
+
+		return asNullableField(field), enums, directStruct, nestedStructs, err
+	}
+
+	return parseJSONSchemaFieldDef(fieldName, fieldDefMap, isRequired, parentStructName, schemaType, names, nestedFieldOrder)
+}
+
+// parseJSONSchemaFieldDef parses a single field definition by its schema type.
+func parseJSONSchemaFieldDef(
+	fieldName string,
+	fieldDefMap map[string]any,
+	isRequired bool,
+	parentStructName string,
+	schemaType SchemaType,
+	names naming.Initialisms,
+	nestedFieldOrder map[string][]string,
+) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
+	field := createBaseField(fieldName, isRequired, fieldDefMap, names)
 	fieldType := getFieldTypeFromSchema(fieldDefMap)
 
+	// x-codegen-required takes precedence over the required list and -all-required
+	isRequired = field.Required
+
 	// Handle different field types
+	if structName, isRef := refStructName(fieldDefMap, names); isRef {
+		return handleRefField(field, structName)
+	}
+
+	if branches, isUnion := unionBranches(fieldDefMap); isUnion {
+		return handleUnionField(field, branches, fieldDefMap, isRequired, parentStructName, schemaType, names)
+	}
+
 	switch {
 	case hasEnum(fieldDefMap):
-		return handleEnumField(field, fieldType, fieldDefMap, isRequired, schemaType)
+		return handleEnumField(field, fieldType, fieldDefMap, isRequired, parentStructName, schemaType, names)
 	case fieldType == "array":
-		return handleArrayField(field, fieldDefMap, isRequired, schemaType)
+		if items, isTuple := tupleItems(fieldDefMap); isTuple {
+			return handleTupleField(field, items, parentStructName, schemaType, names)
+		}
+
+		return handleArrayField(field, fieldDefMap, parentStructName, schemaType, names, nestedFieldOrder)
 	case fieldType == "object":
-		return handleObjectField(field, fieldDefMap, parentStructName, schemaType, nestedFieldOrder)
+		return handleObjectField(field, fieldDefMap, parentStructName, schemaType, names, nestedFieldOrder)
 	default:
 		return handleSimpleField(field, fieldType, isRequired, schemaType)
 	}
 }
 
 // createBaseField creates a base GoField with common properties.
-func createBaseField(fieldName string, _ bool, fieldDefMap map[string]any) codegen.GoField {
+func createBaseField(fieldName string, isRequired bool, fieldDefMap map[string]any, names naming.Initialisms) codegen.GoField {
 	field := codegen.GoField{
-		Name:      naming.SchemaFieldToGoField(fieldName),
+		Name:      names.SchemaFieldToGoField(fieldName),
 		JSONTag:   fieldName,
+		Required:  isRequired,
 		ExtraTags: make(map[string]string),
 	}
 
-	// Get description
+	// Get description, replaced by x-codegen-comment for Go-specific notes
 	if desc, ok := fieldDefMap["description"].(string); ok {
 		field.Comment = desc
 	}
 
+	if comment, ok := fieldDefMap["x-codegen-comment"].(string); ok {
+		field.Comment = comment
+	}
+
+	// Defaults become the generated ApplyDefaults() method
+	field.Default = fieldDefMap["default"]
+
+	// Constraints only become validate rules with -constraint-tags
+	field.Constraints = constraintRules(fieldDefMap)
+
+	// Get format, e.g. date-time
+	if format, ok := fieldDefMap["format"].(string); ok {
+		field.Format = format
+	}
+
+	// Parse deprecated keyword
+	if deprecated, ok := fieldDefMap["deprecated"].(bool); ok {
+		field.Deprecated = deprecated
+	}
+
+	// Parse x-codegen-required extension
+	if required, ok := fieldDefMap["x-codegen-required"].(bool); ok {
+		field.Required = required
+	}
+
+	// Parse x-codegen-primary extension
+	if primary, ok := fieldDefMap["x-codegen-primary"].(bool); ok {
+		field.Primary = primary
+	}
+
+	// Parse x-codegen-go-type and x-codegen-import extensions, ignoring blank overrides
+	if goType, ok := fieldDefMap["x-codegen-go-type"].(string); ok && strings.TrimSpace(goType) != "" {
+		field.TypeOverride = strings.TrimSpace(goType)
+
+		if importPath, ok := fieldDefMap["x-codegen-import"].(string); ok {
+			field.Import = strings.TrimSpace(importPath)
+		}
+	}
+
+	// Parse x-codegen-json-name extension, ignoring names that would break the struct tag
+	if jsonName, ok := fieldDefMap["x-codegen-json-name"].(string); ok {
+		if jsonName = strings.TrimSpace(jsonName); jsonName != "" && !strings.ContainsAny(jsonName, "\",` \t") {
+			field.JSONName = jsonName
+		}
+	}
+
 	// Parse x-codegen-extra-tags extension
 	if extraTags, ok := fieldDefMap["x-codegen-extra-tags"].(map[string]any); ok {
 		for tagName, tagValue := range extraTags {
@@ -177,41 +289,185 @@ func createBaseField(fieldName string, _ bool, fieldDefMap map[string]any) codeg
 	return field
 }
 
-// getFieldTypeFromSchema extracts the type from schema definition.
+// withoutSkippedProperties returns a copy of properties without fields marked with the
+// x-codegen-skip extension or excluded from schemaType by readOnly or writeOnly, so they are
+// neither generated nor treated as required.
+func withoutSkippedProperties(properties map[string]any, schemaType SchemaType) map[string]any {
+	filtered := make(map[string]any, len(properties))
+
+	for propName, propDef := range properties {
+		if isSkippedField(propDef) || isExcludedAccessField(propDef, schemaType) {
+			continue
+		}
+
+		filtered[propName] = propDef
+	}
+
+	return filtered
+}
+
+// isSkippedField checks if a property definition is marked with x-codegen-skip: true.
+func isSkippedField(fieldDef any) bool {
+	fieldDefMap, ok := fieldDef.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	skip, _ := fieldDefMap["x-codegen-skip"].(bool)
+
+	return skip
+}
+
+// isExcludedAccessField checks if a property definition is readOnly in an input schema or
+// writeOnly in an output schema: readOnly fields are assigned by the responder and writeOnly
+// fields are only ever sent, so one shared schema can describe both directions.
+func isExcludedAccessField(fieldDef any, schemaType SchemaType) bool {
+	fieldDefMap, ok := fieldDef.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	switch schemaType {
+	case SchemaTypeInput:
+		readOnly, _ := fieldDefMap["readOnly"].(bool)
+
+		return readOnly
+	case SchemaTypeOutput, SchemaTypeRequiredOutput:
+		writeOnly, _ := fieldDefMap["writeOnly"].(bool)
+
+		return writeOnly
+	default:
+		return false
+	}
+}
+
+// getFieldTypeFromSchema extracts the type from schema definition. A nullable type array like
+// ["string", "null"] yields its non-null type; other type arrays yield any.
 func getFieldTypeFromSchema(fieldDefMap map[string]any) string {
-	fieldType, ok := fieldDefMap["type"].(string)
+	switch fieldType := fieldDefMap["type"].(type) {
+	case string:
+		return fieldType
+	case []any:
+		if baseType, ok := nullableBaseType(fieldType); ok {
+			return baseType
+		}
+	}
+
+	return "any"
+}
+
+// isNullableType checks if a field's type is a two-element type array containing "null".
+func isNullableType(fieldDefMap map[string]any) bool {
+	types, ok := fieldDefMap["type"].([]any)
 	if !ok {
-		return "any"
+		return false
 	}
 
-	return fieldType
+	_, ok = nullableBaseType(types)
+
+	return ok
 }
 
-// hasEnum checks if the field definition contains an enum.
+// nullableBaseType returns the non-null member of a ["<type>", "null"] type array.
+func nullableBaseType(types []any) (string, bool) {
+	const nullableTypeCount = 2
+
+	if len(types) != nullableTypeCount {
+		return "", false
+	}
+
+	for i, member := range types {
+		if member == "null" {
+			baseType, ok := types[1-i].(string)
+
+			return baseType, ok && baseType != "null"
+		}
+	}
+
+	return "", false
+}
+
+// asNullableField makes a nullable field a pointer; slices, maps and unions are nillable already.
+func asNullableField(field codegen.GoField) codegen.GoField {
+	if field.IsPointer || field.Union != nil ||
+		strings.HasPrefix(field.GoType, "[]") || strings.HasPrefix(field.GoType, "map[") {
+		return field
+	}
+
+	field.GoType = "*" + field.GoType
+	field.IsPointer = true
+
+	return field
+}
+
+// hasEnum checks if the field definition contains an enum or a const.
 func hasEnum(fieldDefMap map[string]any) bool {
-	_, hasEnum := fieldDefMap["enum"]
+	_, hasEnum := schemaEnumValues(fieldDefMap)
 
 	return hasEnum
 }
 
+// schemaEnumValues returns the enum values of a field definition. A const is a one-value enum.
+func schemaEnumValues(fieldDefMap map[string]any) (any, bool) {
+	if values, ok := fieldDefMap["enum"]; ok {
+		return values, true
+	}
+
+	if value, ok := fieldDefMap["const"]; ok {
+		return []any{value}, true
+	}
+
+	return nil, false
+}
+
+// constFieldType infers the schema type of an untyped const from its value.
+func constFieldType(fieldType string, fieldDefMap map[string]any) string {
+	if fieldType != "any" {
+		return fieldType
+	}
+
+	switch fieldDefMap["const"].(type) {
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	default:
+		return fieldType
+	}
+}
+
 // handleEnumField processes enum field types.
 func handleEnumField(
 	field codegen.GoField,
 	fieldType string,
 	fieldDefMap map[string]any,
 	isRequired bool,
+	parentStructName string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
-	enumValues := fieldDefMap["enum"]
+	// An x-codegen-go-type override replaces the generated enum type
+	if field.TypeOverride != "" {
+		return handleSimpleField(field, fieldType, isRequired, schemaType)
+	}
+
+	enumValues, _ := schemaEnumValues(fieldDefMap)
+	fieldType = constFieldType(fieldType, fieldDefMap)
 
-	field, enumDef, err := parseJSONSchemaEnum(field, fieldType, enumValues)
+	field, enumDef, err := parseJSONSchemaEnum(field, fieldType, enumValues, enumTypeNameFor(field, fieldDefMap, names), names)
 	if err != nil {
 		return field, nil, nil, nil, err
 	}
 
+	applyEnumDescriptions(enumDef, enumValues, fieldDefMap)
+	enumDef.Comment = enumComment(fieldDefMap, enumDef.Comment)
+	enumDef.Preferred = preferredEnumValue(fieldDefMap)
+	enumDef.Scope = enumScope(parentStructName, fieldDefMap, names)
+
 	// For output schemas, make non-required enum fields pointers
-	if schemaType == SchemaTypeOutput && !isRequired {
+	if schemaType != SchemaTypeInput && !isRequired {
 		field.GoType = "*" + field.GoType
+		field.IsPointer = true
 	}
 
 	return field, []codegen.GoEnum{*enumDef}, nil, nil, nil
@@ -221,8 +477,10 @@ func handleEnumField(
 func handleArrayField(
 	field codegen.GoField,
 	fieldDefMap map[string]any,
-	_ bool,
+	parentStructName string,
 	schemaType SchemaType,
+	names naming.Initialisms,
+	nestedFieldOrder map[string][]string,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
 	// Check if array items are objects with properties
 	items, hasItems := fieldDefMap["items"]
@@ -241,22 +499,34 @@ func handleArrayField(
 		return field, nil, nil, nil, nil
 	}
 
+	// Items referencing a shared definition reuse its struct
+	if structName, isRef := refStructName(itemsMap, names); isRef {
+		field.GoType = "[]" + structName
+
+		return field, nil, nil, nil, nil
+	}
+
 	itemType, hasType := itemsMap["type"].(string)
 	_, hasProperties := itemsMap["properties"].(map[string]any)
-	_, hasEnum := itemsMap["enum"]
+	_, hasEnum := schemaEnumValues(itemsMap)
 
 	// If items are objects with properties, create a nested struct
 	if hasType && itemType == "object" && hasProperties {
-		return handleObjectArrayField(field, itemsMap, schemaType)
+		return handleObjectArrayField(field, itemsMap, schemaType, names, nestedFieldOrder)
 	}
 
 	// If items have enum values, create an enum type for the array items
 	if hasEnum {
-		updatedField, enumDef, err := parseJSONSchemaArrayEnum(field, itemsMap)
+		updatedField, enumDef, err := parseJSONSchemaArrayEnum(field, itemsMap, names)
 		if err != nil {
 			return field, nil, nil, nil, err
 		}
 
+		enumValues, _ := schemaEnumValues(itemsMap)
+		applyEnumDescriptions(enumDef, enumValues, itemsMap)
+		enumDef.Comment = enumComment(itemsMap, enumDef.Comment)
+		enumDef.Scope = parentStructName
+
 		return updatedField, []codegen.GoEnum{*enumDef}, nil, nil, nil
 	}
 
@@ -273,14 +543,16 @@ func handleObjectArrayField(
 	field codegen.GoField,
 	itemsMap map[string]any,
 	schemaType SchemaType,
+	names naming.Initialisms,
+	nestedFieldOrder map[string][]string,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
 	// Create struct name for the array item type
 	itemStructName := field.Name + "Item"
 
-	// Create a field representing the object item type
+	// Create a field representing the object item type, keyed like its nested field order path
 	itemField := codegen.GoField{
 		Name:    itemStructName,
-		JSONTag: field.JSONTag + "_item", // This won't be used but needed for the struct creation
+		JSONTag: field.JSONTag + ArrayItemsPathSuffix,
 	}
 
 	// Get description for the item struct
@@ -295,7 +567,8 @@ func handleObjectArrayField(
 		itemField,
 		itemsMap,
 		schemaType,
-		nil, // Array items don't have nested field order preservation yet
+		names,
+		nestedFieldOrder,
 	)
 	if err != nil {
 		return field, nil, nil, nil, fmt.Errorf("failed to parse array item object: %w", err)
@@ -313,6 +586,7 @@ func handleObjectField(
 	fieldDefMap map[string]any,
 	parentStructName string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	nestedFieldOrder map[string][]string,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
 	// Create unique struct name to avoid conflicts in deeply nested structures
@@ -320,7 +594,7 @@ func handleObjectField(
 		field.Name = parentStructName + field.Name
 	}
 
-	return parseJSONSchemaObjectField(field, fieldDefMap, schemaType, nestedFieldOrder)
+	return parseJSONSchemaObjectField(field, fieldDefMap, schemaType, names, nestedFieldOrder)
 }
 
 // handleSimpleField processes simple field types.
@@ -331,10 +605,14 @@ func handleSimpleField(
 	schemaType SchemaType,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
 	field.GoType = convertJSONSchemaTypeToGo(fieldType)
+	if field.TypeOverride != "" {
+		field.GoType = field.TypeOverride
+	}
 
 	// For output schemas, make non-required fields pointers
-	// But skip arrays since they're already nillable
-	if schemaType == SchemaTypeOutput && !isRequired && !strings.HasPrefix(field.GoType, "[]") {
+	// But skip arrays and pointer overrides since they're already nillable
+	if schemaType != SchemaTypeInput && !isRequired && !strings.HasPrefix(field.GoType, "[]") &&
+		!strings.HasPrefix(field.GoType, "*") {
 		field.GoType = "*" + field.GoType
 	}
 
@@ -350,33 +628,47 @@ func parseJSONSchemaObjectField(
 	field codegen.GoField,
 	fieldDefMap map[string]any,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	nestedFieldOrder map[string][]string,
 ) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
 	structName := field.Name
 
 	properties, ok := fieldDefMap["properties"].(map[string]any)
 	if !ok {
-		field.GoType = "map[string]any"
-
-		return field, nil, nil, nil, nil
+		return parseJSONSchemaMapField(field, fieldDefMap, schemaType, names)
 	}
 
+	properties = withoutSkippedProperties(properties, schemaType)
 	requiredFields := extractRequiredFields(fieldDefMap)
-	propNames := getOrderedPropertyNames(properties, field.JSONTag, nestedFieldOrder)
+	propNames := getOrderedPropertyNames(fieldDefMap, properties, field.JSONTag, nestedFieldOrder)
 
 	nestedFields, allEnums, allDeeplyNestedStructs, err := processNestedProperties(
-		properties, propNames, requiredFields, structName, schemaType, nestedFieldOrder,
+		properties, propNames, requiredFields, structName, schemaType, names, scopedFieldOrder(nestedFieldOrder, field.JSONTag),
 	)
 	if err != nil {
 		return field, nil, nil, nil, err
 	}
 
 	nestedStruct := createNestedStruct(structName, field.Comment, nestedFields)
+	nestedStruct.Title = titleTypeName(fieldDefMap, names)
 	field = updateFieldForStruct(field, structName)
 
 	return field, allEnums, nestedStruct, allDeeplyNestedStructs, nil
 }
 
+// scopedFieldOrder returns the nested field orders below path, keyed relative to it.
+func scopedFieldOrder(nestedFieldOrder map[string][]string, path string) map[string][]string {
+	scoped := make(map[string][]string)
+
+	for key, order := range nestedFieldOrder {
+		if relative, ok := strings.CutPrefix(key, path+"."); ok {
+			scoped[relative] = order
+		}
+	}
+
+	return scoped
+}
+
 // extractRequiredFields extracts required field names from field definition map.
 func extractRequiredFields(fieldDefMap map[string]any) []string {
 	var requiredFields []string
@@ -392,12 +684,18 @@ func extractRequiredFields(fieldDefMap map[string]any) []string {
 	return requiredFields
 }
 
-// getOrderedPropertyNames returns property names in the correct order.
+// getOrderedPropertyNames returns property names in the correct order: the explicit x-field-order
+// or x-order of the object, then the preserved declaration order, then alphabetical.
 func getOrderedPropertyNames(
+	objectDef map[string]any,
 	properties map[string]any,
 	fieldJSONTag string,
 	nestedFieldOrder map[string][]string,
 ) []string {
+	if propNames := explicitPropertyOrder(objectDef, properties); propNames != nil {
+		return propNames
+	}
+
 	fieldOrderForThisStruct := nestedFieldOrder[fieldJSONTag]
 	if len(fieldOrderForThisStruct) > 0 {
 		return getPreservedOrderPropertyNames(properties, fieldOrderForThisStruct)
@@ -418,21 +716,63 @@ func getPreservedOrderPropertyNames(properties map[string]any, fieldOrder []stri
 	}
 
 	// Add any remaining fields not in the preserved order (edge case)
-	for propName := range properties {
-		found := false
-		for _, orderedField := range propNames {
-			if orderedField == propName {
-				found = true
+	return appendUnorderedNames(propNames, properties)
+}
 
-				break
+// explicitPropertyOrder returns the property names of an object schema ordered by its x-field-order
+// array, or by the x-order integers of its properties, and nil when neither is set. Properties left
+// out follow alphabetically, and properties sharing an x-order are sorted alphabetically. Schemas
+// loaded from JSON files can use it when the positional order is not wanted.
+func explicitPropertyOrder(objectDef map[string]any, properties map[string]any) []string {
+	if fieldOrder, ok := objectDef["x-field-order"].([]any); ok {
+		var propNames []string
+
+		for _, name := range fieldOrder {
+			name, ok := name.(string)
+			if _, exists := properties[name]; ok && exists && !slices.Contains(propNames, name) {
+				propNames = append(propNames, name)
 			}
 		}
-		if !found {
-			propNames = append(propNames, propName)
+
+		return appendUnorderedNames(propNames, properties)
+	}
+
+	orders := make(map[string]int)
+
+	for name, propDef := range properties {
+		if order, ok := propertyOrder(propDef); ok {
+			orders[name] = order
 		}
 	}
 
-	return propNames
+	if len(orders) == 0 {
+		return nil
+	}
+
+	propNames := slices.SortedFunc(maps.Keys(orders), func(a, b string) int {
+		return cmp.Or(cmp.Compare(orders[a], orders[b]), strings.Compare(a, b))
+	})
+
+	return appendUnorderedNames(propNames, properties)
+}
+
+// propertyOrder returns the x-order integer of a property definition.
+func propertyOrder(propDef any) (int, bool) {
+	propMap, ok := propDef.(map[string]any)
+	if !ok {
+		return 0, false
+	}
+
+	switch order := propMap["x-order"].(type) {
+	case int:
+		return order, true
+	case float64:
+		if order == float64(int(order)) {
+			return int(order), true
+		}
+	}
+
+	return 0, false
 }
 
 // getAlphabeticalPropertyNames returns property names in alphabetical order.
@@ -453,6 +793,7 @@ func processNestedProperties(
 	requiredFields []string,
 	structName string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	nestedFieldOrder map[string][]string,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
 	var (
@@ -466,6 +807,12 @@ func processNestedProperties(
 		requiredSet[reqField] = true
 	}
 
+	if schemaType == SchemaTypeRequiredOutput {
+		for _, propName := range propNames {
+			requiredSet[propName] = true
+		}
+	}
+
 	for _, propName := range propNames {
 		propDef := properties[propName]
 
@@ -475,12 +822,14 @@ func processNestedProperties(
 			requiredSet[propName],
 			structName,
 			schemaType,
+			names,
 			nestedFieldOrder,
 		)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to parse nested field %s: %w", propName, err)
 		}
 
+		nestedField = applyStringLengthRules(nestedField, propDef, slices.Contains(requiredFields, propName))
 		nestedFields = append(nestedFields, nestedField)
 		allEnums = append(allEnums, allNestedEnums...)
 
@@ -511,11 +860,35 @@ func updateFieldForStruct(field codegen.GoField, structName string) codegen.GoFi
 	return field
 }
 
+// enumScope returns the struct whose name prefixes the enum of a field with -prefix-enums: the
+// parent struct of a nested field, or "" for top-level fields and enums named by their title.
+func enumScope(parentStructName string, fieldDefMap map[string]any, names naming.Initialisms) string {
+	if title, ok := fieldDefMap["title"].(string); ok && names.TitleToPascalCase(title) != "" {
+		return ""
+	}
+
+	return parentStructName
+}
+
+// enumTypeNameFor returns the enum type name for a field, preferring the schema title
+// (e.g. "Priority Level" -> PriorityLevelEnum) over the field name.
+func enumTypeNameFor(field codegen.GoField, fieldDefMap map[string]any, names naming.Initialisms) string {
+	if title, ok := fieldDefMap["title"].(string); ok {
+		if titleName := names.TitleToPascalCase(title); titleName != "" {
+			return strings.TrimSuffix(titleName, "Enum") + "Enum"
+		}
+	}
+
+	return field.Name + "Enum"
+}
+
 // parseJSONSchemaEnum parses enum definition in JSON Schema.
 func parseJSONSchemaEnum(
 	field codegen.GoField,
-	_ string,
+	fieldType string,
 	enumValues any,
+	enumTypeName string,
+	names naming.Initialisms,
 ) (codegen.GoField, *codegen.GoEnum, error) {
 	enumSlice, ok := enumValues.([]any)
 	if !ok {
@@ -524,17 +897,22 @@ func parseJSONSchemaEnum(
 
 	var values []codegen.EnumValue
 
-	enumTypeName := field.Name + "Enum"
-
 	for _, val := range enumSlice {
+		// A null member only marks a nullable enum, it is not a value of the enum type
+		if val == nil {
+			continue
+		}
+
 		valueStr := fmt.Sprintf("%v", val)
-		constName := naming.EnumValueToConstName(enumTypeName, valueStr)
+		constName := names.EnumValueToConstName(enumTypeName, valueStr)
 		values = append(values, codegen.EnumValue{
 			ConstName: constName,
 			Value:     valueStr,
 		})
 	}
 
+	disambiguateEnumConstNames(values)
+
 	field.GoType = enumTypeName
 	field.IsEnum = true
 	field.IsPointer = strings.HasPrefix(field.GoType, "*")
@@ -542,19 +920,91 @@ func parseJSONSchemaEnum(
 	enum := &codegen.GoEnum{
 		Name:    enumTypeName,
 		Comment: fmt.Sprintf("valid %s values", field.JSONTag),
-		Type:    "string", // All enums are string-based in Go for JSON compatibility
+		Type:    enumGoType(fieldType),
 		Values:  values,
 	}
 
 	return field, enum, nil
 }
 
+// applyEnumDescriptions documents enum constants with the x-enum-descriptions (or enumDescriptions)
+// array parallel to the schema enum. Arrays of a different length are applied as far as they go and
+// recorded in DescriptionWarning, so the generator can warn instead of failing.
+func applyEnumDescriptions(enum *codegen.GoEnum, enumValues any, fieldDefMap map[string]any) {
+	descriptions, ok := fieldDefMap["x-enum-descriptions"].([]any)
+	if !ok {
+		descriptions, ok = fieldDefMap["enumDescriptions"].([]any)
+	}
+
+	enumSlice, _ := enumValues.([]any)
+	if !ok || len(enumSlice) == 0 {
+		return
+	}
+
+	if len(descriptions) != len(enumSlice) {
+		enum.DescriptionWarning = fmt.Sprintf("%d enum descriptions for %d enum values, only matching positions are used",
+			len(descriptions), len(enumSlice))
+	}
+
+	byValue := make(map[string]string, len(descriptions))
+
+	for i, description := range descriptions[:min(len(descriptions), len(enumSlice))] {
+		if text, ok := description.(string); ok {
+			byValue[fmt.Sprintf("%v", enumSlice[i])] = strings.TrimSpace(text)
+		}
+	}
+
+	for i := range enum.Values {
+		enum.Values[i].Comment = byValue[enum.Values[i].Value]
+	}
+}
+
+// enumComment returns the schema description documenting an enum type, joined into one paragraph,
+// or fallback when the schema has none.
+func enumComment(fieldDefMap map[string]any, fallback string) string {
+	description, _ := fieldDefMap["description"].(string)
+
+	return descriptionOr(description, fallback)
+}
+
+// descriptionOr returns description with its lines joined by spaces, or fallback when it is blank.
+func descriptionOr(description, fallback string) string {
+	if words := strings.Fields(description); len(words) > 0 {
+		return strings.Join(words, " ")
+	}
+
+	return fallback
+}
+
+// enumGoType returns the Go type backing an enum: int or float64 for numeric schema types so
+// constants compare against decoded JSON numbers, string otherwise.
+func enumGoType(fieldType string) string {
+	switch fieldType {
+	case "integer", "number":
+		return convertJSONSchemaTypeToGo(fieldType)
+	default:
+		return "string"
+	}
+}
+
+// preferredEnumValue returns the schema example, falling back to the default, as an enum value string.
+func preferredEnumValue(fieldDefMap map[string]any) string {
+	for _, key := range []string{"example", "default"} {
+		if value, ok := fieldDefMap[key]; ok && value != nil {
+			return fmt.Sprintf("%v", value)
+		}
+	}
+
+	return ""
+}
+
 // parseJSONSchemaArrayEnum parses array items with enum values and generates enum type for array.
 func parseJSONSchemaArrayEnum(
 	field codegen.GoField,
 	itemsMap map[string]any,
+	names naming.Initialisms,
 ) (codegen.GoField, *codegen.GoEnum, error) {
-	enumValues := itemsMap["enum"]
+	enumValues, _ := schemaEnumValues(itemsMap)
 
 	enumSlice, ok := enumValues.([]any)
 	if !ok {
@@ -565,16 +1015,24 @@ func parseJSONSchemaArrayEnum(
 
 	// Create enum type name for array items
 	enumTypeName := field.Name + "ItemEnum"
+	itemType := getFieldTypeFromSchema(itemsMap)
 
 	for _, val := range enumSlice {
+		// A null member only marks nullable items, it is not a value of the enum type
+		if val == nil {
+			continue
+		}
+
 		valueStr := fmt.Sprintf("%v", val)
-		constName := naming.EnumValueToConstName(enumTypeName, valueStr)
+		constName := names.EnumValueToConstName(enumTypeName, valueStr)
 		values = append(values, codegen.EnumValue{
 			ConstName: constName,
 			Value:     valueStr,
 		})
 	}
 
+	disambiguateEnumConstNames(values)
+
 	// Set array field to use enum type
 	field.GoType = "[]" + enumTypeName
 	field.IsEnum = false // The field itself is not enum, but array of enums
@@ -583,7 +1041,7 @@ func parseJSONSchemaArrayEnum(
 	enum := &codegen.GoEnum{
 		Name:    enumTypeName,
 		Comment: fmt.Sprintf("valid %s item values", field.JSONTag),
-		Type:    "string", // All enums are string-based in Go for JSON compatibility
+		Type:    enumGoType(itemType),
 		Values:  values,
 	}
 
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// parseJSONSchemaMapField handles object fields without properties as Go maps. A schema-valued
+// additionalProperties types the map values, e.g. map[string]float64, or map[string]<Field>Value
+// for objects with their own properties. Absent or boolean additionalProperties keep map[string]any.
+func parseJSONSchemaMapField(
+	field codegen.GoField,
+	fieldDefMap map[string]any,
+	schemaType SchemaType,
+	names naming.Initialisms,
+) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
+	if pattern := propertyNamesPattern(fieldDefMap); pattern != "" {
+		field = withKeyPattern(field, pattern)
+	}
+
+	valueDef, ok := fieldDefMap["additionalProperties"].(map[string]any)
+	if !ok {
+		field.GoType = "map[string]any"
+
+		return field, nil, nil, nil, nil
+	}
+
+	valueField := codegen.GoField{
+		Name:      field.Name + "Value",
+		JSONTag:   field.JSONTag,
+		Comment:   fmt.Sprintf("value in %s map", field.JSONTag),
+		ExtraTags: make(map[string]string),
+	}
+	if desc, ok := valueDef["description"].(string); ok {
+		valueField.Comment = desc
+	}
+
+	if structName, isRef := refStructName(valueDef, names); isRef {
+		field.GoType = "map[string]" + structName
+
+		return field, nil, nil, nil, nil
+	}
+
+	if enumValues, isEnum := schemaEnumValues(valueDef); isEnum {
+		valueField, enumDef, err := parseJSONSchemaEnum(valueField, "", enumValues, enumTypeNameFor(valueField, valueDef, names), names)
+		if err != nil {
+			return field, nil, nil, nil, fmt.Errorf("failed to parse %s map values: %w", field.JSONTag, err)
+		}
+
+		applyEnumDescriptions(enumDef, enumValues, valueDef)
+		enumDef.Comment = enumComment(valueDef, enumDef.Comment)
+
+		field.GoType = "map[string]" + valueField.GoType
+
+		return field, []codegen.GoEnum{*enumDef}, nil, nil, nil
+	}
+
+	switch valueType := getFieldTypeFromSchema(valueDef); valueType {
+	case "object":
+		valueField, enums, valueStruct, nestedStructs, err := parseJSONSchemaObjectField(valueField, valueDef, schemaType, names, nil)
+		if err != nil {
+			return field, nil, nil, nil, fmt.Errorf("failed to parse %s map values: %w", field.JSONTag, err)
+		}
+
+		field.GoType = "map[string]" + valueField.GoType
+
+		return field, enums, valueStruct, nestedStructs, nil
+	case "array":
+		field.GoType = "map[string]" + parseJSONSchemaArray(valueField, valueDef).GoType
+	default:
+		field.GoType = "map[string]" + convertJSONSchemaTypeToGo(valueType)
+	}
+
+	return field, nil, nil, nil, nil
+}
+
+// withKeyPattern documents the propertyNames pattern of a map field and adds the validator rules
+// checking its keys, which become a validate tag with -constraint-tags.
+func withKeyPattern(field codegen.GoField, pattern string) codegen.GoField {
+	note := "keys match " + pattern
+	if field.Comment == "" {
+		field.Comment = "Keys match " + pattern
+	} else {
+		field.Comment = strings.TrimSuffix(field.Comment, ".") + " (" + note + ")"
+	}
+
+	field.Constraints = append(slices.Clone(field.Constraints), mapKeyRules(pattern)...)
+
+	return field
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+)
+
+// TestAdditionalPropertiesMaps tests map generation from additionalProperties
+func TestAdditionalPropertiesMaps(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"scores": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "number"},
+			},
+			"tags_by_lang": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			},
+			"ratings": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string", "enum": []any{"good", "bad"}},
+			},
+			"people": map[string]any{
+				"type": "object",
+				"additionalProperties": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"age": map[string]any{"type": "integer"},
+					},
+					"required": []any{"age"},
+				},
+			},
+			"extra": map[string]any{
+				"type":                 "object",
+				"additionalProperties": true,
+			},
+			"misc": map[string]any{"type": "object"},
+		},
+	}
+
+	fields, enums, structs, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.NoError(t, err)
+
+	fieldTypes := make(map[string]string)
+	for _, field := range fields {
+		fieldTypes[field.JSONTag] = field.GoType
+	}
+
+	assert.Equal(t, "map[string]float64", fieldTypes["scores"])
+	assert.Equal(t, "map[string][]string", fieldTypes["tags_by_lang"])
+	assert.Equal(t, "map[string]RatingsValueEnum", fieldTypes["ratings"])
+	assert.Equal(t, "map[string]PeopleValue", fieldTypes["people"])
+	assert.Equal(t, "map[string]any", fieldTypes["extra"])
+	assert.Equal(t, "map[string]any", fieldTypes["misc"])
+
+	require.Len(t, enums, 1)
+	assert.Equal(t, "RatingsValueEnum", enums[0].Name)
+
+	require.Len(t, structs, 1)
+	assert.Equal(t, "PeopleValue", structs[0].Name)
+	require.Len(t, structs[0].Fields, 1)
+	assert.Equal(t, "int", structs[0].Fields[0].GoType)
+}
+
+// TestPropertyNamesPattern tests that map key patterns are documented and become dive rules
+func TestPropertyNamesPattern(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"scores": map[string]any{
+				"type":                 "object",
+				"description":          "Scores by user.",
+				"propertyNames":        map[string]any{"pattern": "^[a-z]+$"},
+				"additionalProperties": map[string]any{"type": "number"},
+			},
+			"labels": map[string]any{
+				"type":          "object",
+				"propertyNames": map[string]any{"pattern": "^(en|de)$"},
+			},
+		},
+	}
+
+	fields, _, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+
+	byTag := make(map[string]codegen.GoField)
+	for _, field := range fields {
+		byTag[field.JSONTag] = field
+	}
+
+	assert.Equal(t, "map[string]float64", byTag["scores"].GoType)
+	assert.Equal(t, "Scores by user (keys match ^[a-z]+$)", byTag["scores"].Comment)
+	assert.Equal(t, []string{"dive", "keys", "regexp=^[a-z]+$", "endkeys"}, byTag["scores"].Constraints)
+
+	assert.Equal(t, "Keys match ^(en|de)$", byTag["labels"].Comment)
+	assert.Empty(t, byTag["labels"].Constraints, "Patterns with validator separators are only documented")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -13,10 +14,15 @@ import (
 )
 
 const (
-	// minimumFrontmatterParts is the minimum number of parts when splitting by --- delimiters.
+	// minimumFrontmatterParts is the number of parts when splitting by --- delimiters: the text before
+	// the frontmatter, the frontmatter and the template.
 	minimumFrontmatterParts = 3
 )
 
+// frontmatterDelimiter matches a --- line opening or closing the frontmatter. Dashes inside YAML
+// values or template lines like foo---bar are not delimiters.
+var frontmatterDelimiter = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
 // SchemaFieldOrders holds all extracted field order information.
 type SchemaFieldOrders struct {
 	InputFieldOrder        []string
@@ -57,19 +63,34 @@ func ParsePromptFile(filePath string) (*ast.PromptFile, error) {
 		return nil, fmt.Errorf("failed to read file %s: %w", absPath, err)
 	}
 
-	return ParsePromptContent(string(content), absPath)
+	promptFile, err := ParsePromptContent(string(content), absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Schemas referencing standalone .json files can only be resolved relative to a file on disk
+	if err := resolveExternalSchemas(promptFile); err != nil {
+		return nil, err
+	}
+
+	return promptFile, nil
 }
 
 // ParsePromptContent parses dotprompt content and returns a PromptFile.
+// Files saved with a UTF-8 byte order mark or Windows line endings parse like any other; only the
+// first block between --- lines is frontmatter and everything after it is the template.
 func ParsePromptContent(content, filename string) (*ast.PromptFile, error) {
-	// Split by frontmatter delimiters
-	parts := strings.Split(content, "---")
-	if len(parts) < minimumFrontmatterParts {
+	content = strings.TrimPrefix(content, "\uFEFF")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	// Split by frontmatter delimiters, which must be on a line of their own
+	delimiters := frontmatterDelimiter.FindAllStringIndex(content, minimumFrontmatterParts-1)
+	if len(delimiters) < minimumFrontmatterParts-1 {
 		return nil, errors.New("invalid dotprompt format: missing frontmatter delimiters")
 	}
 
 	// Parse YAML frontmatter
-	frontmatterContent := strings.TrimSpace(parts[1])
+	frontmatterContent := strings.TrimSpace(content[delimiters[0][1]:delimiters[1][0]])
 
 	var frontmatter ast.FrontmatterData
 
@@ -78,6 +99,10 @@ func ParsePromptContent(content, filename string) (*ast.PromptFile, error) {
 		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
 	}
 
+	if err := collectNamespacedKeys(frontmatterContent, &frontmatter); err != nil {
+		return nil, err
+	}
+
 	// Extract field orders for input and output schemas
 	fieldOrders, err := extractAllSchemaFieldOrders(frontmatterContent)
 	if err != nil {
@@ -85,8 +110,7 @@ func ParsePromptContent(content, filename string) (*ast.PromptFile, error) {
 	}
 
 	// Extract template content (everything after the second ---)
-	templateParts := parts[2:]
-	template := strings.TrimSpace(strings.Join(templateParts, "---"))
+	template := strings.TrimSpace(content[delimiters[1][1]:])
 
 	promptFile := &ast.PromptFile{
 		Filename:    filename,
@@ -138,6 +162,8 @@ func extractSchemaFieldOrder(yamlContent, schemaType, schemaKey string) ([]strin
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	resolveAliases(&node)
+
 	// Find the schema node (input.schema or output.schema)
 	schemaNode := findSchemaNode(&node, schemaType, schemaKey)
 	if schemaNode == nil {
@@ -222,6 +248,11 @@ func extractFieldNamesFromNode(node *yaml.Node) []string {
 
 // findPropertiesNode finds the "properties" node in a JSON schema.
 func findPropertiesNode(node *yaml.Node) *yaml.Node {
+	return findMappingNode(node, "properties")
+}
+
+// findMappingNode finds the mapping value stored under key in a YAML mapping node.
+func findMappingNode(node *yaml.Node, key string) *yaml.Node {
 	if node.Kind != yaml.MappingNode {
 		return nil
 	}
@@ -234,7 +265,7 @@ func findPropertiesNode(node *yaml.Node) *yaml.Node {
 		keyNode := node.Content[i]
 		valueNode := node.Content[i+1]
 
-		if keyNode.Value == "properties" && valueNode.Kind == yaml.MappingNode {
+		if keyNode.Value == key && valueNode.Kind == yaml.MappingNode {
 			return valueNode
 		}
 	}
@@ -270,6 +301,8 @@ func extractNestedSchemaFieldOrder(yamlContent, schemaType, schemaKey string) (m
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	resolveAliases(&node)
+
 	// Find the schema node (input.schema or output.schema)
 	schemaNode := findSchemaNode(&node, schemaType, schemaKey)
 	if schemaNode == nil {
@@ -293,8 +326,46 @@ func extractNestedFieldOrdersRecursive(node *yaml.Node, currentPath string, nest
 	propertiesNode := findPropertiesNode(node)
 	if propertiesNode != nil {
 		processPropertiesNodeRecursively(propertiesNode, currentPath, nestedOrders)
+
+		return
+	}
+
+	if isPicoschemaNode(node) {
+		processPicoschemaNodeRecursively(node, currentPath, nestedOrders)
+	}
+}
+
+// processPicoschemaNodeRecursively extracts the declared key order of nested Picoschema objects,
+// e.g. "address(object)": {street: string, city: string}.
+func processPicoschemaNodeRecursively(node *yaml.Node, currentPath string, nestedOrders map[string][]string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		valueNode := node.Content[i+1]
+
+		if keyNode.Kind != yaml.ScalarNode || valueNode.Kind != yaml.MappingNode {
+			continue
+		}
+
+		fieldName, _ := parsePicoschemaObjectKey(keyNode.Value)
+		nestedPath := buildNestedPath(currentPath, fieldName)
+
+		if fieldNames := extractFieldNamesFromNode(valueNode); len(fieldNames) > 0 {
+			nestedOrders[nestedPath] = fieldNames
+		}
+
+		processPicoschemaNodeRecursively(valueNode, nestedPath, nestedOrders)
 	}
-	// Note: Picoschema format doesn't currently support nested objects
+}
+
+// isPicoschemaNode checks if a YAML mapping node is a Picoschema object rather than a JSON Schema.
+func isPicoschemaNode(node *yaml.Node) bool {
+	for i := 0; i < len(node.Content); i += 2 {
+		if key := node.Content[i].Value; key == "type" || key == "properties" {
+			return false
+		}
+	}
+
+	return true
 }
 
 // processPropertiesNodeRecursively processes properties node and extracts nested field orders.
@@ -322,10 +393,19 @@ func processPropertiesNodeRecursively(propertiesNode *yaml.Node, currentPath str
 			if isObjectTypeNode(valueNode) {
 				extractNestedFieldOrdersRecursive(valueNode, nestedPath, nestedOrders)
 			}
+
+			// Object array items are recorded under the array field path with an ArrayItemsPathSuffix
+			if itemsNode := findMappingNode(valueNode, "items"); itemsNode != nil && isObjectTypeNode(itemsNode) {
+				extractNestedFieldOrdersRecursive(itemsNode, nestedPath+ArrayItemsPathSuffix, nestedOrders)
+			}
 		}
 	}
 }
 
+// ArrayItemsPathSuffix marks the items of an array field in nested field order paths, so the item
+// fields of "results" are keyed "results[]" and an object inside each item "results[].meta".
+const ArrayItemsPathSuffix = "[]"
+
 // buildNestedPath constructs the nested path for a field.
 func buildNestedPath(currentPath, fieldName string) string {
 	if currentPath == "" {
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParsePromptContentLayout tests that encoding details and --- inside the template do not change the parse
+func TestParsePromptContentLayout(t *testing.T) {
+	const want = "{{role \"user\"}}\nSummarize {{topic}}.\n\n---\n\nKeep it short."
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "LF",
+			content: "---\ninput:\n  schema:\n    topic: string\n---\n" + want + "\n",
+		},
+		{
+			name:    "CRLF",
+			content: "---\r\ninput:\r\n  schema:\r\n    topic: string\r\n---\r\n{{role \"user\"}}\r\nSummarize {{topic}}.\r\n\r\n---\r\n\r\nKeep it short.\r\n",
+		},
+		{
+			name:    "byte order mark",
+			content: "\uFEFF---\ninput:\n  schema:\n    topic: string\n---\n" + want + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promptFile, err := ParsePromptContent(tt.content, "summary.prompt")
+			require.NoError(t, err)
+
+			assert.Equal(t, want, promptFile.Template)
+			assert.Equal(t, map[string]any{"topic": "string"}, promptFile.Frontmatter.Input.Schema)
+			assert.Equal(t, []string{"topic"}, promptFile.InputFieldOrder)
+		})
+	}
+
+	t.Run("missing frontmatter", func(t *testing.T) {
+		_, err := ParsePromptContent("---\nmodel: test\n", "summary.prompt")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing frontmatter delimiters")
+	})
+}
+
+// TestParsePromptContentDelimiterLines tests that only --- lines delimit the frontmatter
+func TestParsePromptContentDelimiterLines(t *testing.T) {
+	promptFile, err := ParsePromptContent(`---
+model: vendor/model---v2
+input:
+  schema:
+    type: object
+    properties:
+      range:
+        type: string
+        description: "dates like 2024-01---2024-03"
+        pattern: "^[0-9-]+---[0-9-]+$"
+---   
+Compare foo---bar for {{range}}.
+---
+Done.`, "compare.prompt")
+	require.NoError(t, err)
+
+	assert.Equal(t, "vendor/model---v2", promptFile.Frontmatter.Model)
+	assert.Equal(t, "Compare foo---bar for {{range}}.\n---\nDone.", promptFile.Template)
+
+	properties := promptFile.Frontmatter.Input.Schema.(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "dates like 2024-01---2024-03", properties["range"].(map[string]any)["description"])
+	assert.Equal(t, []string{"range"}, promptFile.InputFieldOrder)
+}
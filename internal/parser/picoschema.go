@@ -42,48 +42,106 @@ func IsPicoschema(schema any) bool {
 	return !hasType && !hasProperties
 }
 
+// ParsePicoschemaWithNestedFieldOrder parses Picoschema with nested field order preservation.
+func ParsePicoschemaWithNestedFieldOrder(
+	schema any,
+	requiredFields []string,
+	schemaType SchemaType,
+	names naming.Initialisms,
+	fieldOrder []string,
+	nestedFieldOrder map[string][]string,
+) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
+	return parsePicoschemaWithFieldOrder(schema, requiredFields, schemaType, names, fieldOrder, nestedFieldOrder)
+}
+
 // parsePicoschemaWithFieldOrder parses Picoschema format with preserved field order.
 func parsePicoschemaWithFieldOrder(
 	schema any,
 	requiredFields []string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
-) ([]codegen.GoField, []codegen.GoEnum, error) {
+	nestedFieldOrder map[string][]string,
+) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
 	schemaMap, ok := schema.(map[string]any)
 	if !ok {
-		return nil, nil, errors.New("schema must be an object")
+		return nil, nil, nil, errors.New("schema must be an object")
 	}
 
-	var (
-		fields []codegen.GoField
-		enums  []codegen.GoEnum
-	)
-
 	// Build required fields set and ordered field names using shared functions
 	requiredSet := buildRequiredFieldsSet(schemaMap, requiredFields, schemaType)
 	fieldNames := buildOrderedFieldNames(schemaMap, fieldOrder)
 
+	scope := picoschemaScope{schemaType: schemaType, names: names, nestedFieldOrder: nestedFieldOrder}
+
+	return parsePicoschemaFields(schemaMap, fieldNames, requiredSet, scope)
+}
+
+// parsePicoschemaFields parses the fields of a Picoschema object in the given order.
+func parsePicoschemaFields(
+	schemaMap map[string]any,
+	fieldNames []string,
+	requiredSet map[string]bool,
+	scope picoschemaScope,
+) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
+	var (
+		fields  []codegen.GoField
+		enums   []codegen.GoEnum
+		structs []codegen.GoStruct
+	)
+
 	// Process fields in sorted order
 	for _, fieldName := range fieldNames {
 		fieldDef := schemaMap[fieldName]
+		fieldKey, deprecated := cutPicoschemaDeprecated(fieldName)
+
+		if objectDef, isObject := fieldDef.(map[string]any); isObject {
+			field, nestedEnums, nestedStructs, err := parsePicoschemaObjectField(fieldKey, objectDef, scope)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to parse field %s: %w", fieldName, err)
+			}
+
+			field.Deprecated = deprecated
+			field.Required = requiredSet[fieldName]
+			fields = append(fields, field)
+			enums = append(enums, nestedEnums...)
+			structs = append(structs, nestedStructs...)
+
+			continue
+		}
 
 		field, enumDef, err := parsePicoschemaField(
-			fieldName,
+			fieldKey,
 			fieldDef,
 			requiredSet[fieldName],
-			schemaType,
+			scope.schemaType,
+			scope.names,
 		)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse field %s: %w", fieldName, err)
+			return nil, nil, nil, fmt.Errorf("failed to parse field %s: %w", fieldName, err)
 		}
 
+		field.Deprecated = deprecated
+		field.Required = requiredSet[fieldName]
 		fields = append(fields, field)
 		if enumDef != nil {
 			enums = append(enums, *enumDef)
 		}
 	}
 
-	return fields, enums, nil
+	return fields, enums, structs, nil
+}
+
+// cutPicoschemaDeprecated removes the (deprecated) marker from a Picoschema key,
+// e.g. "score(deprecated)" or "tags(array)(deprecated)", and reports whether it was present.
+func cutPicoschemaDeprecated(fieldName string) (string, bool) {
+	const marker = "(deprecated)"
+
+	if !strings.Contains(fieldName, marker) {
+		return fieldName, false
+	}
+
+	return strings.Replace(fieldName, marker, "", 1), true
 }
 
 // parsePicoschemaField parses a single field in Picoschema format.
@@ -92,24 +150,25 @@ func parsePicoschemaField(
 	fieldDef any,
 	isRequired bool,
 	schemaType SchemaType,
+	names naming.Initialisms,
 ) (codegen.GoField, *codegen.GoEnum, error) {
 	fieldStr, ok := fieldDef.(string)
 	if !ok {
 		return codegen.GoField{}, nil, errors.New("picoschema field must be a string")
 	}
 
-	field := createBasePicoschemaField(fieldName)
+	field := createBasePicoschemaField(fieldName, names)
 	typeDescPart, description := parseFieldDefinition(fieldStr)
 	field.Comment = description
 
 	// Handle enum definitions
 	if strings.Contains(typeDescPart, "(enum") {
-		return handlePicoschemaEnum(field, typeDescPart, isRequired, schemaType)
+		return handlePicoschemaEnum(field, typeDescPart, isRequired, schemaType, names)
 	}
 
 	// Handle array definitions
 	if strings.Contains(fieldName, "(array)") {
-		return handlePicoschemaArray(field, fieldName, fieldStr, typeDescPart)
+		return handlePicoschemaArray(field, fieldName, fieldStr, typeDescPart, names)
 	}
 
 	// Handle simple types
@@ -117,9 +176,9 @@ func parsePicoschemaField(
 }
 
 // createBasePicoschemaField creates the base field structure.
-func createBasePicoschemaField(fieldName string) codegen.GoField {
+func createBasePicoschemaField(fieldName string, names naming.Initialisms) codegen.GoField {
 	field := codegen.GoField{
-		Name:      naming.SchemaFieldToGoField(fieldName),
+		Name:      names.SchemaFieldToGoField(fieldName),
 		JSONTag:   fieldName,
 		ExtraTags: make(map[string]string),
 	}
@@ -127,7 +186,7 @@ func createBasePicoschemaField(fieldName string) codegen.GoField {
 	// Check for optional field marker
 	if strings.HasSuffix(fieldName, "?") {
 		field.JSONTag = strings.TrimSuffix(fieldName, "?")
-		field.Name = naming.SchemaFieldToGoField(field.JSONTag)
+		field.Name = names.SchemaFieldToGoField(field.JSONTag)
 	}
 
 	return field
@@ -205,8 +264,9 @@ func handlePicoschemaEnum(
 	typeDescPart string,
 	isRequired bool,
 	schemaType SchemaType,
+	names naming.Initialisms,
 ) (codegen.GoField, *codegen.GoEnum, error) {
-	field, enumDef, err := parsePicoschemaEnum(field, typeDescPart)
+	field, enumDef, err := parsePicoschemaEnum(field, typeDescPart, names)
 	if err != nil {
 		return field, enumDef, err
 	}
@@ -214,6 +274,7 @@ func handlePicoschemaEnum(
 	// For output schemas, make non-required enum fields pointers
 	if schemaType == SchemaTypeOutput && !isRequired {
 		field.GoType = "*" + field.GoType
+		field.IsPointer = true
 	}
 
 	return field, enumDef, err
@@ -225,10 +286,11 @@ func handlePicoschemaArray(
 	fieldName string,
 	fieldStr string,
 	typeDescPart string,
+	names naming.Initialisms,
 ) (codegen.GoField, *codegen.GoEnum, error) {
 	// Clean up field name and JSON tag by removing (array) suffix
 	cleanFieldName := strings.Replace(fieldName, "(array)", "", 1)
-	field.Name = naming.SchemaFieldToGoField(cleanFieldName)
+	field.Name = names.SchemaFieldToGoField(cleanFieldName)
 	field.JSONTag = cleanFieldName
 
 	// For array parsing we need the full field string split by comma
@@ -267,6 +329,7 @@ func handleSimplePicoschemaType(
 func parsePicoschemaEnum(
 	field codegen.GoField,
 	typeDescPart string,
+	names naming.Initialisms,
 ) (codegen.GoField, *codegen.GoEnum, error) {
 	// Extract enum values from: "string(enum): [value1, value2], description"
 	re := regexp.MustCompile(`(\w+)\(enum[^)]*\):\s*\[([^\]]+)\]`)
@@ -288,20 +351,22 @@ func parsePicoschemaEnum(
 
 	for _, valueStr := range valueStrs {
 		value := strings.TrimSpace(valueStr)
-		constName := naming.EnumValueToConstName(enumTypeName, value)
+		constName := names.EnumValueToConstName(enumTypeName, value)
 		enumValues = append(enumValues, codegen.EnumValue{
 			ConstName: constName,
 			Value:     value,
 		})
 	}
 
+	disambiguateEnumConstNames(enumValues)
+
 	field.GoType = enumTypeName
 	field.IsEnum = true
 	field.IsPointer = strings.HasPrefix(field.GoType, "*")
 
 	enum := &codegen.GoEnum{
 		Name:    enumTypeName,
-		Comment: fmt.Sprintf("valid %s values", field.JSONTag),
+		Comment: descriptionOr(field.Comment, fmt.Sprintf("valid %s values", field.JSONTag)),
 		Type:    convertPicoschemaTypeToGo(baseType),
 		Values:  enumValues,
 	}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// picoschemaScope carries the state needed while parsing nested Picoschema objects.
+type picoschemaScope struct {
+	schemaType       SchemaType
+	names            naming.Initialisms
+	nestedFieldOrder map[string][]string // declared field order keyed by dotted object path
+	path             string              // dotted path of the object being parsed, empty at the root
+	parentStructName string              // prefix for nested struct names, empty at the root
+}
+
+// parsePicoschemaObjectKey splits a nested object key such as "address?(object, mailing address)"
+// into the property name and its description.
+func parsePicoschemaObjectKey(key string) (string, string) {
+	name, typeDesc, hasTypeDesc := strings.Cut(key, "(")
+	name = strings.TrimSuffix(strings.TrimSpace(name), "?")
+
+	if !hasTypeDesc {
+		return name, ""
+	}
+
+	_, description, _ := strings.Cut(strings.TrimSuffix(typeDesc, ")"), ",")
+
+	return name, strings.TrimSpace(description)
+}
+
+// parsePicoschemaObjectField parses a nested Picoschema object into a struct field, returning the
+// nested struct followed by any structs declared deeper inside it.
+func parsePicoschemaObjectField(
+	key string,
+	objectDef map[string]any,
+	scope picoschemaScope,
+) (codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
+	name, description := parsePicoschemaObjectKey(key)
+	structName := scope.parentStructName + scope.names.SchemaFieldToGoField(name)
+
+	if description == "" {
+		description = name
+	}
+
+	nestedScope := picoschemaScope{
+		schemaType:       scope.schemaType,
+		names:            scope.names,
+		nestedFieldOrder: scope.nestedFieldOrder,
+		path:             buildNestedPath(scope.path, name),
+		parentStructName: structName,
+	}
+
+	requiredSet := buildRequiredFieldsSet(objectDef, nil, scope.schemaType)
+	fieldNames := buildOrderedFieldNames(objectDef, scope.nestedFieldOrder[nestedScope.path])
+
+	fields, enums, deeplyNestedStructs, err := parsePicoschemaFields(objectDef, fieldNames, requiredSet, nestedScope)
+	if err != nil {
+		return codegen.GoField{}, nil, nil, err
+	}
+
+	field := codegen.GoField{
+		Name:      scope.names.SchemaFieldToGoField(name),
+		JSONTag:   name,
+		Comment:   description,
+		ExtraTags: make(map[string]string),
+	}
+	field = updateFieldForStruct(field, structName)
+
+	structs := append([]codegen.GoStruct{*createNestedStruct(structName, description, fields)}, deeplyNestedStructs...)
+
+	return field, enums, structs, nil
+}
@@ -0,0 +1,316 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// definitionsKeys are the root schema keys holding reusable definitions for local $ref pointers.
+var definitionsKeys = []string{"definitions", "$defs"} //nolint:gochecknoglobals // read-only lookup table
+
+// rootRef is the $ref pointer to the root schema itself.
+const rootRef = "#"
+
+// RootRefType is the Go type of fields referencing the root schema with $ref "#". The parser does
+// not name the root struct, so callers replace it with the name of the struct they generate.
+const RootRefType = "$root"
+
+// recursiveRefKey marks $ref nodes leading back to the struct they are declared in. Their fields
+// become pointers, or slices and maps of pointers, so the recursive type can be declared in Go.
+const recursiveRefKey = "$recursive"
+
+// schemaRefs holds the object definitions referenced from a schema. Each of them is generated
+// once as a shared struct that every referencing field reuses.
+type schemaRefs struct {
+	definitions map[string]map[string]any // definition name -> definition, from definitions and $defs
+	shared      map[string]string         // referenced object definition name -> Go struct name
+	names       naming.Initialisms        // initialisms of the generated struct names
+}
+
+// resolveLocalRefs rewrites local $ref pointers in a JSON Schema. References to object
+// definitions are kept and recorded as shared structs, while references to other definitions
+// (enums, primitives, arrays) are replaced by a copy of the definition.
+func resolveLocalRefs(schemaMap map[string]any, names naming.Initialisms) (map[string]any, *schemaRefs, error) {
+	refs := &schemaRefs{
+		definitions: make(map[string]map[string]any),
+		shared:      make(map[string]string),
+		names:       names,
+	}
+
+	for _, key := range definitionsKeys {
+		definitions, _ := schemaMap[key].(map[string]any)
+		for name, definition := range definitions {
+			if definitionMap, ok := definition.(map[string]any); ok {
+				refs.definitions[name] = definitionMap
+			}
+		}
+	}
+
+	resolved, err := refs.resolve(schemaMap, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolvedMap, _ := resolved.(map[string]any)
+	refs.markRecursiveRefs(resolvedMap)
+
+	return resolvedMap, refs, nil
+}
+
+// resolve walks a schema node, resolving every $ref it contains. The stack of definitions being
+// inlined guards against self-referencing non-object definitions.
+func (r *schemaRefs) resolve(node any, inlining []string) (any, error) {
+	switch typed := node.(type) {
+	case map[string]any:
+		if ref, ok := typed["$ref"].(string); ok {
+			return r.resolveRef(ref, inlining)
+		}
+
+		resolved := make(map[string]any, len(typed))
+		for key, value := range typed {
+			resolvedValue, err := r.resolve(value, inlining)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved[key] = resolvedValue
+		}
+
+		return resolved, nil
+	case []any:
+		resolved := make([]any, len(typed))
+		for i, value := range typed {
+			resolvedValue, err := r.resolve(value, inlining)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved[i] = resolvedValue
+		}
+
+		return resolved, nil
+	default:
+		return node, nil
+	}
+}
+
+// resolveRef resolves a single local $ref pointer such as "#/definitions/Address".
+func (r *schemaRefs) resolveRef(ref string, inlining []string) (any, error) {
+	if ref == rootRef {
+		return map[string]any{"$ref": ref}, nil
+	}
+
+	name, ok := localRefName(ref)
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only #, local #/definitions/ and #/$defs/ references are supported", ref)
+	}
+
+	definition, ok := r.definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("unresolvable $ref %q: no definition named %q", ref, name)
+	}
+
+	if isObjectDefinition(definition) {
+		r.shared[name] = r.names.SchemaFieldToGoField(name)
+
+		return map[string]any{"$ref": ref}, nil
+	}
+
+	for _, inlined := range inlining {
+		if inlined == name {
+			return nil, fmt.Errorf("circular $ref %q", ref)
+		}
+	}
+
+	return r.resolve(definition, append(inlining, name))
+}
+
+// localRefName extracts the definition name from a local $ref pointer.
+func localRefName(ref string) (string, bool) {
+	for _, key := range definitionsKeys {
+		if name, ok := strings.CutPrefix(ref, "#/"+key+"/"); ok && name != "" {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// isObjectDefinition checks if a definition describes an object with properties.
+func isObjectDefinition(definition map[string]any) bool {
+	_, hasProperties := definition["properties"].(map[string]any)
+
+	return hasProperties
+}
+
+// refStructName returns the shared struct name for an object $ref, if the schema node is one.
+// Recursive references are returned as a pointer to the struct.
+func refStructName(fieldDefMap map[string]any, names naming.Initialisms) (string, bool) {
+	ref, ok := fieldDefMap["$ref"].(string)
+	if !ok {
+		return "", false
+	}
+
+	pointer := ""
+	if recursive, _ := fieldDefMap[recursiveRefKey].(bool); recursive {
+		pointer = "*"
+	}
+
+	if ref == rootRef {
+		return pointer + RootRefType, true
+	}
+
+	name, ok := localRefName(ref)
+	if !ok {
+		return "", false
+	}
+
+	return pointer + names.SchemaFieldToGoField(name), true
+}
+
+// markRecursiveRefs flags the object $ref nodes whose target leads back, directly or through
+// other references (A -> B -> A), to the root schema or definition declaring them.
+func (r *schemaRefs) markRecursiveRefs(root map[string]any) {
+	declared := map[string][]map[string]any{rootRef: collectRefNodes(root, true)}
+	for name := range r.shared {
+		declared[name] = collectRefNodes(resolvedDefinition(root, name), false)
+	}
+
+	for owner, refNodes := range declared {
+		for _, refNode := range refNodes {
+			if target := refTarget(refNode); target == owner || refReaches(declared, target, owner) {
+				refNode[recursiveRefKey] = true
+			}
+		}
+	}
+}
+
+// refReaches reports whether the references declared by from lead to the schema named to.
+func refReaches(declared map[string][]map[string]any, from, to string) bool {
+	visited := map[string]bool{from: true}
+	pending := []string{from}
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for _, refNode := range declared[current] {
+			target := refTarget(refNode)
+			if target == to {
+				return true
+			}
+
+			if !visited[target] {
+				visited[target] = true
+				pending = append(pending, target)
+			}
+		}
+	}
+
+	return false
+}
+
+// collectRefNodes returns the $ref nodes inside a resolved schema. The definitions of the root
+// schema are skipped, they declare their own references.
+func collectRefNodes(node any, skipDefinitions bool) []map[string]any {
+	var refNodes []map[string]any
+
+	switch typed := node.(type) {
+	case map[string]any:
+		if _, ok := typed["$ref"].(string); ok {
+			return []map[string]any{typed}
+		}
+
+		for key, value := range typed {
+			if skipDefinitions && slices.Contains(definitionsKeys, key) {
+				continue
+			}
+
+			refNodes = append(refNodes, collectRefNodes(value, false)...)
+		}
+	case []any:
+		for _, value := range typed {
+			refNodes = append(refNodes, collectRefNodes(value, false)...)
+		}
+	}
+
+	return refNodes
+}
+
+// refTarget returns the definition name a resolved $ref node points at, or rootRef.
+func refTarget(refNode map[string]any) string {
+	ref, _ := refNode["$ref"].(string)
+	if name, ok := localRefName(ref); ok {
+		return name
+	}
+
+	return ref
+}
+
+// handleRefField points a field at the shared struct generated for its $ref.
+func handleRefField(
+	field codegen.GoField,
+	structName string,
+) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
+	return updateFieldForStruct(field, structName), nil, nil, nil, nil
+}
+
+// parseSharedDefinitions generates the shared structs for all referenced object definitions.
+// Definitions are parsed with their own required list and the schema type of the schema
+// referencing them, so their fields follow the same pointer, readOnly and -all-required rules as
+// inline objects.
+func (r *schemaRefs) parseSharedDefinitions(
+	schemaMap map[string]any,
+	schemaType SchemaType,
+	names naming.Initialisms,
+) ([]codegen.GoEnum, []codegen.GoStruct, error) {
+	definitionNames := make([]string, 0, len(r.shared))
+	for name := range r.shared {
+		definitionNames = append(definitionNames, name)
+	}
+	sort.Strings(definitionNames)
+
+	var (
+		enums   []codegen.GoEnum
+		structs []codegen.GoStruct
+	)
+
+	for _, name := range definitionNames {
+		definition := resolvedDefinition(schemaMap, name)
+
+		field := codegen.GoField{Name: r.shared[name], JSONTag: name, Comment: name}
+		if desc, ok := definition["description"].(string); ok {
+			field.Comment = desc
+		}
+
+		_, defEnums, defStruct, nestedStructs, err := parseJSONSchemaObjectField(field, definition, schemaType, names, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse definition %s: %w", name, err)
+		}
+
+		enums = append(enums, defEnums...)
+		if defStruct != nil {
+			structs = append(structs, *defStruct)
+		}
+		structs = append(structs, nestedStructs...)
+	}
+
+	return enums, structs, nil
+}
+
+// resolvedDefinition looks up a definition by name in a schema whose refs were already resolved.
+func resolvedDefinition(schemaMap map[string]any, name string) map[string]any {
+	for _, key := range definitionsKeys {
+		definitions, _ := schemaMap[key].(map[string]any)
+		if definition, ok := definitions[name].(map[string]any); ok {
+			return definition
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLocalRefsShareOneStruct tests that fields referencing the same definition reuse one struct
+func TestLocalRefsShareOneStruct(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"billing":  map[string]any{"$ref": "#/definitions/Address"},
+			"shipping": map[string]any{"$ref": "#/definitions/Address"},
+			"stops":    map[string]any{"type": "array", "items": map[string]any{"$ref": "#/definitions/Address"}},
+			"total":    map[string]any{"$ref": "#/$defs/money_amount"},
+			"currency": map[string]any{"$ref": "#/$defs/Currency"},
+		},
+		"required": []any{"billing", "shipping", "total", "currency"},
+		"definitions": map[string]any{
+			"Address": map[string]any{
+				"type":        "object",
+				"description": "postal address",
+				"properties": map[string]any{
+					"street": map[string]any{"type": "string"},
+					"city":   map[string]any{"type": "string"},
+				},
+				"required": []any{"street"},
+			},
+		},
+		"$defs": map[string]any{
+			"money_amount": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"cents":    map[string]any{"type": "integer"},
+					"currency": map[string]any{"$ref": "#/$defs/Currency"},
+				},
+				"required": []any{"cents", "currency"},
+			},
+			"Currency": map[string]any{"type": "string", "enum": []any{"usd", "eur"}},
+		},
+	}
+
+	fields, enums, structs, err := ParseSchemaWithStructs(schema, []string{"billing", "shipping", "total", "currency"}, SchemaTypeOutput)
+	require.NoError(t, err)
+
+	fieldTypes := make(map[string]string)
+	for _, field := range fields {
+		fieldTypes[field.Name] = field.GoType
+	}
+
+	assert.Equal(t, "Address", fieldTypes["Billing"])
+	assert.Equal(t, "Address", fieldTypes["Shipping"])
+	assert.Equal(t, "[]Address", fieldTypes["Stops"])
+	assert.Equal(t, "MoneyAmount", fieldTypes["Total"])
+	assert.Equal(t, "CurrencyEnum", fieldTypes["Currency"], "Non-object definitions are inlined")
+
+	require.Len(t, structs, 2, "Each referenced definition is generated exactly once")
+	assert.Equal(t, "Address", structs[0].Name)
+	assert.Equal(t, []string{"Address represents postal address"}, structs[0].Comments)
+	assert.Equal(t, "MoneyAmount", structs[1].Name)
+
+	var enumNames []string
+	for _, enum := range enums {
+		enumNames = append(enumNames, enum.Name)
+	}
+	assert.Contains(t, enumNames, "CurrencyEnum")
+}
+
+// TestUnresolvableRefReturnsError tests that a $ref without a matching definition fails parsing
+func TestUnresolvableRefReturnsError(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"owner": map[string]any{"$ref": "#/definitions/Person"},
+		},
+	}
+
+	_, _, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unresolvable $ref "#/definitions/Person"`)
+
+	schema["properties"] = map[string]any{"owner": map[string]any{"$ref": "https://example.com/person.json"}}
+
+	_, _, _, err = ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported $ref")
+}
+
+// TestRecursiveRefsUsePointers tests that self and cyclic references become pointer fields
+func TestRecursiveRefsUsePointers(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"label":    map[string]any{"type": "string"},
+			"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#"}},
+			"owner":    map[string]any{"$ref": "#/definitions/Person"},
+			"address":  map[string]any{"$ref": "#/definitions/Address"},
+		},
+		"required": []any{"label", "children", "owner", "address"},
+		"definitions": map[string]any{
+			"Person": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+					"team": map[string]any{"$ref": "#/definitions/Team"},
+				},
+				"required": []any{"name", "team"},
+			},
+			"Team": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"lead": map[string]any{"$ref": "#/definitions/Person"},
+				},
+				"required": []any{"lead"},
+			},
+			"Address": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string"}},
+			},
+		},
+	}
+
+	fields, _, structs, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+	require.NoError(t, err)
+
+	fieldTypes := make(map[string]string)
+	for _, field := range fields {
+		fieldTypes[field.Name] = field.GoType
+	}
+
+	assert.Equal(t, "[]*"+RootRefType, fieldTypes["Children"], "Root references are bound to the root struct by the generator")
+	assert.Equal(t, "Person", fieldTypes["Owner"], "References that do not lead back stay values")
+	assert.Equal(t, "Address", fieldTypes["Address"])
+
+	structFields := make(map[string]string)
+	for _, goStruct := range structs {
+		for _, field := range goStruct.Fields {
+			structFields[goStruct.Name+"."+field.Name] = field.GoType
+		}
+	}
+
+	assert.Equal(t, "*Team", structFields["Person.Team"], "References of a cycle are pointers even when required")
+	assert.Equal(t, "*Person", structFields["Team.Lead"])
+
+	var structNames []string
+	for _, goStruct := range structs {
+		structNames = append(structNames, goStruct.Name)
+	}
+	assert.ElementsMatch(t, []string{"Person", "Team", "Address"}, structNames, "Each recursive type is generated exactly once")
+}
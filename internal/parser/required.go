@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"sort"
+)
+
+// UnknownRequiredFields returns the required field names that are not properties of their schema,
+// as dotted paths such as "habitt" or "address.zip". The root of the schema is checked against
+// required, which covers the frontmatter fallback list; nested JSON Schema objects are checked
+// against their own required arrays.
+func UnknownRequiredFields(schema any, required []string) []string {
+	schemaMap, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var unknown []string
+
+	collectUnknownRequired(schemaMap, required, "", &unknown)
+
+	return unknown
+}
+
+// collectUnknownRequired checks one object schema and recurses into its nested objects.
+func collectUnknownRequired(schemaMap map[string]any, required []string, path string, unknown *[]string) {
+	properties, isJSONSchema := schemaMap["properties"].(map[string]any)
+	if !isJSONSchema && schemaMap["type"] == nil {
+		properties = picoschemaPropertyNames(schemaMap)
+	}
+
+	for _, name := range required {
+		if _, exists := properties[name]; !exists {
+			*unknown = append(*unknown, path+name)
+		}
+	}
+
+	if !isJSONSchema {
+		return
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyMap, ok := properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if items, ok := propertyMap["items"].(map[string]any); ok {
+			propertyMap, name = items, name+ArrayItemsPathSuffix
+		}
+
+		if _, hasProperties := propertyMap["properties"].(map[string]any); hasProperties {
+			collectUnknownRequired(propertyMap, stringList(propertyMap["required"]), path+name+".", unknown)
+		}
+	}
+}
+
+// picoschemaPropertyNames returns the property names of a Picoschema object: its raw keys and the
+// names without optional, type and deprecation markers.
+func picoschemaPropertyNames(schemaMap map[string]any) map[string]any {
+	properties := make(map[string]any)
+	for key, value := range schemaMap {
+		properties[key] = value
+
+		fieldKey, _ := cutPicoschemaDeprecated(key)
+		name, _ := parsePicoschemaObjectKey(fieldKey)
+		properties[name] = value
+	}
+
+	return properties
+}
+
+// stringList returns the strings of a decoded YAML or JSON array.
+func stringList(value any) []string {
+	items, _ := value.([]any)
+
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+
+	return strs
+}
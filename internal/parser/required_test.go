@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUnknownRequiredFields tests that required names missing from their schema are reported with their path
+func TestUnknownRequiredFields(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"habit": map[string]any{"type": "string"},
+			"address": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string"}},
+				"required":   []any{"city", "zip"},
+			},
+			"steps": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"name": map[string]any{"type": "string"}},
+					"required":   []any{"title"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"habitt", "address.zip", "steps[].title"}, UnknownRequiredFields(schema, []string{"habit", "habitt"}))
+	assert.Equal(t, []string{"address.zip", "steps[].title"}, UnknownRequiredFields(schema, nil), "Nested required arrays are checked on their own")
+
+	picoschema := map[string]any{
+		"name":              "string",
+		"nickname?":         "string, optional",
+		"tags(array)":       "string",
+		"score(deprecated)": "number",
+	}
+	assert.Empty(t, UnknownRequiredFields(picoschema, []string{"name", "nickname", "tags", "score"}))
+	assert.Equal(t, []string{"nam"}, UnknownRequiredFields(picoschema, []string{"nam"}))
+}
@@ -2,10 +2,12 @@ package parser
 
 import (
 	"errors"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
 )
 
 // SchemaType represents whether the schema is for input or output.
@@ -14,22 +16,29 @@ type SchemaType string
 const (
 	SchemaTypeInput  SchemaType = "input"
 	SchemaTypeOutput SchemaType = "output"
+
+	// SchemaTypeRequiredOutput is an output schema whose properties are all treated as required,
+	// so only nullable fields become pointers
+	SchemaTypeRequiredOutput SchemaType = "required-output"
 )
 
-// ParseSchemaWithStructs parses a schema and returns Go fields, enums, and nested structs.
+// ParseSchemaWithStructs parses a schema and returns Go fields, enums, and nested structs, named
+// with the default initialisms.
 func ParseSchemaWithStructs(
 	schema any,
 	requiredFields []string,
 	schemaType SchemaType,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
-	return ParseSchemaWithStructsAndFieldOrder(schema, requiredFields, schemaType, nil)
+	return ParseSchemaWithStructsAndFieldOrder(schema, requiredFields, schemaType, naming.DefaultSet(), nil)
 }
 
-// ParseSchemaWithStructsAndFieldOrder parses a schema with preserved field order.
+// ParseSchemaWithStructsAndFieldOrder parses a schema with preserved field order. Generated names
+// write the words of names in upper case.
 func ParseSchemaWithStructsAndFieldOrder(
 	schema any,
 	requiredFields []string,
 	schemaType SchemaType,
+	names naming.Initialisms,
 	fieldOrder []string,
 ) ([]codegen.GoField, []codegen.GoEnum, []codegen.GoStruct, error) {
 	if schema == nil {
@@ -38,16 +47,49 @@ func ParseSchemaWithStructsAndFieldOrder(
 
 	// Try to detect schema format and parse accordingly
 	if IsPicoschema(schema) {
-		fields, enums, err := parsePicoschemaWithFieldOrder(schema, requiredFields, schemaType, fieldOrder)
-
-		return fields, enums, nil, err // Picoschema doesn't support nested structs yet
+		return parsePicoschemaWithFieldOrder(schema, requiredFields, schemaType, names, fieldOrder, nil)
 	} else if IsJSONSchema(schema) {
-		return parseJSONSchemaWithStructsAndFieldOrder(schema, requiredFields, schemaType, fieldOrder)
+		return parseJSONSchemaWithStructsAndFieldOrder(schema, requiredFields, schemaType, names, fieldOrder)
 	}
 
 	return nil, nil, nil, errors.New("unsupported schema format")
 }
 
+// SchemaDescription returns the root-level description of a JSON Schema, or "" for Picoschema
+// and schemas without one.
+func SchemaDescription(schema any) string {
+	if !IsJSONSchema(schema) {
+		return ""
+	}
+
+	schemaMap, _ := schema.(map[string]any)
+	description, _ := schemaMap["description"].(string)
+
+	return strings.TrimSpace(description)
+}
+
+// SchemaTitleName returns the Go type name for the root-level title of a JSON Schema, e.g.
+// "Classification Result" becomes ClassificationResult, or "" for Picoschema and schemas without one.
+func SchemaTitleName(schema any, names naming.Initialisms) string {
+	if !IsJSONSchema(schema) {
+		return ""
+	}
+
+	schemaMap, _ := schema.(map[string]any)
+
+	return titleTypeName(schemaMap, names)
+}
+
+// titleTypeName returns the Go type name for the title of a schema object, or "" without a title.
+func titleTypeName(schemaMap map[string]any, names naming.Initialisms) string {
+	title, _ := schemaMap["title"].(string)
+	if strings.TrimSpace(title) == "" {
+		return ""
+	}
+
+	return names.SchemaFieldToGoField(strings.TrimSpace(title))
+}
+
 // detectPicoschemaFieldType determines the type of a Picoschema field string (enum, array, or
 // simple).
 func detectPicoschemaFieldType(fieldStr string) string {
@@ -77,19 +119,7 @@ func buildOrderedFieldNames(schemaFields map[string]any, fieldOrder []string) []
 		}
 
 		// Add any remaining fields not in the preserved order (edge case)
-		for fieldName := range schemaFields {
-			found := false
-			for _, orderedField := range fieldNames {
-				if orderedField == fieldName {
-					found = true
-
-					break
-				}
-			}
-			if !found {
-				fieldNames = append(fieldNames, fieldName)
-			}
-		}
+		fieldNames = appendUnorderedNames(fieldNames, schemaFields)
 	} else {
 		// Fallback to alphabetical sorting for consistency
 		for fieldName := range schemaFields {
@@ -101,13 +131,30 @@ func buildOrderedFieldNames(schemaFields map[string]any, fieldOrder []string) []
 	return fieldNames
 }
 
+// appendUnorderedNames appends the keys of fields missing from ordered in alphabetical order, so
+// map iteration order never leaks into the generated code.
+func appendUnorderedNames(ordered []string, fields map[string]any) []string {
+	var remaining []string
+
+	for name := range fields {
+		if !slices.Contains(ordered, name) {
+			remaining = append(remaining, name)
+		}
+	}
+
+	sort.Strings(remaining)
+
+	return append(ordered, remaining...)
+}
+
 // buildRequiredFieldsSet creates a set of required fields based on schema type.
-// For input schemas, all fields are required. For output schemas, use provided required fields.
+// For input and required output schemas, all fields are required. For output schemas, use provided
+// required fields.
 func buildRequiredFieldsSet(schemaFields map[string]any, requiredFields []string, schemaType SchemaType) map[string]bool {
 	requiredSet := make(map[string]bool)
 
-	if schemaType == SchemaTypeInput {
-		// All fields are treated as required for input schemas
+	if schemaType != SchemaTypeOutput {
+		// All fields are treated as required for input and required output schemas
 		for fieldName := range schemaFields {
 			requiredSet[fieldName] = true
 		}
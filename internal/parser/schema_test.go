@@ -348,3 +348,19 @@ func TestFieldOrderingConsistency(t *testing.T) {
 		}
 	}
 }
+
+// TestCasingStylesKeepJSONKeys tests that snake_case, kebab-case and camelCase keys share a Go name but keep their json key
+func TestCasingStylesKeepJSONKeys(t *testing.T) {
+	for _, key := range []string{"user_name", "user-name", "userName"} {
+		schema := map[string]any{
+			"type":       "object",
+			"properties": map[string]any{key: map[string]any{"type": "string"}},
+		}
+
+		fields, _, _, err := ParseSchemaWithStructs(schema, nil, SchemaTypeOutput)
+		require.NoError(t, err)
+		require.Len(t, fields, 1)
+		assert.Equal(t, "UserName", fields[0].Name)
+		assert.Equal(t, key, fields[0].JSONTag)
+	}
+}
@@ -92,7 +92,7 @@ func TestValidateTemplateWithSchema(t *testing.T) {
 				Template: tt.template,
 			}
 
-			errors := pf.ValidateTemplateWithSchema()
+			errors := pf.ValidateTemplateWithSchema(nil)
 
 			assert.Len(t, errors, tt.wantErrors, "Expected %d errors, got %v", tt.wantErrors, errors)
 		})
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// tupleItems returns the positional item schemas of a tuple array, declared with prefixItems or,
+// in older drafts, with an items array.
+func tupleItems(fieldDefMap map[string]any) ([]any, bool) {
+	for _, key := range []string{"prefixItems", "items"} {
+		if items, ok := fieldDefMap[key].([]any); ok && len(items) > 0 {
+			return items, true
+		}
+	}
+
+	return nil, false
+}
+
+// handleTupleField generates a struct with one positional Elem<N> field per tuple item. The struct
+// is encoded to and from a JSON array by its generated MarshalJSON and UnmarshalJSON methods.
+func handleTupleField(
+	field codegen.GoField,
+	items []any,
+	parentStructName string,
+	schemaType SchemaType,
+	names naming.Initialisms,
+) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
+	structName := parentStructName + field.Name + "Tuple"
+
+	var (
+		elems         []codegen.GoField
+		enums         []codegen.GoEnum
+		nestedStructs []codegen.GoStruct
+	)
+
+	for i, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return field, nil, nil, nil, errors.New("tuple items must be schema objects")
+		}
+
+		elem, elemEnums, directStruct, deeperStructs, err := parseJSONSchemaFieldDef(
+			fmt.Sprintf("elem_%d", i), itemMap, true, structName, schemaType, names, nil,
+		)
+		if err != nil {
+			return field, nil, nil, nil, fmt.Errorf("failed to parse tuple item %d of %s: %w", i, field.JSONTag, err)
+		}
+
+		elems = append(elems, elem)
+		enums = append(enums, elemEnums...)
+
+		if directStruct != nil {
+			nestedStructs = append(nestedStructs, *directStruct)
+		}
+
+		nestedStructs = append(nestedStructs, deeperStructs...)
+	}
+
+	tupleStruct := &codegen.GoStruct{
+		Name:     structName,
+		Comments: []string{fmt.Sprintf("%s is the %d-element %s tuple, encoded as a JSON array", structName, len(elems), field.JSONTag)},
+		Fields:   elems,
+		Tuple:    true,
+	}
+
+	return updateFieldForStruct(field, structName), enums, tupleStruct, nestedStructs, nil
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTupleArrayBecomesPositionalStruct tests that items arrays and prefixItems generate a tuple struct
+func TestTupleArrayBecomesPositionalStruct(t *testing.T) {
+	for _, key := range []string{"items", "prefixItems"} {
+		t.Run(key, func(t *testing.T) {
+			schema := map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"range": map[string]any{
+						"type": "array",
+						key: []any{
+							map[string]any{"type": "integer", "description": "Lower bound"},
+							map[string]any{"type": "string", "enum": []any{"open", "closed"}},
+						},
+					},
+				},
+			}
+
+			fields, enums, structs, err := ParseSchemaWithStructs(schema, []string{"range"}, SchemaTypeOutput)
+			require.NoError(t, err)
+
+			require.Len(t, fields, 1)
+			assert.Equal(t, "RangeTuple", fields[0].GoType)
+
+			require.Len(t, structs, 1)
+			assert.True(t, structs[0].Tuple)
+			assert.Equal(t, "RangeTuple", structs[0].Name)
+			require.Len(t, structs[0].Fields, 2)
+			assert.Equal(t, "Elem0", structs[0].Fields[0].Name)
+			assert.Equal(t, "int", structs[0].Fields[0].GoType)
+			assert.Equal(t, "Elem1", structs[0].Fields[1].Name)
+			assert.Len(t, enums, 1)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/naming"
+)
+
+// unionKeywords are the JSON Schema keywords listing the branches of a union.
+var unionKeywords = []string{"oneOf", "anyOf"} //nolint:gochecknoglobals // read-only lookup table
+
+// unionBranches returns the branches of a oneOf/anyOf field definition.
+func unionBranches(fieldDefMap map[string]any) ([]any, bool) {
+	for _, keyword := range unionKeywords {
+		if branches, ok := fieldDefMap[keyword].([]any); ok {
+			return branches, true
+		}
+	}
+
+	return nil, false
+}
+
+// handleUnionField processes oneOf/anyOf fields. The field itself stays any; when its object
+// branches are told apart by a discriminator property the parsed union is attached so the
+// generator can emit an interface with one struct per variant.
+func handleUnionField(
+	field codegen.GoField,
+	branches []any,
+	fieldDefMap map[string]any,
+	isRequired bool,
+	parentStructName string,
+	schemaType SchemaType,
+	names naming.Initialisms,
+) (codegen.GoField, []codegen.GoEnum, *codegen.GoStruct, []codegen.GoStruct, error) {
+	union, err := parseJSONSchemaUnion(parentStructName+field.Name, field.Comment, branches, fieldDefMap, schemaType, names)
+	if err != nil {
+		return field, nil, nil, nil, fmt.Errorf("failed to parse union %s: %w", field.JSONTag, err)
+	}
+
+	field.Union = union
+
+	return handleSimpleField(field, "any", isRequired, schemaType)
+}
+
+// parseJSONSchemaUnion parses a discriminated union. It returns nil when the union has no
+// discriminator or a branch cannot be matched to a discriminator value.
+func parseJSONSchemaUnion(
+	unionName string,
+	comment string,
+	branches []any,
+	fieldDefMap map[string]any,
+	schemaType SchemaType,
+	names naming.Initialisms,
+) (*codegen.GoUnion, error) {
+	discriminator, _ := fieldDefMap["discriminator"].(map[string]any)
+
+	propertyName, _ := discriminator["propertyName"].(string)
+	if propertyName == "" {
+		return nil, nil
+	}
+
+	mapping, _ := discriminator["mapping"].(map[string]any)
+
+	union := &codegen.GoUnion{
+		Name:          unionName,
+		Comment:       comment,
+		Discriminator: propertyName,
+	}
+
+	for _, branch := range branches {
+		branchMap, ok := branch.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+
+		// Referenced branches reuse the shared definition struct and take their value from the mapping
+		if structName, isRef := refStructName(branchMap, names); isRef {
+			value := mappedDiscriminatorValue(mapping, branchMap["$ref"])
+			if value == "" {
+				return nil, nil
+			}
+
+			// The union interface already breaks recursive references, variants are never pointers
+			union.Variants = append(union.Variants, codegen.GoUnionVariant{Value: value, StructName: strings.TrimPrefix(structName, "*")})
+
+			continue
+		}
+
+		properties, ok := branchMap["properties"].(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+
+		value := discriminatorValue(properties[propertyName])
+		if value == "" {
+			return nil, nil
+		}
+
+		variantField := codegen.GoField{
+			Name:    names.EnumValueToConstName(unionName, value),
+			JSONTag: value,
+			Comment: fmt.Sprintf("the %s variant of %s", value, unionName),
+		}
+
+		if desc, ok := branchMap["description"].(string); ok {
+			variantField.Comment = desc
+		}
+
+		_, enums, variantStruct, nestedStructs, err := parseJSONSchemaObjectField(
+			variantField,
+			withPlainDiscriminator(branchMap, properties, propertyName),
+			schemaType,
+			names,
+			nil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s variant: %w", value, err)
+		}
+
+		union.Variants = append(union.Variants, codegen.GoUnionVariant{Value: value, StructName: variantStruct.Name})
+		union.Structs = append(union.Structs, *variantStruct)
+		union.Structs = append(union.Structs, nestedStructs...)
+		union.Enums = append(union.Enums, enums...)
+	}
+
+	return union, nil
+}
+
+// discriminatorValue returns the single value a branch allows for the discriminator property,
+// declared with const or a one-value enum.
+func discriminatorValue(propertyDef any) string {
+	propertyMap, ok := propertyDef.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	if value, ok := propertyMap["const"].(string); ok {
+		return value
+	}
+
+	if values, ok := propertyMap["enum"].([]any); ok && len(values) == 1 {
+		value, _ := values[0].(string)
+
+		return value
+	}
+
+	return ""
+}
+
+// mappedDiscriminatorValue looks up the discriminator value mapped to a $ref branch.
+func mappedDiscriminatorValue(mapping map[string]any, ref any) string {
+	for value, target := range mapping {
+		if target == ref {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// withPlainDiscriminator returns a copy of a branch whose discriminator property is a required
+// plain string, so variants share the field instead of each declaring a one-value enum.
+func withPlainDiscriminator(branchMap, properties map[string]any, propertyName string) map[string]any {
+	plainProperties := make(map[string]any, len(properties))
+	for name, def := range properties {
+		plainProperties[name] = def
+	}
+
+	discriminatorDef := map[string]any{"type": "string"}
+	if propertyMap, ok := properties[propertyName].(map[string]any); ok {
+		if desc, ok := propertyMap["description"].(string); ok {
+			discriminatorDef["description"] = desc
+		}
+	}
+
+	plainProperties[propertyName] = discriminatorDef
+
+	plainBranch := make(map[string]any, len(branchMap))
+	for key, value := range branchMap {
+		plainBranch[key] = value
+	}
+
+	plainBranch["properties"] = plainProperties
+	plainBranch["required"] = append(extractRequiredAny(branchMap), propertyName)
+
+	return plainBranch
+}
+
+// extractRequiredAny returns a copy of the raw required list of a schema object.
+func extractRequiredAny(schemaMap map[string]any) []any {
+	required, _ := schemaMap["required"].([]any)
+
+	return append([]any(nil), required...)
+}
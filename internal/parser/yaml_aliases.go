@@ -0,0 +1,73 @@
+package parser
+
+import "gopkg.in/yaml.v3"
+
+// mergeKey is the YAML key merging the pairs of an aliased mapping into the enclosing one.
+const mergeKey = "<<"
+
+// resolveAliases replaces alias nodes (*common) below node with the anchored node they refer to
+// (&common) and expands merge keys, so field order extraction sees aliased subtrees like inline ones.
+// Anchored nodes are resolved where they are defined, which always precedes their aliases.
+func resolveAliases(node *yaml.Node) {
+	for i, child := range node.Content {
+		if child.Kind == yaml.AliasNode && child.Alias != nil {
+			node.Content[i] = child.Alias
+
+			continue
+		}
+
+		resolveAliases(child)
+	}
+
+	if node.Kind == yaml.MappingNode {
+		node.Content = expandMergeKeys(node.Content)
+	}
+}
+
+// expandMergeKeys replaces "<<" pairs of a mapping with the pairs of the merged mappings.
+// Keys declared in the mapping itself take precedence over merged ones, as in yaml.Unmarshal.
+func expandMergeKeys(pairs []*yaml.Node) []*yaml.Node {
+	var (
+		expanded []*yaml.Node
+		merged   []*yaml.Node
+	)
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		key, value := pairs[i], pairs[i+1]
+		if key.Kind != yaml.ScalarNode || key.Value != mergeKey {
+			expanded = append(expanded, key, value)
+
+			continue
+		}
+
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+
+		for _, source := range sources {
+			if source.Kind == yaml.MappingNode {
+				merged = append(merged, source.Content...)
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(merged); i += 2 {
+		if !hasMappingKey(expanded, merged[i].Value) {
+			expanded = append(expanded, merged[i], merged[i+1])
+		}
+	}
+
+	return expanded
+}
+
+// hasMappingKey reports whether the key/value pairs of a mapping declare key.
+func hasMappingKey(pairs []*yaml.Node, key string) bool {
+	for i := 0; i < len(pairs); i += 2 {
+		if pairs[i].Value == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -114,6 +114,6 @@ func BenchmarkValidateHelpers(b *testing.B) {
 	}
 
 	for b.Loop() {
-		ValidateHelpers(helpers, blockHelpers)
+		ValidateHelpers(helpers, blockHelpers, nil)
 	}
 }
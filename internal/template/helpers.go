@@ -0,0 +1,94 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HelperSpec is the arity of a registered helper: how many parameters it is called with.
+type HelperSpec struct {
+	MinParams int // parameters the helper requires
+	MaxParams int // parameters the helper accepts at most, negative for no limit
+}
+
+// ParseHelperSpec parses a helper registration: "name" accepts any number of parameters,
+// "name:1" exactly one, "name:1-2" one or two and "name:1+" at least one.
+func ParseHelperSpec(spec string) (string, HelperSpec, error) {
+	name, arity, hasArity := strings.Cut(strings.TrimSpace(spec), ":")
+	if name == "" || strings.ContainsAny(name, " {}") {
+		return "", HelperSpec{}, fmt.Errorf("invalid helper %q: expected name[:arity]", spec)
+	}
+
+	if !hasArity {
+		return name, HelperSpec{MaxParams: -1}, nil
+	}
+
+	var (
+		helperSpec HelperSpec
+		err        error
+	)
+
+	if minParams, ok := strings.CutSuffix(arity, "+"); ok {
+		helperSpec.MaxParams = -1
+		helperSpec.MinParams, err = strconv.Atoi(minParams)
+	} else if minParams, maxParams, ok := strings.Cut(arity, "-"); ok {
+		helperSpec.MinParams, err = strconv.Atoi(minParams)
+		if err == nil {
+			helperSpec.MaxParams, err = strconv.Atoi(maxParams)
+		}
+	} else {
+		helperSpec.MinParams, err = strconv.Atoi(arity)
+		helperSpec.MaxParams = helperSpec.MinParams
+	}
+
+	if err == nil && (helperSpec.MinParams < 0 || helperSpec.MaxParams >= 0 && helperSpec.MaxParams < helperSpec.MinParams) {
+		err = errors.New("parameter counts must be ascending and not negative")
+	}
+
+	if err != nil {
+		return "", HelperSpec{}, fmt.Errorf("invalid helper arity %q: %w", spec, err)
+	}
+
+	return name, helperSpec, nil
+}
+
+// ParseHelperSpecs parses helper registrations into the map passed to ValidateHelpers.
+// A helper registered twice keeps its last spec.
+func ParseHelperSpecs(specs []string) (map[string]HelperSpec, error) {
+	helpers := make(map[string]HelperSpec, len(specs))
+
+	for _, spec := range specs {
+		name, helperSpec, err := ParseHelperSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		helpers[name] = helperSpec
+	}
+
+	return helpers, nil
+}
+
+// validateHelperArity checks the parameter count of a call to a registered helper.
+func validateHelperArity(name string, spec HelperSpec, params int) []ValidationError {
+	var expected string
+
+	switch {
+	case spec.MaxParams < 0 && params < spec.MinParams:
+		expected = fmt.Sprintf("at least %d", spec.MinParams)
+	case spec.MaxParams >= 0 && (params < spec.MinParams || params > spec.MaxParams):
+		expected = strconv.Itoa(spec.MinParams)
+		if spec.MaxParams != spec.MinParams {
+			expected = fmt.Sprintf("%d to %d", spec.MinParams, spec.MaxParams)
+		}
+	default:
+		return nil
+	}
+
+	return []ValidationError{{
+		Message: fmt.Sprintf("%s helper expects %s parameter(s), got %d", name, expected, params),
+		Type:    "helper",
+	}}
+}
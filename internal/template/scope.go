@@ -0,0 +1,193 @@
+package template
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aymerick/raymond/ast"
+)
+
+// itemSegment marks the item of an array in a variable path, e.g. "items[].name" for {{name}}
+// inside {{#each items}}.
+const itemSegment = "[]"
+
+// contextScope is a template context opened by the root or a context-changing block helper.
+type contextScope struct {
+	path        []string            // input path of the context, nil when it cannot be resolved
+	blockParams map[string][]string // input paths of the names bound with "as |name|", nil when unresolved
+}
+
+// scopeWalker collects the variables a template resolves against the prompt input.
+type scopeWalker struct {
+	scopes    []contextScope
+	variables []string
+}
+
+// inputVariables returns the variables of a template as paths from the input. Variables inside
+// {{#with user}} are prefixed with the object, e.g. "user.email", and variables inside
+// {{#each items}} with the array item, e.g. "items[].name"; block parameters resolve to the
+// value they are bound to. Variables in the context of other block helpers are left out, their
+// context is not known, unless they reach a known context with ../ or @root.
+func inputVariables(program *ast.Program) []string {
+	walker := &scopeWalker{scopes: []contextScope{{path: []string{}}}}
+	walker.program(program)
+
+	return walker.variables
+}
+
+// program walks the statements of a program.
+func (w *scopeWalker) program(program *ast.Program) {
+	if program == nil {
+		return
+	}
+
+	for _, node := range program.Body {
+		switch statement := node.(type) {
+		case *ast.MustacheStatement:
+			w.expression(statement.Expression)
+		case *ast.BlockStatement:
+			w.block(statement)
+		case *ast.PartialStatement:
+			// A partial called with a context, {{> header user}}, reads it from the current scope
+			for _, param := range statement.Params {
+				if path, ok := param.(*ast.PathExpression); ok {
+					w.path(path)
+				}
+			}
+		}
+	}
+}
+
+// block walks a block statement, opening a new context for helpers other than if and unless.
+func (w *scopeWalker) block(block *ast.BlockStatement) {
+	w.arguments(block.Expression)
+
+	switch block.Expression.HelperName() {
+	case "if", "unless":
+		w.program(block.Program)
+	default:
+		w.scopes = append(w.scopes, w.blockScope(block))
+		w.program(block.Program)
+		w.scopes = w.scopes[:len(w.scopes)-1]
+	}
+
+	// {{else}} runs in the context of the block itself
+	w.program(block.Inverse)
+}
+
+// blockScope returns the context a block opens: the object of {{#with}}, the item of {{#each}}
+// and an unresolved context for other helpers. The first block parameter is bound to the same
+// value, the index or key bound by the second one is not an input path.
+func (w *scopeWalker) blockScope(block *ast.BlockStatement) contextScope {
+	scope := contextScope{blockParams: make(map[string][]string)}
+
+	if len(block.Expression.Params) == 1 {
+		if param, ok := block.Expression.Params[0].(*ast.PathExpression); ok {
+			switch block.Expression.HelperName() {
+			case "with":
+				scope.path = w.resolve(param)
+			case "each":
+				if target := w.resolve(param); target != nil {
+					scope.path = append(target, itemSegment)
+				}
+			}
+		}
+	}
+
+	if block.Program != nil {
+		for i, param := range block.Program.BlockParams {
+			scope.blockParams[param] = nil
+			if i == 0 {
+				scope.blockParams[param] = scope.path
+			}
+		}
+	}
+
+	return scope
+}
+
+// expression records the variable of a plain mustache or the arguments of a helper call.
+func (w *scopeWalker) expression(expression *ast.Expression) {
+	if len(expression.Params) == 0 && expression.Hash == nil {
+		if path, ok := expression.Path.(*ast.PathExpression); ok {
+			w.path(path)
+		}
+
+		return
+	}
+
+	w.arguments(expression)
+}
+
+// arguments records the variables passed to a helper as parameters or hash values.
+func (w *scopeWalker) arguments(expression *ast.Expression) {
+	values := append([]ast.Node(nil), expression.Params...)
+	if expression.Hash != nil {
+		for _, pair := range expression.Hash.Pairs {
+			values = append(values, pair.Val)
+		}
+	}
+
+	for _, value := range values {
+		switch argument := value.(type) {
+		case *ast.PathExpression:
+			w.path(argument)
+		case *ast.SubExpression:
+			w.arguments(argument.Expression)
+		}
+	}
+}
+
+// path records a path expression when it resolves against the input.
+func (w *scopeWalker) path(path *ast.PathExpression) {
+	if len(path.Parts) == 0 {
+		return // {{this}}
+	}
+
+	if resolved := w.resolve(path); len(resolved) > 0 {
+		w.variables = append(w.variables, joinPath(resolved))
+	}
+}
+
+// resolve returns the input path of a path expression, or nil when it does not resolve against the
+// input, e.g. data variables like @index or paths in the context of a custom block helper.
+func (w *scopeWalker) resolve(path *ast.PathExpression) []string {
+	if path.Data {
+		if len(path.Parts) > 1 && path.Parts[0] == "root" {
+			return slices.Clone(path.Parts[1:])
+		}
+
+		return nil
+	}
+
+	if path.Depth >= len(w.scopes) {
+		return nil
+	}
+
+	scopes := w.scopes[:len(w.scopes)-path.Depth]
+
+	// Block parameters shadow the fields of the context they are visible in
+	if len(path.Parts) > 0 {
+		for i := len(scopes) - 1; i >= 0; i-- {
+			if bound, ok := scopes[i].blockParams[path.Parts[0]]; ok {
+				if bound == nil {
+					return nil
+				}
+
+				return append(slices.Clone(bound), path.Parts[1:]...)
+			}
+		}
+	}
+
+	context := scopes[len(scopes)-1].path
+	if context == nil {
+		return nil
+	}
+
+	return append(slices.Clone(context), path.Parts...)
+}
+
+// joinPath joins the segments of an input path, attaching item markers to the array before them.
+func joinPath(segments []string) string {
+	return strings.ReplaceAll(strings.Join(segments, "."), "."+itemSegment, itemSegment)
+}
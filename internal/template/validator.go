@@ -2,25 +2,25 @@ package template
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
-	"github.com/aymerick/raymond"
-)
-
-const (
-	// expectedPathRegexGroups is the expected number of regex capture groups for path expressions.
-	expectedPathRegexGroups = 3
+	"github.com/aymerick/raymond/ast"
+	"github.com/aymerick/raymond/parser"
 )
 
 // ValidationResult contains the result of template validation.
 type ValidationResult struct {
-	Valid        bool
-	Errors       []ValidationError
-	Warnings     []ValidationError
-	Variables    []string
-	Helpers      []HelperUsage
-	BlockHelpers []BlockHelperUsage
+	Valid     bool
+	Errors    []ValidationError
+	Warnings  []ValidationError
+	Variables []string
+	// InputVariables are the variables resolved against the input schema as paths from the input,
+	// e.g. user.email inside {{#with user}} and items[].name inside {{#each items}}
+	InputVariables []string
+	Helpers        []HelperUsage
+	BlockHelpers   []BlockHelperUsage
+	// Partials are the names of the partials included with {{> name}}, in template order
+	Partials []string
 }
 
 // ValidationError represents a template validation error.
@@ -28,7 +28,7 @@ type ValidationError struct {
 	Message string
 	Line    int
 	Column  int
-	Type    string // "syntax", "variable", "helper"
+	Type    string // "syntax", "variable", "helper", "partial"
 }
 
 // HelperUsage represents usage of a helper function.
@@ -54,10 +54,10 @@ func ValidateHandlebarsTemplate(templateContent string) *ValidationResult {
 		Variables:    []string{},
 		Helpers:      []HelperUsage{},
 		BlockHelpers: []BlockHelperUsage{},
+		Partials:     []string{},
 	}
 
-	// Parse the template using raymond
-	template, err := raymond.Parse(templateContent)
+	program, err := parser.Parse(templateContent)
 	if err != nil {
 		result.Valid = false
 		result.Errors = append(result.Errors, ValidationError{
@@ -68,158 +68,134 @@ func ValidateHandlebarsTemplate(templateContent string) *ValidationResult {
 		return result
 	}
 
-	// Get AST string and parse it
-	astString := template.PrintAST()
-	parseASTString(astString, result)
+	usageWalker{result: result}.program(program)
+	result.InputVariables = inputVariables(program)
 
 	return result
 }
 
-// parseASTString parses the AST string output from raymond.PrintAST().
-func parseASTString(astString string, result *ValidationResult) {
-	lines := strings.Split(astString, "\n")
+// usageWalker collects the variables and helpers of a template, descending into the program and
+// the {{else}} inverse of every block.
+type usageWalker struct {
+	result *ValidationResult
+}
 
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+// program walks the statements of a program.
+func (w usageWalker) program(program *ast.Program) {
+	if program == nil {
+		return
+	}
 
-		// Parse different AST node types
-		switch {
-		case strings.HasPrefix(line, "{{ PATH:"):
-			parsePathExpression(line, result)
-		case strings.HasPrefix(line, "{{     PATH:"):
-			// Handle variables inside block programs
-			parsePathExpression(line, result)
-		case strings.HasPrefix(line, "BLOCK:"):
-			parseBlockExpression(lines[i:], result)
+	for _, node := range program.Body {
+		switch statement := node.(type) {
+		case *ast.MustacheStatement:
+			w.mustache(statement.Expression)
+		case *ast.BlockStatement:
+			w.block(statement)
+		case *ast.PartialStatement:
+			w.partial(statement)
 		}
 	}
 }
 
-// parsePathExpression parses lines like "{{ PATH:name [] }}" or "{{ PATH:role ["system"] }}".
-func parsePathExpression(line string, result *ValidationResult) {
-	// Regex to match: {{ PATH:name [params] }} or {{     PATH: [] (for {{this}})
-	pathRegex := regexp.MustCompile(`\{\{[\s]*PATH:([^\s\[\]]*)\s*\[([^\]]*)\]`)
-	matches := pathRegex.FindStringSubmatch(line)
-
-	if len(matches) != expectedPathRegexGroups {
-		return
+// partial records a partial inclusion and the context it is called with. Dynamic partial names
+// like {{> (lookup . "name")}} are not known until rendering and are left out.
+func (w usageWalker) partial(partial *ast.PartialStatement) {
+	if path, ok := partial.Name.(*ast.PathExpression); ok {
+		w.result.Partials = append(w.result.Partials, path.Original)
 	}
 
-	pathName := matches[1]
-	paramsStr := matches[2]
-
-	// Handle special case of {{this}} which shows up as empty path
-	if pathName == "" {
-		pathName = "this"
+	for _, param := range partial.Params {
+		if path, ok := param.(*ast.PathExpression); ok {
+			w.result.Variables = append(w.result.Variables, pathName(path))
+		}
 	}
+}
 
-	// Convert path separators (user/name -> user.name)
-	pathName = strings.ReplaceAll(pathName, "/", ".")
+// mustache records a plain mustache as a variable and a mustache with parameters as a helper call.
+func (w usageWalker) mustache(expression *ast.Expression) {
+	path, ok := expression.Path.(*ast.PathExpression)
+	if !ok {
+		return
+	}
 
-	// Parse parameters
-	var params []string
+	if len(expression.Params) == 0 {
+		w.result.Variables = append(w.result.Variables, pathName(path))
 
-	if strings.TrimSpace(paramsStr) != "" {
-		// Handle different parameter formats
-		if strings.Contains(paramsStr, "\"") {
-			// String parameters like ["system"]
-			stringRegex := regexp.MustCompile(`"([^"]*)"`)
-
-			stringMatches := stringRegex.FindAllStringSubmatch(paramsStr, -1)
-			for _, match := range stringMatches {
-				if len(match) > 1 {
-					params = append(params, match[1])
-				}
-			}
-		} else if after, ok := strings.CutPrefix(paramsStr, "PATH:"); ok {
-			// Path parameters like [PATH:items]
-			pathParam := after
-			params = append(params, pathParam)
-		}
+		return
 	}
 
-	// If there are parameters, it's a helper function
-	if len(params) > 0 {
-		result.Helpers = append(result.Helpers, HelperUsage{
-			Name:       pathName,
-			Parameters: params,
-		})
-	} else {
-		// No parameters, it's a variable
-		result.Variables = append(result.Variables, pathName)
-	}
+	w.result.Helpers = append(w.result.Helpers, HelperUsage{
+		Name:       pathName(path),
+		Parameters: parameters(expression),
+	})
 }
 
-// parseBlockExpression parses block helpers from AST string.
-func parseBlockExpression(lines []string, result *ValidationResult) {
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-
-		// Look for PATH line after BLOCK:
-		if strings.HasPrefix(line, "PATH:") && i > 0 &&
-			strings.TrimSpace(lines[i-1]) == "BLOCK:" {
-			parseBlockPath(line, result)
+// block records a block helper and the variables it is called with, then walks both branches.
+func (w usageWalker) block(block *ast.BlockStatement) {
+	// Block parameters are referenced variables, e.g. the collection of {{#each items}}
+	for _, param := range block.Expression.Params {
+		if path, ok := param.(*ast.PathExpression); ok {
+			w.result.Variables = append(w.result.Variables, pathName(path))
 		}
 	}
-}
-
-// parseBlockPath parses block helper path like "PATH:each [PATH:items]".
-func parseBlockPath(line string, result *ValidationResult) {
-	// Regex to match: PATH:helperName [params]
-	pathRegex := regexp.MustCompile(`PATH:([^\s\[]+)\s*\[([^\]]*)\]`)
-	matches := pathRegex.FindStringSubmatch(line)
 
-	if len(matches) != expectedPathRegexGroups {
-		return
-	}
+	w.result.BlockHelpers = append(w.result.BlockHelpers, BlockHelperUsage{
+		Name:       block.Expression.HelperName(),
+		Parameters: parameters(block.Expression),
+	})
 
-	helperName := matches[1]
-	paramsStr := matches[2]
+	w.program(block.Program)
+	w.program(block.Inverse)
+}
 
+// parameters returns the string literal and path parameters of a helper call.
+func parameters(expression *ast.Expression) []string {
 	var params []string
 
-	if strings.TrimSpace(paramsStr) != "" {
-		if after, ok := strings.CutPrefix(paramsStr, "PATH:"); ok {
-			// Path parameter like PATH:items
-			pathParam := after
-			params = append(params, pathParam)
-			// Also add as a variable since it's referenced
-			result.Variables = append(result.Variables, pathParam)
+	for _, param := range expression.Params {
+		switch value := param.(type) {
+		case *ast.StringLiteral:
+			params = append(params, value.Value)
+		case *ast.PathExpression:
+			params = append(params, pathName(value))
 		}
 	}
 
-	result.BlockHelpers = append(result.BlockHelpers, BlockHelperUsage{
-		Name:       helperName,
-		Parameters: params,
-	})
+	return params
+}
+
+// pathName returns the dotted name of a path expression: "user.email", "@index" or "this".
+func pathName(path *ast.PathExpression) string {
+	name := strings.Join(path.Parts, ".")
+
+	switch {
+	case path.Data:
+		return "@" + name
+	case name == "":
+		return "this"
+	default:
+		return name
+	}
 }
 
-// ValidateVariablesAgainstSchema validates that template variables exist in the schema.
+// ValidateVariablesAgainstSchema validates that template variables exist in the schema. Nested
+// variables are checked field by field, e.g. user.age against the properties of user, and array
+// items against the items schema, e.g. items[].name. Objects whose fields the schema does not list,
+// like maps or $ref targets, accept any field.
 func ValidateVariablesAgainstSchema(variables []string, schema map[string]any) []ValidationError {
 	var errors []ValidationError
 
-	// Extract schema properties
-	schemaProps := make(map[string]bool)
-	if properties, ok := schema["properties"].(map[string]any); ok {
-		for prop := range properties {
-			schemaProps[prop] = true
-		}
-	}
+	root := rootSchema(schema)
 
 	// Check each variable against schema
 	for _, variable := range variables {
-		// Handle nested properties (user.email -> user)
-		rootVar := strings.Split(variable, ".")[0]
-
 		// Skip special handlebars variables
-		if isSpecialVariable(rootVar) {
+		if isSpecialVariable(strings.Split(variable, ".")[0]) {
 			continue
 		}
 
-		if !schemaProps[rootVar] {
+		if !root.hasPath(variablePath(variable)) {
 			errors = append(errors, ValidationError{
 				Message: fmt.Sprintf("Variable '%s' not found in input schema", variable),
 				Type:    "variable",
@@ -230,6 +206,112 @@ func ValidateVariablesAgainstSchema(variables []string, schema map[string]any) [
 	return errors
 }
 
+// variablePath splits a variable into its input path segments, e.g. "items[].name" into items,
+// the item marker and name.
+func variablePath(variable string) []string {
+	var segments []string
+
+	for _, part := range strings.Split(variable, ".") {
+		name, item := strings.CutSuffix(part, itemSegment)
+		segments = append(segments, name)
+
+		if item {
+			segments = append(segments, itemSegment)
+		}
+	}
+
+	return segments
+}
+
+// schemaNode is a schema definition in either format, so nested lookups know how to read it.
+type schemaNode struct {
+	definition any
+	picoschema bool
+	array      bool // a Picoschema "(array)" field, whose definition is the item schema
+}
+
+// rootSchema returns the node of an input schema, detecting JSON Schema by its properties or type.
+func rootSchema(schema map[string]any) schemaNode {
+	_, hasProperties := schema["properties"]
+	_, hasType := schema["type"]
+
+	return schemaNode{definition: schema, picoschema: !hasProperties && !hasType}
+}
+
+// hasPath reports whether the input path exists in the schema. A path is accepted as soon as it
+// reaches a definition that does not list its fields or items.
+func (node schemaNode) hasPath(path []string) bool {
+	for _, segment := range path {
+		if segment == itemSegment {
+			item, known := node.item()
+			if !known {
+				return true
+			}
+
+			node = item
+
+			continue
+		}
+
+		property, listed, found := node.property(segment)
+		if !listed {
+			return true
+		}
+
+		if !found {
+			return false
+		}
+
+		node = property
+	}
+
+	return true
+}
+
+// property returns the definition of a field, whether the node lists its fields at all and
+// whether the field is one of them.
+func (node schemaNode) property(name string) (schemaNode, bool, bool) {
+	definition, ok := node.definition.(map[string]any)
+	if !ok || node.array {
+		return schemaNode{}, false, false
+	}
+
+	if !node.picoschema {
+		properties, ok := definition["properties"].(map[string]any)
+		if !ok {
+			return schemaNode{}, false, false
+		}
+
+		property, found := properties[name]
+
+		return schemaNode{definition: property}, true, found
+	}
+
+	// Picoschema keys carry optional and type markers, e.g. "name?" or "tags(array)"
+	for key, property := range definition {
+		fieldName, marker, _ := strings.Cut(key, "(")
+		if strings.TrimSuffix(strings.TrimSpace(fieldName), "?") == name {
+			return schemaNode{definition: property, picoschema: true, array: strings.HasPrefix(marker, "array")}, true, true
+		}
+	}
+
+	return schemaNode{}, true, false
+}
+
+// item returns the item schema of an array, or false when it is not known.
+func (node schemaNode) item() (schemaNode, bool) {
+	if node.array {
+		return schemaNode{definition: node.definition, picoschema: true}, true
+	}
+
+	definition, _ := node.definition.(map[string]any)
+	if items, ok := definition["items"].(map[string]any); ok && !node.picoschema {
+		return schemaNode{definition: items}, true
+	}
+
+	return schemaNode{}, false
+}
+
 // isSpecialVariable checks if a variable is a special handlebars variable.
 func isSpecialVariable(variable string) bool {
 	specialVars := map[string]bool{
@@ -243,15 +325,18 @@ func isSpecialVariable(variable string) bool {
 	return specialVars[variable]
 }
 
-// ValidateHelpers validates helper function usage.
-func ValidateHelpers(helpers []HelperUsage, blockHelpers []BlockHelperUsage) []ValidationError {
+// ValidateHelpers validates helper function usage. The built-in role, each, if, unless and with
+// helpers are always known; other helpers must be registered in knownHelpers, which may be nil.
+func ValidateHelpers(helpers []HelperUsage, blockHelpers []BlockHelperUsage, knownHelpers map[string]HelperSpec) []ValidationError {
 	var errors []ValidationError
 
 	// Validate regular helpers
 	for _, helper := range helpers {
-		switch helper.Name {
-		case "role":
+		switch spec, known := knownHelpers[helper.Name]; {
+		case helper.Name == "role":
 			errors = append(errors, validateRoleHelper(helper)...)
+		case known:
+			errors = append(errors, validateHelperArity(helper.Name, spec, len(helper.Parameters))...)
 		default:
 			// Unknown helper - could be a warning
 			errors = append(errors, ValidationError{
@@ -263,11 +348,15 @@ func ValidateHelpers(helpers []HelperUsage, blockHelpers []BlockHelperUsage) []V
 
 	// Validate block helpers
 	for _, blockHelper := range blockHelpers {
-		switch blockHelper.Name {
-		case "each":
+		switch spec, known := knownHelpers[blockHelper.Name]; {
+		case blockHelper.Name == "each":
 			errors = append(errors, validateEachHelper(blockHelper)...)
-		case "if", "unless":
+		case blockHelper.Name == "if", blockHelper.Name == "unless":
 			errors = append(errors, validateConditionalHelper(blockHelper)...)
+		case blockHelper.Name == "with":
+			errors = append(errors, validateWithHelper(blockHelper)...)
+		case known:
+			errors = append(errors, validateHelperArity(blockHelper.Name, spec, len(blockHelper.Parameters))...)
 		default:
 			errors = append(errors, ValidationError{
 				Message: fmt.Sprintf("Unknown block helper '%s'", blockHelper.Name),
@@ -279,6 +368,23 @@ func ValidateHelpers(helpers []HelperUsage, blockHelpers []BlockHelperUsage) []V
 	return errors
 }
 
+// ValidatePartials reports partials that are not in the known set, e.g. the partial files found
+// next to a prompt.
+func ValidatePartials(partials []string, known map[string]bool) []ValidationError {
+	var errors []ValidationError
+
+	for _, partial := range partials {
+		if !known[partial] {
+			errors = append(errors, ValidationError{
+				Message: fmt.Sprintf("Unknown partial '%s'", partial),
+				Type:    "partial",
+			})
+		}
+	}
+
+	return errors
+}
+
 // validateRoleHelper validates the {{role}} helper.
 func validateRoleHelper(helper HelperUsage) []ValidationError {
 	var errors []ValidationError
@@ -336,3 +442,17 @@ func validateConditionalHelper(blockHelper BlockHelperUsage) []ValidationError {
 
 	return errors
 }
+
+// validateWithHelper validates the {{#with}} block helper.
+func validateWithHelper(blockHelper BlockHelperUsage) []ValidationError {
+	var errors []ValidationError
+
+	if len(blockHelper.Parameters) == 0 {
+		errors = append(errors, ValidationError{
+			Message: "with helper requires a context parameter",
+			Type:    "helper",
+		})
+	}
+
+	return errors
+}
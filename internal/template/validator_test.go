@@ -195,6 +195,84 @@ func TestValidateHandlebarsTemplate_EachHelper(t *testing.T) {
 	}
 }
 
+func TestValidateHandlebarsTemplate_ElseBranches(t *testing.T) {
+	tests := []struct {
+		name             string
+		template         string
+		wantVars         []string
+		wantBlockHelpers []string
+	}{
+		{
+			name:             "if/else",
+			template:         "{{#if premium}}{{perk}}{{else}}{{fallback}}{{/if}}",
+			wantVars:         []string{"premium", "perk", "fallback"},
+			wantBlockHelpers: []string{"if"},
+		},
+		{
+			name:             "unless/else",
+			template:         "{{#unless banned}}Welcome {{name}}{{else}}{{reason}}{{/unless}}",
+			wantVars:         []string{"banned", "name", "reason"},
+			wantBlockHelpers: []string{"unless"},
+		},
+		{
+			name:             "else if chain",
+			template:         "{{#if a}}{{one}}{{else if b}}{{two}}{{else}}{{three}}{{/if}}",
+			wantVars:         []string{"a", "one", "b", "two", "three"},
+			wantBlockHelpers: []string{"if", "if"},
+		},
+		{
+			name:             "nested in else",
+			template:         "{{#if a}}x{{else}}{{#unless b}}{{#if c}}{{deep}}{{else}}{{deeper}}{{/if}}{{/unless}}{{/if}}",
+			wantVars:         []string{"a", "b", "c", "deep", "deeper"},
+			wantBlockHelpers: []string{"if", "unless", "if"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHandlebarsTemplate(tt.template)
+
+			assert.True(t, result.Valid, "Expected valid template, got errors: %v", result.Errors)
+			assert.Equal(t, tt.wantVars, result.Variables)
+			assert.Equal(t, tt.wantVars, result.InputVariables)
+
+			var blockHelpers []string
+			for _, blockHelper := range result.BlockHelpers {
+				blockHelpers = append(blockHelpers, blockHelper.Name)
+			}
+
+			assert.Equal(t, tt.wantBlockHelpers, blockHelpers)
+			assert.Empty(t, ValidateHelpers(result.Helpers, result.BlockHelpers, nil))
+		})
+	}
+
+	t.Run("else variable missing from schema", func(t *testing.T) {
+		result := ValidateHandlebarsTemplate("{{#if premium}}Thanks!{{else}}{{fallback}}{{/if}}")
+		schema := map[string]any{"properties": map[string]any{"premium": map[string]any{"type": "boolean"}}}
+
+		errors := ValidateVariablesAgainstSchema(result.InputVariables, schema)
+		if assert.Len(t, errors, 1) {
+			assert.Contains(t, errors[0].Message, "'fallback'")
+		}
+	})
+}
+
+func TestValidateHandlebarsTemplate_Partials(t *testing.T) {
+	result := ValidateHandlebarsTemplate("{{> systemHeader}}{{#if verbose}}{{> details user}}{{/if}}{{> (lookup . \"name\")}}")
+
+	assert.True(t, result.Valid, "Expected valid template, got errors: %v", result.Errors)
+	assert.Equal(t, []string{"systemHeader", "details"}, result.Partials)
+	assert.Equal(t, []string{"verbose", "user"}, result.InputVariables)
+	assert.Empty(t, result.Helpers)
+	assert.Empty(t, ValidateHelpers(result.Helpers, result.BlockHelpers, nil))
+
+	errors := ValidatePartials(result.Partials, map[string]bool{"systemHeader": true})
+	if assert.Len(t, errors, 1) {
+		assert.Equal(t, "partial", errors[0].Type)
+		assert.Equal(t, "Unknown partial 'details'", errors[0].Message)
+	}
+}
+
 func TestValidateVariablesAgainstSchema(t *testing.T) {
 	schema := map[string]any{
 		"properties": map[string]any{
@@ -206,6 +284,15 @@ func TestValidateVariablesAgainstSchema(t *testing.T) {
 					"email": map[string]any{"type": "string"},
 				},
 			},
+			"items": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"sku": map[string]any{"type": "string"}},
+				},
+			},
+			"tags":     map[string]any{"type": "array"},
+			"metadata": map[string]any{"type": "object", "additionalProperties": true},
 		},
 	}
 
@@ -230,8 +317,18 @@ func TestValidateVariablesAgainstSchema(t *testing.T) {
 			wantErrorVars: []string{},
 		},
 		{
-			name:          "nested variable with valid root",
+			name:          "nested variable missing from object",
 			variables:     []string{"user.email", "user.name"},
+			wantErrorVars: []string{"user.name"},
+		},
+		{
+			name:          "array item fields",
+			variables:     []string{"items[].sku", "items[].color", "tags[].anything"},
+			wantErrorVars: []string{"items[].color"},
+		},
+		{
+			name:          "fields of objects without listed properties",
+			variables:     []string{"metadata.anything", "name.length"},
 			wantErrorVars: []string{},
 		},
 		{
@@ -267,6 +364,34 @@ func TestValidateVariablesAgainstSchema(t *testing.T) {
 	}
 }
 
+func TestValidateVariablesAgainstPicoschema(t *testing.T) {
+	schema := map[string]any{
+		"name":            "string, the user name",
+		"nickname?":       "string, optional nickname",
+		"tags(array)":     "string, user tags",
+		"role(enum)":      "[admin, user], user role",
+		"settings?(enum)": "[a, b], optional settings",
+	}
+
+	errors := ValidateVariablesAgainstSchema([]string{"name", "nickname", "tags", "role", "settings"}, schema)
+	assert.Empty(t, errors, "Picoschema field markers should be stripped")
+
+	errors = ValidateVariablesAgainstSchema([]string{"unknown"}, schema)
+	assert.Len(t, errors, 1)
+
+	nested := map[string]any{
+		"user(object)":  map[string]any{"email": "string", "age?": "integer"},
+		"items(array)":  map[string]any{"sku": "string"},
+		"labels(array)": "string",
+	}
+
+	errors = ValidateVariablesAgainstSchema([]string{"user.email", "user.age", "items[].sku", "labels[].x"}, nested)
+	assert.Empty(t, errors, "Nested Picoschema objects and array items should resolve")
+
+	errors = ValidateVariablesAgainstSchema([]string{"user.nope", "items[].nope"}, nested)
+	assert.Len(t, errors, 2)
+}
+
 func TestValidateHelpers_RoleValidation(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -317,7 +442,7 @@ func TestValidateHelpers_RoleValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := ValidateHelpers(tt.helpers, []BlockHelperUsage{})
+			errors := ValidateHelpers(tt.helpers, []BlockHelperUsage{}, nil)
 
 			assert.Len(t, errors, tt.wantErrors, "Expected %d errors", tt.wantErrors)
 
@@ -355,7 +480,7 @@ func TestValidateHelpers_EachValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := ValidateHelpers([]HelperUsage{}, tt.blockHelpers)
+			errors := ValidateHelpers([]HelperUsage{}, tt.blockHelpers, nil)
 
 			assert.Len(t, errors, tt.wantErrors, "Expected %d errors", tt.wantErrors)
 		})
@@ -421,7 +546,7 @@ Habit to classify:
 
 			// Then validate against schema
 			schemaErrors := ValidateVariablesAgainstSchema(result.Variables, tt.schema)
-			helperErrors := ValidateHelpers(result.Helpers, result.BlockHelpers)
+			helperErrors := ValidateHelpers(result.Helpers, result.BlockHelpers, nil)
 
 			allErrors := append(schemaErrors, helperErrors...)
 			hasErrors := len(allErrors) > 0
@@ -434,3 +559,215 @@ Habit to classify:
 		})
 	}
 }
+
+func TestValidateHandlebarsTemplate_InputVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantVars []string
+	}{
+		{
+			name:     "root and nested variables",
+			template: "{{name}} <{{user.email}}>",
+			wantVars: []string{"name", "user.email"},
+		},
+		{
+			name:     "each item fields are relative to the item",
+			template: "{{#each items}}{{name}} {{@index}} {{this}} {{../title}}{{/each}}",
+			wantVars: []string{"items", "items[].name", "title"},
+		},
+		{
+			name:     "block parameters are bound by the block",
+			template: "{{#each items as |item i|}}{{item.name}} {{i}}{{/each}}",
+			wantVars: []string{"items", "items[].name"},
+		},
+		{
+			name:     "conditionals keep the context",
+			template: "{{#if done}}{{summary}}{{else}}{{draft}}{{/if}}",
+			wantVars: []string{"done", "summary", "draft"},
+		},
+		{
+			name:     "with and @root",
+			template: "{{#with user}}{{email}} {{@root.company}}{{/with}}",
+			wantVars: []string{"user", "user.email", "company"},
+		},
+		{
+			name:     "nested each and with",
+			template: "{{#each orders}}{{#with customer}}{{name}}{{/with}}{{#each lines}}{{sku}}{{/each}}{{/each}}",
+			wantVars: []string{"orders", "orders[].customer", "orders[].customer.name", "orders[].lines", "orders[].lines[].sku"},
+		},
+		{
+			name:     "custom block helpers have an unknown context",
+			template: "{{#group items}}{{key}} {{../title}}{{/group}}",
+			wantVars: []string{"items", "title"},
+		},
+		{
+			name:     "helper arguments",
+			template: `{{role "system"}}{{uppercase name}}`,
+			wantVars: []string{"name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHandlebarsTemplate(tt.template)
+
+			assert.True(t, result.Valid, "Expected valid template, got errors: %v", result.Errors)
+			assert.ElementsMatch(t, tt.wantVars, result.InputVariables)
+		})
+	}
+}
+
+func TestValidateHandlebarsTemplate_ScopedVariables(t *testing.T) {
+	schema := map[string]any{
+		"properties": map[string]any{
+			"title": map[string]any{"type": "string"},
+			"user": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"name": map[string]any{"type": "string"}},
+			},
+			"items": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"sku": map[string]any{"type": "string"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		template   string
+		wantErrors []string
+	}{
+		{
+			name:     "fields of the with object and each item",
+			template: "{{#with user}}{{name}}{{/with}}{{#each items}}{{sku}} {{../title}}{{/each}}",
+		},
+		{
+			name:       "missing field of the with object",
+			template:   "{{#with user}}{{age}}{{else}}{{title}}{{/with}}",
+			wantErrors: []string{"Variable 'user.age' not found in input schema"},
+		},
+		{
+			name:       "missing field of the each item",
+			template:   "{{#each items}}{{nope}}{{/each}}",
+			wantErrors: []string{"Variable 'items[].nope' not found in input schema"},
+		},
+		{
+			name:       "missing nested field",
+			template:   "{{user.age}}",
+			wantErrors: []string{"Variable 'user.age' not found in input schema"},
+		},
+		{
+			name:       "with requires a context",
+			template:   "{{#with}}x{{/with}}",
+			wantErrors: []string{"with helper requires a context parameter"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHandlebarsTemplate(tt.template)
+			assert.True(t, result.Valid, "Expected valid template, got errors: %v", result.Errors)
+
+			var messages []string
+			for _, err := range ValidateVariablesAgainstSchema(result.InputVariables, schema) {
+				messages = append(messages, err.Message)
+			}
+
+			for _, err := range ValidateHelpers(result.Helpers, result.BlockHelpers, nil) {
+				messages = append(messages, err.Message)
+			}
+
+			assert.Equal(t, tt.wantErrors, messages)
+		})
+	}
+}
+
+func TestValidateHelpers_KnownHelpers(t *testing.T) {
+	knownHelpers, err := ParseHelperSpecs([]string{"json:1", "media:1-2", "log:0+", "uppercase"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name       string
+		template   string
+		wantErrors []string
+	}{
+		{
+			name:     "custom helpers within their arity",
+			template: `{{json data}}{{media url "image/png"}}{{log}}{{uppercase a b c}}`,
+		},
+		{
+			name:       "missing required parameter",
+			template:   `{{#json}}x{{/json}}`,
+			wantErrors: []string{"json helper expects 1 parameter(s), got 0"},
+		},
+		{
+			name:       "too many parameters",
+			template:   `{{media url "image/png" "extra"}}`,
+			wantErrors: []string{"media helper expects 1 to 2 parameter(s), got 3"},
+		},
+		{
+			name:       "unregistered helper",
+			template:   `{{format date "short"}}`,
+			wantErrors: []string{"Unknown helper function 'format'"},
+		},
+		{
+			name:       "built-in helpers stay validated",
+			template:   `{{role "robot"}}{{#each}}x{{/each}}`,
+			wantErrors: []string{"Invalid role 'robot'. Valid roles: system, user, assistant", "each helper requires a collection parameter"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHandlebarsTemplate(tt.template)
+			assert.True(t, result.Valid, "Expected valid template, got errors: %v", result.Errors)
+
+			var messages []string
+			for _, err := range ValidateHelpers(result.Helpers, result.BlockHelpers, knownHelpers) {
+				messages = append(messages, err.Message)
+			}
+
+			assert.Equal(t, tt.wantErrors, messages)
+		})
+	}
+}
+
+func TestParseHelperSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		wantName string
+		wantSpec HelperSpec
+		wantErr  string
+	}{
+		{spec: "json", wantName: "json", wantSpec: HelperSpec{MaxParams: -1}},
+		{spec: "json:1", wantName: "json", wantSpec: HelperSpec{MinParams: 1, MaxParams: 1}},
+		{spec: "media:1-2", wantName: "media", wantSpec: HelperSpec{MinParams: 1, MaxParams: 2}},
+		{spec: "log:0+", wantName: "log", wantSpec: HelperSpec{MaxParams: -1}},
+		{spec: ":1", wantErr: `invalid helper ":1"`},
+		{spec: "json:x", wantErr: `invalid helper arity "json:x"`},
+		{spec: "media:2-1", wantErr: `invalid helper arity "media:2-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			name, spec, err := ParseHelperSpec(tt.spec)
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantSpec, spec)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Package dotpromptgen generates Go (or Zod) models from dotprompt files in memory, for build
+// tools that embed the generator instead of running the dotprompt-gen-go binary.
+package dotpromptgen
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/oter/dotprompt-gen-go/internal/codegen"
+	"github.com/oter/dotprompt-gen-go/internal/generator"
+	"github.com/oter/dotprompt-gen-go/internal/template"
+)
+
+// DefaultPackageName is the package name used when Options.PackageName is empty.
+const DefaultPackageName = "models"
+
+// Options configures a generation run. Field names mirror the dotprompt-gen-go flags and the
+// zero value produces the same output as running the binary without flags, except for the package
+// name: the binary names the package after the output directory, which in-memory generation does
+// not have, so it defaults to DefaultPackageName.
+type Options struct {
+	Files   []string          // paths of .prompt files to read
+	Prompts map[string]string // in-memory prompts: file name (e.g. "classify.prompt") -> content
+
+	PackageName         string            // package of the generated files (default "models")
+	Language            string            // "go" (default) or "zod"
+	ErrorTypes          bool              // -error-types
+	Reset               bool              // -reset
+	EnumBaseType        string            // -enum-base-type (default "string")
+	KeepGoing           bool              // -keep-going
+	GoBuildCheck        bool              // -go-build-check
+	ExampleStructs      bool              // -example-structs
+	MaxLineLength       int               // -max-line-length: 0 uses the default of 120, negative disables wrapping
+	ValidateAll         bool              // -validate-all
+	StrictEnums         bool              // -strict-enums
+	InputSuffix         string            // -input-suffix (default "Input")
+	OutputSuffix        string            // -output-suffix (default "Output")
+	AllowDuplicateTypes bool              // -allow-duplicate-types
+	Constructors        bool              // -constructors
+	NoTimeTypes         bool              // -no-time-types
+	NoOmitEmpty         bool              // -no-omitempty
+	ExperimentalUnions  bool              // -experimental-unions
+	StructValidate      bool              // -struct-validate
+	StrictEnumNames     bool              // -strict-enum-names
+	NoInitialisms       bool              // -no-initialisms
+	ConstraintTags      bool              // -constraint-tags
+	SingleFile          string            // -single-file: merge every prompt into one file with this name
+	DedupeStructs       bool              // -dedupe-structs
+	Getters             bool              // -getters
+	StrictTemplate      bool              // -strict-template
+	EmitRender          bool              // -emit-render
+	Strict              bool              // -strict
+	IgnoreTitle         bool              // -ignore-title
+	Imports             []string          // -import: extra import paths of every generated Go file
+	EmitTests           bool              // -emit-tests: also return a "<name>.gen_test.go" entry per file
+	Template            string            // -template: text/template source replacing the built-in Go template
+	Helpers             []string          // -helper: custom template helpers as name[:arity], e.g. "json:1"
+	EmitMetadata        bool              // -emit-metadata
+	EmitIsZero          bool              // -emit-iszero
+	AllRequired         bool              // -all-required
+	NoGofmt             bool              // -gofmt=false
+	PrefixEnums         bool              // -prefix-enums
+	SchemaExamples      bool              // -emit-examples
+	MergeEnums          bool              // -merge-enums
+	SplitEnums          bool              // -split-enums: enums get their own "<name>.enums.gen.go" entry
+	OptionalStyle       string            // -optional-style: "pointer" (default) or "wrapper"
+	TypeMappings        map[string]string // type_mappings from the config file, e.g. "number": "float32"
+	Initialisms         []string          // initialisms from the config file, added to the defaults
+}
+
+// Generate renders every prompt in opts and returns the generated source keyed by output file
+// name, e.g. "classify.gen.go". Nothing is written to disk; prompts without a schema produce no file.
+func Generate(opts Options) (map[string][]byte, error) {
+	if len(opts.Files) == 0 && len(opts.Prompts) == 0 {
+		return nil, errors.New("no prompts to generate: set Files or Prompts")
+	}
+
+	sources := make([]generator.PromptSource, 0, len(opts.Files)+len(opts.Prompts))
+	for _, path := range opts.Files {
+		sources = append(sources, generator.PromptSource{Path: path})
+	}
+
+	// Sort in-memory prompts so errors and duplicate checks are reported deterministically
+	names := make([]string, 0, len(opts.Prompts))
+	for name := range opts.Prompts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if opts.Prompts[name] == "" {
+			return nil, fmt.Errorf("prompt %s is empty", name)
+		}
+
+		sources = append(sources, generator.PromptSource{Path: name, Content: opts.Prompts[name]})
+	}
+
+	helpers, err := template.ParseHelperSpecs(opts.Helpers)
+	if err != nil {
+		return nil, err
+	}
+
+	g := opts.generator()
+	g.Helpers = helpers
+
+	generated, err := generator.RenderPrompts(g, sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate: %w", err)
+	}
+
+	return generated, nil
+}
+
+// GenerateFromSchema generates Go types for a bare JSON Schema that is not wrapped in a prompt
+// file, declaring the root object as typeName in package pkg (default "models").
+func GenerateFromSchema(schema map[string]any, typeName, pkg string) ([]byte, error) {
+	code, err := generator.GenerateFromSchema(Options{PackageName: pkg}.generator(), schema, typeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s: %w", typeName, err)
+	}
+
+	return code, nil
+}
+
+// generator converts the options into the internal generator configuration.
+func (opts Options) generator() codegen.Generator {
+	g := codegen.Generator{
+		PackageName:         opts.PackageName,
+		ErrorTypes:          opts.ErrorTypes,
+		Reset:               opts.Reset,
+		EnumBase:            opts.EnumBaseType,
+		KeepGoing:           opts.KeepGoing,
+		Language:            opts.Language,
+		BuildCheck:          opts.GoBuildCheck,
+		Examples:            opts.ExampleStructs,
+		MaxLineLength:       opts.MaxLineLength,
+		ValidateAll:         opts.ValidateAll,
+		TypeMappings:        opts.TypeMappings,
+		StrictEnums:         opts.StrictEnums,
+		InputSuffix:         opts.InputSuffix,
+		OutputSuffix:        opts.OutputSuffix,
+		AllowDuplicateTypes: opts.AllowDuplicateTypes,
+		Constructors:        opts.Constructors,
+		NoTimeTypes:         opts.NoTimeTypes,
+		NoOmitEmpty:         opts.NoOmitEmpty,
+		ExperimentalUnions:  opts.ExperimentalUnions,
+		StructValidate:      opts.StructValidate,
+		StrictEnumNames:     opts.StrictEnumNames,
+		NoInitialisms:       opts.NoInitialisms,
+		ConstraintTags:      opts.ConstraintTags,
+		SingleFile:          opts.SingleFile,
+		DedupeStructs:       opts.DedupeStructs,
+		Getters:             opts.Getters,
+		StrictTemplate:      opts.StrictTemplate,
+		EmitRender:          opts.EmitRender,
+		Strict:              opts.Strict,
+		IgnoreTitle:         opts.IgnoreTitle,
+		Imports:             opts.Imports,
+		EmitTests:           opts.EmitTests,
+		Template:            opts.Template,
+		EmitMetadata:        opts.EmitMetadata,
+		EmitIsZero:          opts.EmitIsZero,
+		AllRequired:         opts.AllRequired,
+		NoGofmt:             opts.NoGofmt,
+		PrefixEnums:         opts.PrefixEnums,
+		SchemaExamples:      opts.SchemaExamples,
+		MergeEnums:          opts.MergeEnums,
+		SplitEnums:          opts.SplitEnums,
+		OptionalStyle:       opts.OptionalStyle,
+		Initialisms:         opts.Initialisms,
+	}
+
+	if g.PackageName == "" {
+		g.PackageName = DefaultPackageName
+	}
+
+	// The flag defaults of the binary, so the input hash in the header matches its output too
+	g.Language = cmp.Or(g.Language, generator.LanguageGo)
+	g.EnumBase = cmp.Or(g.EnumBase, "string")
+	g.InputSuffix = cmp.Or(g.InputSuffix, generator.DefaultInputSuffix)
+	g.OutputSuffix = cmp.Or(g.OutputSuffix, generator.DefaultOutputSuffix)
+	g.OptionalStyle = cmp.Or(g.OptionalStyle, generator.OptionalStylePointer)
+
+	switch {
+	case g.MaxLineLength == 0:
+		g.MaxLineLength = generator.DefaultMaxLineLength
+	case g.MaxLineLength < 0:
+		g.MaxLineLength = 0
+	}
+
+	return g
+}
@@ -0,0 +1,70 @@
+package dotpromptgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateFromFilesAndContent tests that files and in-memory prompts are generated together
+func TestGenerateFromFilesAndContent(t *testing.T) {
+	files, err := Generate(Options{
+		Files:          []string{filepath.Join("..", "..", "internal", "integration_tests", "prompts", "simple_types.prompt")},
+		Prompts:        map[string]string{"review.prompt": "---\noutput:\n  schema:\n    verdict: string\n---\nReview.\n"},
+		ExampleStructs: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Contains(t, string(files["simple_types.gen.go"]), "package models")
+	assert.Contains(t, string(files["simple_types.gen.go"]), "func ExampleSimpleTypesInput()")
+	assert.Contains(t, string(files["review.gen.go"]), "type ReviewOutput struct")
+}
+
+// TestGenerateReportsDuplicateTypes tests that prompts declaring the same type names are rejected
+func TestGenerateReportsDuplicateTypes(t *testing.T) {
+	prompt := "---\noutput:\n  schema:\n    type: object\n    properties:\n      priority:\n        type: string\n        enum: [low, high]\n---\nRate.\n"
+
+	_, err := Generate(Options{Prompts: map[string]string{"a.prompt": prompt, "b.prompt": prompt}})
+	require.ErrorContains(t, err, "duplicate generated type names")
+
+	files, err := Generate(Options{Prompts: map[string]string{"a.prompt": prompt, "b.prompt": prompt}, AllowDuplicateTypes: true})
+	require.NoError(t, err)
+	assert.Len(t, files, 3) // a.gen.go, b.gen.go and enum_errors.gen.go
+
+	_, err = Generate(Options{})
+	require.Error(t, err)
+}
+
+// TestZeroOptionsMatchBinary tests that zero options generate what the binary generates without
+// flags, apart from the package named after the output directory
+func TestZeroOptionsMatchBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the binary")
+	}
+
+	prompt, err := os.ReadFile(filepath.Join("..", "..", "internal", "integration_tests", "prompts", "comprehensive_enums.prompt"))
+	require.NoError(t, err)
+
+	promptFile := filepath.Join(t.TempDir(), "review", "comprehensive_enums.prompt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(promptFile), 0o750))
+	require.NoError(t, os.WriteFile(promptFile, prompt, 0o600))
+
+	output, err := exec.Command("go", "run", "../../cmd/dotprompt-gen-go", "-file", promptFile).CombinedOutput() //nolint:gosec // fixed test command
+	require.NoError(t, err, string(output))
+
+	binaryCode, err := os.ReadFile(filepath.Join(filepath.Dir(promptFile), "comprehensive_enums.gen.go"))
+	require.NoError(t, err)
+
+	files, err := Generate(Options{Files: []string{promptFile}})
+	require.NoError(t, err)
+	assert.Contains(t, string(files["comprehensive_enums.gen.go"]), "package "+DefaultPackageName)
+
+	files, err = Generate(Options{Files: []string{promptFile}, PackageName: "review"})
+	require.NoError(t, err)
+	assert.Equal(t, string(binaryCode), string(files["comprehensive_enums.gen.go"]))
+}
@@ -0,0 +1,51 @@
+package dotpromptgen_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oter/dotprompt-gen-go/pkg/dotpromptgen"
+)
+
+func ExampleGenerate() {
+	files, err := dotpromptgen.Generate(dotpromptgen.Options{
+		PackageName: "prompts",
+		Prompts: map[string]string{
+			"greet.prompt": "---\ninput:\n  schema:\n    name: string, who to greet\n---\nHello {{name}}!\n",
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	code := string(files["greet.gen.go"])
+	fmt.Println(strings.Contains(code, "package prompts"))
+	fmt.Println(strings.Contains(code, "type GreetInput struct"))
+	// Output:
+	// true
+	// true
+}
+
+func ExampleGenerateFromSchema() {
+	code, err := dotpromptgen.GenerateFromSchema(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"sku":      map[string]any{"type": "string"},
+			"quantity": map[string]any{"type": "integer"},
+		},
+		"required": []any{"sku", "quantity"},
+	}, "LineItem", "orders")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(strings.Contains(string(code), "package orders"))
+	fmt.Println(strings.Contains(string(code), "type LineItem struct"))
+	// Output:
+	// true
+	// true
+}
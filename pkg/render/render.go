@@ -0,0 +1,80 @@
+// Package render executes dotprompt templates for the Render() methods generated with -emit-render.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/aymerick/raymond"
+)
+
+// templates caches parsed templates by source, so each prompt is parsed once.
+var templates sync.Map //nolint:gochecknoglobals // parse cache shared by all generated Render() methods
+
+// Execute renders a dotprompt handlebars template with input. The input is encoded to JSON first,
+// so template variables resolve by json field name. As in dotprompt, values are not HTML-escaped
+// and {{role "user"}} emits the <<<dotprompt:role:user>>> marker splitting messages.
+func Execute(source string, input any) (string, error) {
+	tpl, err := parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode template input: %w", err)
+	}
+
+	var context any
+	if err := json.Unmarshal(data, &context); err != nil {
+		return "", fmt.Errorf("failed to decode template input: %w", err)
+	}
+
+	rendered, err := tpl.Exec(unescaped(context))
+	if err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return rendered, nil
+}
+
+// parse returns the parsed template for source with the dotprompt helpers registered.
+func parse(source string) (*raymond.Template, error) {
+	if cached, ok := templates.Load(source); ok {
+		if tpl, ok := cached.(*raymond.Template); ok {
+			return tpl, nil
+		}
+	}
+
+	tpl, err := raymond.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	tpl.RegisterHelper("role", func(role string) raymond.SafeString {
+		return raymond.SafeString("<<<dotprompt:role:" + role + ">>>")
+	})
+
+	templates.Store(source, tpl)
+
+	return tpl, nil
+}
+
+// unescaped marks every string of a decoded JSON value as safe, so raymond leaves it unescaped.
+func unescaped(value any) any {
+	switch typed := value.(type) {
+	case string:
+		return raymond.SafeString(typed)
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = unescaped(item)
+		}
+	case []any:
+		for i, item := range typed {
+			typed[i] = unescaped(item)
+		}
+	}
+
+	return value
+}
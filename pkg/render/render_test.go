@@ -0,0 +1,46 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ticket struct {
+	Title    string   `json:"title"`
+	Labels   []string `json:"labels"`
+	Priority int      `json:"priority"`
+	Reporter struct {
+		Name string `json:"name"`
+	} `json:"reporter"`
+}
+
+func TestExecute(t *testing.T) {
+	input := ticket{Title: "Login <fails> & crashes", Labels: []string{"bug", "auth"}, Priority: 2}
+	input.Reporter.Name = "Sam"
+
+	rendered, err := Execute(`{{role "system"}}Triage tickets.
+{{role "user"}}{{title}} (p{{priority}}) by {{reporter.name}}: {{#each labels}}[{{this}}]{{/each}}`, input)
+	require.NoError(t, err)
+
+	assert.Equal(t, "<<<dotprompt:role:system>>>Triage tickets.\n"+
+		"<<<dotprompt:role:user>>>Login <fails> & crashes (p2) by Sam: [bug][auth]", rendered)
+}
+
+func TestExecuteReusesParsedTemplate(t *testing.T) {
+	const source = "Hello {{name}}"
+
+	first, err := Execute(source, map[string]string{"name": "Ada"})
+	require.NoError(t, err)
+	second, err := Execute(source, map[string]string{"name": "Grace"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello Ada", first)
+	assert.Equal(t, "Hello Grace", second)
+}
+
+func TestExecuteInvalidTemplate(t *testing.T) {
+	_, err := Execute("{{#each items}}", nil)
+	require.ErrorContains(t, err, "failed to parse template")
+}